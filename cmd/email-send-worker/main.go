@@ -11,6 +11,7 @@ import (
 	"github.com/ponyo877/prime-checker/internal/emailsend/repository"
 	"github.com/ponyo877/prime-checker/internal/emailsend/usecase"
 	"github.com/ponyo877/prime-checker/internal/shared/config"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
 	"github.com/ponyo877/prime-checker/internal/shared/infrastructure"
 )
 
@@ -24,10 +25,19 @@ func main() {
 	defer infrastructure.ShutdownTracing(tp)
 
 	// Load configurations
+	dbConfig := config.LoadDatabaseConfig()
 	msgConfig := config.LoadMessagingConfig()
+	subscriptionConfig := config.LoadSubscriptionConfig()
 
 	// Initialize infrastructure
-	natsBroker, err := infrastructure.NewMessageBroker(msgConfig)
+	db, err := infrastructure.NewDatabaseConnection(dbConfig)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+	queries := infrastructure.NewQueries(db)
+
+	natsBroker, err := infrastructure.NewMessageBroker(msgConfig, infrastructure.NewDeadLetterWriter(queries))
 	if err != nil {
 		log.Fatal("Failed to connect to NATS:", err)
 	}
@@ -39,7 +49,7 @@ func main() {
 	username := os.Getenv("SMTP_USERNAME")
 
 	emailRepo := repository.NewEmailRepository(smtpHost, smtpPort, username)
-	emailUsecase := usecase.NewEmailSendUsecase(emailRepo)
+	emailUsecase := usecase.NewEmailSendUsecase(emailRepo, faultinjection.NewInjector(queries))
 	worker := adapter.NewEmailSendWorker(emailUsecase)
 
 	// Setup graceful shutdown
@@ -57,7 +67,7 @@ func main() {
 	}()
 
 	log.Println("Starting email send worker...")
-	if err := natsBroker.Subscribe(ctx, "emailsend", worker.HandleMessage); err != nil && err != context.Canceled {
+	if err := natsBroker.Subscribe(ctx, "emailsend", subscriptionConfig, worker.HandleMessage); err != nil && err != context.Canceled {
 		log.Fatal("Email send worker failed:", err)
 	}
 
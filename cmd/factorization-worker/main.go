@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/adapter"
+	"github.com/ponyo877/prime-checker/internal/primecheck/repository"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+	"github.com/ponyo877/prime-checker/internal/shared/config"
+	"github.com/ponyo877/prime-checker/internal/shared/infrastructure"
+)
+
+func main() {
+	// Initialize tracing
+	tracingConfig := infrastructure.LoadTracingConfig("factorization-worker")
+	tp, err := infrastructure.InitTracing(tracingConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	defer infrastructure.ShutdownTracing(tp)
+
+	// Load configurations
+	dbConfig := config.LoadDatabaseConfig()
+	msgConfig := config.LoadMessagingConfig()
+	subscriptionConfig := config.LoadSubscriptionConfig()
+
+	// Initialize infrastructure
+	db, err := infrastructure.NewDatabaseConnection(dbConfig)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	natsBroker, err := infrastructure.NewMessageBroker(msgConfig, infrastructure.NewDeadLetterWriter(infrastructure.NewQueries(db)))
+	if err != nil {
+		log.Fatal("Failed to connect to NATS:", err)
+	}
+	defer natsBroker.Close()
+
+	// Create dependencies (DI)
+	primeCheckRepo := repository.NewPrimeCheckRepository(db)
+	factorizer := repository.NewPrimeFactorizer()
+	factorizationUsecase := usecase.NewFactorizationUsecase(factorizer, primeCheckRepo)
+	worker := adapter.NewFactorizationWorker(factorizationUsecase)
+
+	// Setup graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		log.Println("Received shutdown signal")
+		cancel()
+	}()
+
+	log.Println("Starting factorization worker...")
+	if err := natsBroker.Subscribe(ctx, "factorization", subscriptionConfig, worker.HandleMessage); err != nil && err != context.Canceled {
+		log.Fatal("Factorization worker failed:", err)
+	}
+
+	log.Println("Factorization worker shutdown complete")
+}
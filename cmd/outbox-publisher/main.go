@@ -34,7 +34,7 @@ func main() {
 	}
 	defer db.Close()
 
-	natsBroker, err := infrastructure.NewMessageBroker(msgConfig)
+	natsBroker, err := infrastructure.NewMessageBroker(msgConfig, nil)
 	if err != nil {
 		log.Fatal("Failed to connect to NATS:", err)
 	}
@@ -43,8 +43,9 @@ func main() {
 	// Create dependencies (DI)
 	queries := infrastructure.NewQueries(db)
 	outboxRepo := repository.NewOutboxRepository(queries)
+	primeCheckRepo := repository.NewPrimeCheckRepository(db)
 	messagePublisher := adapter.NewMessagePublisher(natsBroker)
-	outboxUsecase := usecase.NewOutboxPublishingUsecase(outboxRepo, messagePublisher)
+	outboxUsecase := usecase.NewOutboxPublishingUsecase(outboxRepo, primeCheckRepo, messagePublisher)
 	worker := adapter.NewOutboxWorker(outboxUsecase)
 
 	// Setup graceful shutdown
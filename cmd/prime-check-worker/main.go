@@ -8,9 +8,11 @@ import (
 	"syscall"
 
 	"github.com/ponyo877/prime-checker/internal/primecheck/adapter"
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
 	"github.com/ponyo877/prime-checker/internal/primecheck/repository"
 	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
 	"github.com/ponyo877/prime-checker/internal/shared/config"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
 	"github.com/ponyo877/prime-checker/internal/shared/infrastructure"
 )
 
@@ -26,6 +28,7 @@ func main() {
 	// Load configurations
 	dbConfig := config.LoadDatabaseConfig()
 	msgConfig := config.LoadMessagingConfig()
+	subscriptionConfig := config.LoadSubscriptionConfig()
 
 	// Initialize infrastructure
 	db, err := infrastructure.NewDatabaseConnection(dbConfig)
@@ -33,20 +36,21 @@ func main() {
 		log.Fatal("Failed to connect to database:", err)
 	}
 	defer db.Close()
+	queries := infrastructure.NewQueries(db)
 
-	natsBroker, err := infrastructure.NewMessageBroker(msgConfig)
+	natsBroker, err := infrastructure.NewMessageBroker(msgConfig, infrastructure.NewDeadLetterWriter(queries))
 	if err != nil {
 		log.Fatal("Failed to connect to NATS:", err)
 	}
 	defer natsBroker.Close()
 
 	// Create dependencies (DI)
-	queries := infrastructure.NewQueries(db)
 	outboxRepo := repository.NewOutboxRepository(queries)
 	primeCheckRepo := repository.NewPrimeCheckRepository(db)
-	calculator := repository.NewPrimeCalculator()
+	calculator := repository.NewPrimeCalculator(model.NewDefaultPrimalityTestRegistry())
+	certifier := repository.NewPrimeCertifier()
 	publisher := repository.NewResultPublisher(outboxRepo)
-	primeUsecase := usecase.NewPrimeCheckUsecase(calculator, publisher, primeCheckRepo)
+	primeUsecase := usecase.NewPrimeCheckUsecase(calculator, certifier, publisher, primeCheckRepo, faultinjection.NewInjector(queries), config.LoadWorkerID())
 	worker := adapter.NewPrimeCheckWorker(primeUsecase)
 
 	// Setup graceful shutdown
@@ -64,7 +68,7 @@ func main() {
 	}()
 
 	log.Println("Starting prime check worker...")
-	if err := natsBroker.Subscribe(ctx, "primecheck", worker.HandleMessage); err != nil && err != context.Canceled {
+	if err := natsBroker.Subscribe(ctx, "primecheck", subscriptionConfig, worker.HandleMessage); err != nil && err != context.Canceled {
 		log.Fatal("Prime check worker failed:", err)
 	}
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -28,6 +29,7 @@ func main() {
 
 	// Load configurations
 	dbConfig := config.LoadDatabaseConfig()
+	msgConfig := config.LoadMessagingConfig()
 
 	// Initialize infrastructure
 	db, err := infrastructure.NewDatabaseConnection(dbConfig)
@@ -36,10 +38,19 @@ func main() {
 	}
 	defer db.Close()
 
+	// The web server only watches result events, so it needs no dead letter queue
+	natsBroker, err := infrastructure.NewMessageBroker(msgConfig, nil)
+	if err != nil {
+		log.Fatal("Failed to connect to NATS:", err)
+	}
+	defer natsBroker.Close()
+
 	repo := repository.NewRepository(db)
-	uc := usecase.NewUseCase(repo)
+	events := repository.NewPrimeCheckResultEventRepository(natsBroker)
+	uc := usecase.NewUseCase(repo, events)
+	go uc.RunPrimeCheckNotifier(context.Background())
 	h := adapter.NewHandler(uc)
-	srv, err := openapi.NewServer(h)
+	srv, err := openapi.NewServer(h, h, openapi.WithErrorHandler(h.HandleError))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -49,7 +60,7 @@ func main() {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Last-Event-ID, Idempotency-Key")
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
@@ -61,7 +72,7 @@ func main() {
 	}
 
 	// Wrap server with CORS and OpenTelemetry instrumentation
-	handler := corsHandler(otelhttp.NewHandler(srv, "web-server"))
+	handler := corsHandler(otelhttp.NewHandler(adapter.FlushEventStreams(srv), "web-server"))
 
 	httpPort := ":8080"
 	fmt.Printf("Starting web server on %s\n", httpPort)
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ponyo877/prime-checker/internal/shared/config"
+	"github.com/ponyo877/prime-checker/internal/shared/infrastructure"
+	"github.com/ponyo877/prime-checker/internal/webhook/adapter"
+	"github.com/ponyo877/prime-checker/internal/webhook/repository"
+	"github.com/ponyo877/prime-checker/internal/webhook/usecase"
+)
+
+func main() {
+	// Initialize tracing
+	tracingConfig := infrastructure.LoadTracingConfig("webhook-delivery-worker")
+	tp, err := infrastructure.InitTracing(tracingConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	defer infrastructure.ShutdownTracing(tp)
+
+	// Load configurations
+	dbConfig := config.LoadDatabaseConfig()
+	msgConfig := config.LoadMessagingConfig()
+	subscriptionConfig := config.LoadSubscriptionConfig()
+
+	// Initialize infrastructure
+	db, err := infrastructure.NewDatabaseConnection(dbConfig)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	natsBroker, err := infrastructure.NewMessageBroker(msgConfig, infrastructure.NewDeadLetterWriter(infrastructure.NewQueries(db)))
+	if err != nil {
+		log.Fatal("Failed to connect to NATS:", err)
+	}
+	defer natsBroker.Close()
+
+	// Create dependencies (DI)
+	webhookRepo := repository.NewWebhookRepository(db)
+	sender := repository.NewWebhookSender()
+	webhookUsecase := usecase.NewWebhookDeliveryUsecase(sender, webhookRepo)
+	worker := adapter.NewWebhookDeliveryWorker(webhookUsecase)
+
+	// Setup graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		log.Println("Received shutdown signal")
+		cancel()
+	}()
+
+	log.Println("Starting webhook delivery worker...")
+	if err := natsBroker.Subscribe(ctx, "webhookdelivery", subscriptionConfig, worker.HandleMessage); err != nil && err != context.Canceled {
+		log.Fatal("Webhook delivery worker failed:", err)
+	}
+
+	log.Println("Webhook delivery worker shutdown complete")
+}
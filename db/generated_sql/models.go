@@ -10,6 +10,25 @@ import (
 	"time"
 )
 
+type DeadLetterMessage struct {
+	ID            int32
+	Subject       string
+	MessageID     sql.NullString
+	Payload       json.RawMessage
+	ErrorMessage  string
+	DeliveryCount int32
+	CreatedAt     time.Time
+}
+
+type IdempotencyKey struct {
+	ID                 int32
+	UserID             int32
+	IdempotencyKey     string
+	RequestFingerprint string
+	PrimeCheckID       int32
+	CreatedAt          time.Time
+}
+
 type Outbox struct {
 	ID        int32
 	EventType string
@@ -19,21 +38,136 @@ type Outbox struct {
 	UpdatedAt time.Time
 }
 
+type PrimeCertificate struct {
+	PrimeCheckID int32
+	Method       string
+	Certificate  json.RawMessage
+	CreatedAt    time.Time
+}
+
+type PrimeCheckBatch struct {
+	ID           int32
+	UserID       int32
+	SourceFormat sql.NullString
+	UploadStatus sql.NullString
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type PrimeCheckEvent struct {
+	ID           int32
+	PrimeCheckID int32
+	FromStatus   sql.NullString
+	ToStatus     string
+	Actor        string
+	TraceID      sql.NullString
+	CreatedAt    time.Time
+}
+
 type PrimeCheck struct {
+	ID                  int32
+	UserID              int32
+	BatchID             sql.NullInt32
+	NumberText          string
+	NumberHash          sql.NullString
+	BitLength           sql.NullInt32
+	DigitLength         sql.NullInt32
+	Expression          sql.NullString
+	Operation           sql.NullString
+	Accuracy            sql.NullString
+	CallbackUrl         sql.NullString
+	RequestedAlgorithm  sql.NullString
+	TimeoutSeconds      sql.NullInt32
+	TraceID             sql.NullString
+	MessageID           sql.NullString
+	IsPrime             sql.NullBool
+	Algorithm           sql.NullString
+	AlgorithmVersion    sql.NullString
+	AlgorithmParams     json.RawMessage
+	FoundPrime          sql.NullString
+	PrimeGap            sql.NullInt64
+	Confidence          sql.NullString
+	ErrorBound          sql.NullFloat64
+	WorkerID            sql.NullString
+	StartedAt           sql.NullTime
+	FinishedAt          sql.NullTime
+	CalculationUs       sql.NullInt64
+	Status              sql.NullString
+	CertificateStatus   sql.NullString
+	FactorizationStatus sql.NullString
+	Cached              bool
+	Attempt             int32
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type PrimeFactorization struct {
+	PrimeCheckID int32
+	Factors      json.RawMessage
+	Unfactored   sql.NullString
+	CreatedAt    time.Time
+}
+
+type PrimeRangeChunk struct {
+	PrimeRangeID  int32
+	FirstPosition int64
+	EndPosition   int64
+	Primes        json.RawMessage
+	CreatedAt     time.Time
+}
+
+type PrimeRange struct {
 	ID         int32
 	UserID     int32
-	NumberText string
-	TraceID    sql.NullString
-	MessageID  sql.NullString
-	IsPrime    sql.NullBool
+	RangeStart int64
+	RangeEnd   int64
+	CountOnly  bool
+	PrimeCount sql.NullInt64
 	Status     sql.NullString
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
 
+type PrimeResultCache struct {
+	NumberHash       string
+	Accuracy         string
+	NumberText       string
+	IsPrime          bool
+	Algorithm        sql.NullString
+	AlgorithmVersion sql.NullString
+	AlgorithmParams  json.RawMessage
+	Confidence       sql.NullString
+	ErrorBound       sql.NullFloat64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type Setting struct {
+	ID                  int32
+	RecordNumberSuccess bool
+	PrimeCheckSuccess   bool
+	EmailSendSuccess    bool
+	DlqSaveSuccess      bool
+	UpdatedAt           time.Time
+}
+
 type User struct {
-	ID        int32
-	AuthToken string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            int32
+	AuthTokenHash string
+	Role          string
+	WebhookSecret sql.NullString
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type WebhookDelivery struct {
+	ID           int32
+	PrimeCheckID int32
+	Url          string
+	Attempt      int32
+	StatusCode   sql.NullInt32
+	ErrorMessage sql.NullString
+	Success      bool
+	DurationUs   int64
+	CreatedAt    time.Time
 }
@@ -9,8 +9,135 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"strings"
 )
 
+const countPrimeChecksByBatchStatus = `-- name: CountPrimeChecksByBatchStatus :many
+SELECT
+    status,
+    COUNT(*) AS count
+FROM prime_checks
+WHERE
+    batch_id = ?
+GROUP BY status
+`
+
+type CountPrimeChecksByBatchStatusRow struct {
+	Status sql.NullString
+	Count  int64
+}
+
+func (q *Queries) CountPrimeChecksByBatchStatus(ctx context.Context, batchID sql.NullInt32) ([]CountPrimeChecksByBatchStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, countPrimeChecksByBatchStatus, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountPrimeChecksByBatchStatusRow
+	for rows.Next() {
+		var i CountPrimeChecksByBatchStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createCachedPrimeCheck = `-- name: CreateCachedPrimeCheck :execresult
+INSERT INTO prime_checks (user_id, batch_id, number_text, number_hash, bit_length, expression, operation, accuracy, callback_url, is_prime, algorithm, algorithm_version, algorithm_params, confidence, error_bound, factorization_status, status, cached) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'completed', TRUE)
+`
+
+type CreateCachedPrimeCheckParams struct {
+	UserID              int32
+	BatchID             sql.NullInt32
+	NumberText          string
+	NumberHash          sql.NullString
+	BitLength           sql.NullInt32
+	Expression          sql.NullString
+	Operation           sql.NullString
+	Accuracy            sql.NullString
+	CallbackUrl         sql.NullString
+	IsPrime             sql.NullBool
+	Algorithm           sql.NullString
+	AlgorithmVersion    sql.NullString
+	AlgorithmParams     json.RawMessage
+	Confidence          sql.NullString
+	ErrorBound          sql.NullFloat64
+	FactorizationStatus sql.NullString
+}
+
+func (q *Queries) CreateCachedPrimeCheck(ctx context.Context, arg CreateCachedPrimeCheckParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createCachedPrimeCheck,
+		arg.UserID,
+		arg.BatchID,
+		arg.NumberText,
+		arg.NumberHash,
+		arg.BitLength,
+		arg.Expression,
+		arg.Operation,
+		arg.Accuracy,
+		arg.CallbackUrl,
+		arg.IsPrime,
+		arg.Algorithm,
+		arg.AlgorithmVersion,
+		arg.AlgorithmParams,
+		arg.Confidence,
+		arg.ErrorBound,
+		arg.FactorizationStatus,
+	)
+}
+
+const createDeadLetterMessage = `-- name: CreateDeadLetterMessage :exec
+INSERT INTO dead_letter_messages (subject, message_id, payload, error_message, delivery_count) VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateDeadLetterMessageParams struct {
+	Subject       string
+	MessageID     sql.NullString
+	Payload       json.RawMessage
+	ErrorMessage  string
+	DeliveryCount int32
+}
+
+func (q *Queries) CreateDeadLetterMessage(ctx context.Context, arg CreateDeadLetterMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createDeadLetterMessage,
+		arg.Subject,
+		arg.MessageID,
+		arg.Payload,
+		arg.ErrorMessage,
+		arg.DeliveryCount,
+	)
+	return err
+}
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :exec
+INSERT INTO idempotency_keys (user_id, idempotency_key, request_fingerprint, prime_check_id) VALUES (?, ?, ?, ?)
+`
+
+type CreateIdempotencyKeyParams struct {
+	UserID             int32
+	IdempotencyKey     string
+	RequestFingerprint string
+	PrimeCheckID       int32
+}
+
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, createIdempotencyKey,
+		arg.UserID,
+		arg.IdempotencyKey,
+		arg.RequestFingerprint,
+		arg.PrimeCheckID,
+	)
+	return err
+}
+
 const createOutboxMessage = `-- name: CreateOutboxMessage :execresult
 INSERT INTO outbox (event_type, payload) VALUES (?, ?)
 `
@@ -24,28 +151,305 @@ func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessa
 	return q.db.ExecContext(ctx, createOutboxMessage, arg.EventType, arg.Payload)
 }
 
+const createPrimeCertificate = `-- name: CreatePrimeCertificate :exec
+INSERT INTO prime_certificates (prime_check_id, method, certificate) VALUES (?, ?, ?)
+`
+
+type CreatePrimeCertificateParams struct {
+	PrimeCheckID int32
+	Method       string
+	Certificate  json.RawMessage
+}
+
+func (q *Queries) CreatePrimeCertificate(ctx context.Context, arg CreatePrimeCertificateParams) error {
+	_, err := q.db.ExecContext(ctx, createPrimeCertificate, arg.PrimeCheckID, arg.Method, arg.Certificate)
+	return err
+}
+
 const createPrimeCheck = `-- name: CreatePrimeCheck :execresult
-INSERT INTO prime_checks (user_id, number_text, status) VALUES (?, ?, 'processing')
+INSERT INTO prime_checks (user_id, batch_id, number_text, number_hash, bit_length, expression, operation, accuracy, callback_url, requested_algorithm, timeout_seconds, status, certificate_status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'queued', ?)
 `
 
 type CreatePrimeCheckParams struct {
-	UserID     int32
-	NumberText string
+	UserID             int32
+	BatchID            sql.NullInt32
+	NumberText         string
+	NumberHash         sql.NullString
+	BitLength          sql.NullInt32
+	Expression         sql.NullString
+	Operation          sql.NullString
+	Accuracy           sql.NullString
+	CallbackUrl        sql.NullString
+	RequestedAlgorithm sql.NullString
+	TimeoutSeconds     sql.NullInt32
+	CertificateStatus  sql.NullString
 }
 
 func (q *Queries) CreatePrimeCheck(ctx context.Context, arg CreatePrimeCheckParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createPrimeCheck, arg.UserID, arg.NumberText)
+	return q.db.ExecContext(ctx, createPrimeCheck,
+		arg.UserID,
+		arg.BatchID,
+		arg.NumberText,
+		arg.NumberHash,
+		arg.BitLength,
+		arg.Expression,
+		arg.Operation,
+		arg.Accuracy,
+		arg.CallbackUrl,
+		arg.RequestedAlgorithm,
+		arg.TimeoutSeconds,
+		arg.CertificateStatus,
+	)
+}
+
+const createPrimeCheckBatch = `-- name: CreatePrimeCheckBatch :execresult
+INSERT INTO prime_check_batches (user_id, source_format, upload_status) VALUES (?, ?, ?)
+`
+
+type CreatePrimeCheckBatchParams struct {
+	UserID       int32
+	SourceFormat sql.NullString
+	UploadStatus sql.NullString
+}
+
+func (q *Queries) CreatePrimeCheckBatch(ctx context.Context, arg CreatePrimeCheckBatchParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createPrimeCheckBatch, arg.UserID, arg.SourceFormat, arg.UploadStatus)
+}
+
+const createPrimeCheckEvent = `-- name: CreatePrimeCheckEvent :exec
+INSERT INTO prime_check_events (
+    prime_check_id,
+    from_status,
+    to_status,
+    actor,
+    trace_id
+) VALUES (?, ?, ?, ?, ?)
+`
+
+type CreatePrimeCheckEventParams struct {
+	PrimeCheckID int32
+	FromStatus   sql.NullString
+	ToStatus     string
+	Actor        string
+	TraceID      sql.NullString
+}
+
+func (q *Queries) CreatePrimeCheckEvent(ctx context.Context, arg CreatePrimeCheckEventParams) error {
+	_, err := q.db.ExecContext(ctx, createPrimeCheckEvent,
+		arg.PrimeCheckID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Actor,
+		arg.TraceID,
+	)
+	return err
+}
+
+const createPrimeFactorization = `-- name: CreatePrimeFactorization :exec
+INSERT INTO prime_factorizations (prime_check_id, factors, unfactored) VALUES (?, ?, ?)
+`
+
+type CreatePrimeFactorizationParams struct {
+	PrimeCheckID int32
+	Factors      json.RawMessage
+	Unfactored   sql.NullString
+}
+
+func (q *Queries) CreatePrimeFactorization(ctx context.Context, arg CreatePrimeFactorizationParams) error {
+	_, err := q.db.ExecContext(ctx, createPrimeFactorization, arg.PrimeCheckID, arg.Factors, arg.Unfactored)
+	return err
+}
+
+const createPrimeRange = `-- name: CreatePrimeRange :execresult
+INSERT INTO prime_ranges (user_id, range_start, range_end, count_only, status) VALUES (?, ?, ?, ?, 'processing')
+`
+
+type CreatePrimeRangeParams struct {
+	UserID     int32
+	RangeStart int64
+	RangeEnd   int64
+	CountOnly  bool
+}
+
+func (q *Queries) CreatePrimeRange(ctx context.Context, arg CreatePrimeRangeParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createPrimeRange,
+		arg.UserID,
+		arg.RangeStart,
+		arg.RangeEnd,
+		arg.CountOnly,
+	)
+}
+
+const createPrimeRangeChunk = `-- name: CreatePrimeRangeChunk :exec
+INSERT INTO prime_range_chunks (prime_range_id, first_position, end_position, primes) VALUES (?, ?, ?, ?)
+`
+
+type CreatePrimeRangeChunkParams struct {
+	PrimeRangeID  int32
+	FirstPosition int64
+	EndPosition   int64
+	Primes        json.RawMessage
+}
+
+func (q *Queries) CreatePrimeRangeChunk(ctx context.Context, arg CreatePrimeRangeChunkParams) error {
+	_, err := q.db.ExecContext(ctx, createPrimeRangeChunk,
+		arg.PrimeRangeID,
+		arg.FirstPosition,
+		arg.EndPosition,
+		arg.Primes,
+	)
+	return err
+}
+
+const createUser = `-- name: CreateUser :execresult
+INSERT INTO users (auth_token_hash, webhook_secret) VALUES (?, ?)
+`
+
+type CreateUserParams struct {
+	AuthTokenHash string
+	WebhookSecret sql.NullString
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createUser, arg.AuthTokenHash, arg.WebhookSecret)
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    prime_check_id,
+    url,
+    attempt,
+    status_code,
+    error_message,
+    success,
+    duration_us
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateWebhookDeliveryParams struct {
+	PrimeCheckID int32
+	Url          string
+	Attempt      int32
+	StatusCode   sql.NullInt32
+	ErrorMessage sql.NullString
+	Success      bool
+	DurationUs   int64
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookDelivery,
+		arg.PrimeCheckID,
+		arg.Url,
+		arg.Attempt,
+		arg.StatusCode,
+		arg.ErrorMessage,
+		arg.Success,
+		arg.DurationUs,
+	)
+	return err
+}
+
+const deletePrimeRangeChunks = `-- name: DeletePrimeRangeChunks :exec
+DELETE FROM prime_range_chunks
+WHERE
+    prime_range_id = ?
+`
+
+func (q *Queries) DeletePrimeRangeChunks(ctx context.Context, primeRangeID int32) error {
+	_, err := q.db.ExecContext(ctx, deletePrimeRangeChunks, primeRangeID)
+	return err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT
+    id,
+    user_id,
+    idempotency_key,
+    request_fingerprint,
+    prime_check_id,
+    created_at
+FROM idempotency_keys
+WHERE
+    user_id = ?
+    AND idempotency_key = ?
+`
+
+type GetIdempotencyKeyParams struct {
+	UserID         int32
+	IdempotencyKey string
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.UserID, arg.IdempotencyKey)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.RequestFingerprint,
+		&i.PrimeCheckID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPrimeCertificate = `-- name: GetPrimeCertificate :one
+SELECT
+    prime_check_id,
+    method,
+    certificate,
+    created_at
+FROM prime_certificates
+WHERE
+    prime_check_id = ?
+`
+
+func (q *Queries) GetPrimeCertificate(ctx context.Context, primeCheckID int32) (PrimeCertificate, error) {
+	row := q.db.QueryRowContext(ctx, getPrimeCertificate, primeCheckID)
+	var i PrimeCertificate
+	err := row.Scan(
+		&i.PrimeCheckID,
+		&i.Method,
+		&i.Certificate,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
 const getPrimeCheck = `-- name: GetPrimeCheck :one
 SELECT
     id,
     user_id,
+    batch_id,
     number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
     trace_id,
     message_id,
     is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
     status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
     created_at,
     updated_at
 FROM prime_checks
@@ -59,10 +463,139 @@ func (q *Queries) GetPrimeCheck(ctx context.Context, id int32) (PrimeCheck, erro
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
+		&i.BatchID,
 		&i.NumberText,
+		&i.NumberHash,
+		&i.BitLength,
+		&i.DigitLength,
+		&i.Expression,
+		&i.Operation,
+		&i.Accuracy,
+		&i.CallbackUrl,
+		&i.RequestedAlgorithm,
+		&i.TimeoutSeconds,
 		&i.TraceID,
 		&i.MessageID,
 		&i.IsPrime,
+		&i.Algorithm,
+		&i.AlgorithmVersion,
+		&i.AlgorithmParams,
+		&i.FoundPrime,
+		&i.PrimeGap,
+		&i.Confidence,
+		&i.ErrorBound,
+		&i.WorkerID,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CalculationUs,
+		&i.Status,
+		&i.CertificateStatus,
+		&i.FactorizationStatus,
+		&i.Cached,
+		&i.Attempt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPrimeCheckBatch = `-- name: GetPrimeCheckBatch :one
+SELECT
+    id,
+    user_id,
+    source_format,
+    upload_status,
+    created_at,
+    updated_at
+FROM prime_check_batches
+WHERE
+    id = ?
+`
+
+func (q *Queries) GetPrimeCheckBatch(ctx context.Context, id int32) (PrimeCheckBatch, error) {
+	row := q.db.QueryRowContext(ctx, getPrimeCheckBatch, id)
+	var i PrimeCheckBatch
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SourceFormat,
+		&i.UploadStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPrimeCheckStatus = `-- name: GetPrimeCheckStatus :one
+SELECT
+    status,
+    attempt
+FROM prime_checks
+WHERE
+    id = ?
+`
+
+type GetPrimeCheckStatusRow struct {
+	Status  sql.NullString
+	Attempt int32
+}
+
+func (q *Queries) GetPrimeCheckStatus(ctx context.Context, id int32) (GetPrimeCheckStatusRow, error) {
+	row := q.db.QueryRowContext(ctx, getPrimeCheckStatus, id)
+	var i GetPrimeCheckStatusRow
+	err := row.Scan(&i.Status, &i.Attempt)
+	return i, err
+}
+
+const getPrimeFactorization = `-- name: GetPrimeFactorization :one
+SELECT
+    prime_check_id,
+    factors,
+    unfactored,
+    created_at
+FROM prime_factorizations
+WHERE
+    prime_check_id = ?
+`
+
+func (q *Queries) GetPrimeFactorization(ctx context.Context, primeCheckID int32) (PrimeFactorization, error) {
+	row := q.db.QueryRowContext(ctx, getPrimeFactorization, primeCheckID)
+	var i PrimeFactorization
+	err := row.Scan(
+		&i.PrimeCheckID,
+		&i.Factors,
+		&i.Unfactored,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPrimeRange = `-- name: GetPrimeRange :one
+SELECT
+    id,
+    user_id,
+    range_start,
+    range_end,
+    count_only,
+    prime_count,
+    status,
+    created_at,
+    updated_at
+FROM prime_ranges
+WHERE
+    id = ?
+`
+
+func (q *Queries) GetPrimeRange(ctx context.Context, id int32) (PrimeRange, error) {
+	row := q.db.QueryRowContext(ctx, getPrimeRange, id)
+	var i PrimeRange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RangeStart,
+		&i.RangeEnd,
+		&i.CountOnly,
+		&i.PrimeCount,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -70,6 +603,33 @@ func (q *Queries) GetPrimeCheck(ctx context.Context, id int32) (PrimeCheck, erro
 	return i, err
 }
 
+const getSettings = `-- name: GetSettings :one
+SELECT
+    id,
+    record_number_success,
+    prime_check_success,
+    email_send_success,
+    dlq_save_success,
+    updated_at
+FROM settings
+WHERE
+    id = 1
+`
+
+func (q *Queries) GetSettings(ctx context.Context) (Setting, error) {
+	row := q.db.QueryRowContext(ctx, getSettings)
+	var i Setting
+	err := row.Scan(
+		&i.ID,
+		&i.RecordNumberSuccess,
+		&i.PrimeCheckSuccess,
+		&i.EmailSendSuccess,
+		&i.DlqSaveSuccess,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getUnprocessedOutboxMessages = `-- name: GetUnprocessedOutboxMessages :many
 SELECT
     id,
@@ -114,38 +674,85 @@ func (q *Queries) GetUnprocessedOutboxMessages(ctx context.Context) ([]Outbox, e
 	return items, nil
 }
 
-const listPrimeChecks = `-- name: ListPrimeChecks :many
+const getUserByAuthTokenHash = `-- name: GetUserByAuthTokenHash :one
 SELECT
     id,
-    user_id,
+    auth_token_hash,
+    role,
+    webhook_secret,
+    created_at,
+    updated_at
+FROM users
+WHERE
+    auth_token_hash = ?
+`
+
+func (q *Queries) GetUserByAuthTokenHash(ctx context.Context, authTokenHash string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByAuthTokenHash, authTokenHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.AuthTokenHash,
+		&i.Role,
+		&i.WebhookSecret,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserWebhookSecret = `-- name: GetUserWebhookSecret :one
+SELECT
+    webhook_secret
+FROM users
+WHERE
+    id = ?
+`
+
+func (q *Queries) GetUserWebhookSecret(ctx context.Context, id int32) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getUserWebhookSecret, id)
+	var webhook_secret sql.NullString
+	err := row.Scan(&webhook_secret)
+	return webhook_secret, err
+}
+
+const listCachedPrimeResults = `-- name: ListCachedPrimeResults :many
+SELECT
+    number_hash,
+    accuracy,
     number_text,
-    trace_id,
-    message_id,
     is_prime,
-    status,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    confidence,
+    error_bound,
     created_at,
     updated_at
-FROM prime_checks
-ORDER BY created_at DESC
+FROM prime_result_cache
+WHERE
+    number_hash = ?
 `
 
-func (q *Queries) ListPrimeChecks(ctx context.Context) ([]PrimeCheck, error) {
-	rows, err := q.db.QueryContext(ctx, listPrimeChecks)
+func (q *Queries) ListCachedPrimeResults(ctx context.Context, numberHash string) ([]PrimeResultCache, error) {
+	rows, err := q.db.QueryContext(ctx, listCachedPrimeResults, numberHash)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []PrimeCheck
+	var items []PrimeResultCache
 	for rows.Next() {
-		var i PrimeCheck
+		var i PrimeResultCache
 		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
+			&i.NumberHash,
+			&i.Accuracy,
 			&i.NumberText,
-			&i.TraceID,
-			&i.MessageID,
 			&i.IsPrime,
-			&i.Status,
+			&i.Algorithm,
+			&i.AlgorithmVersion,
+			&i.AlgorithmParams,
+			&i.Confidence,
+			&i.ErrorBound,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -162,10 +769,681 @@ func (q *Queries) ListPrimeChecks(ctx context.Context) ([]PrimeCheck, error) {
 	return items, nil
 }
 
-const markOutboxMessageProcessed = `-- name: MarkOutboxMessageProcessed :exec
-UPDATE outbox
-SET
-    processed = TRUE,
+const listCachedPrimeResultsByHashes = `-- name: ListCachedPrimeResultsByHashes :many
+SELECT
+    number_hash,
+    accuracy,
+    number_text,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    confidence,
+    error_bound,
+    created_at,
+    updated_at
+FROM prime_result_cache
+WHERE
+    number_hash IN (/*SLICE:number_hashes*/?)
+`
+
+func (q *Queries) ListCachedPrimeResultsByHashes(ctx context.Context, numberHashes []string) ([]PrimeResultCache, error) {
+	query := listCachedPrimeResultsByHashes
+	var queryParams []interface{}
+	if len(numberHashes) > 0 {
+		for _, v := range numberHashes {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:number_hashes*/?", strings.Repeat(",?", len(numberHashes))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:number_hashes*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PrimeResultCache
+	for rows.Next() {
+		var i PrimeResultCache
+		if err := rows.Scan(
+			&i.NumberHash,
+			&i.Accuracy,
+			&i.NumberText,
+			&i.IsPrime,
+			&i.Algorithm,
+			&i.AlgorithmVersion,
+			&i.AlgorithmParams,
+			&i.Confidence,
+			&i.ErrorBound,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrimeCheckEvents = `-- name: ListPrimeCheckEvents :many
+SELECT
+    id,
+    prime_check_id,
+    from_status,
+    to_status,
+    actor,
+    trace_id,
+    created_at
+FROM prime_check_events
+WHERE
+    prime_check_id = ?
+ORDER BY id
+`
+
+func (q *Queries) ListPrimeCheckEvents(ctx context.Context, primeCheckID int32) ([]PrimeCheckEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listPrimeCheckEvents, primeCheckID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PrimeCheckEvent
+	for rows.Next() {
+		var i PrimeCheckEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.PrimeCheckID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Actor,
+			&i.TraceID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrimeCheckIDsByBatch = `-- name: ListPrimeCheckIDsByBatch :many
+SELECT
+    id
+FROM prime_checks
+WHERE
+    batch_id = ?
+    AND id >= ?
+ORDER BY id ASC
+LIMIT ?
+`
+
+type ListPrimeCheckIDsByBatchParams struct {
+	BatchID sql.NullInt32
+	ID      int32
+	Limit   int32
+}
+
+func (q *Queries) ListPrimeCheckIDsByBatch(ctx context.Context, arg ListPrimeCheckIDsByBatchParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listPrimeCheckIDsByBatch, arg.BatchID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrimeChecks = `-- name: ListPrimeChecks :many
+SELECT
+    id,
+    user_id,
+    batch_id,
+    number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
+    trace_id,
+    message_id,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
+    status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
+    created_at,
+    updated_at
+FROM prime_checks
+WHERE
+    (? IS NULL OR user_id = ?)
+    AND (? IS NULL OR status = ?)
+    AND (? IS NULL OR is_prime = ?)
+    AND (? IS NULL OR created_at >= ?)
+    AND (? IS NULL OR created_at < ?)
+    AND (? IS NULL OR digit_length >= ?)
+    AND (? IS NULL OR digit_length <= ?)
+    AND (? IS NULL OR number_text LIKE ?)
+    AND (
+        ? IS NULL
+        OR created_at < ?
+        OR (created_at = ? AND id < ?)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT ?
+`
+
+type ListPrimeChecksParams struct {
+	UserID          sql.NullInt32
+	Status          sql.NullString
+	IsPrime         sql.NullBool
+	CreatedFrom     sql.NullTime
+	CreatedTo       sql.NullTime
+	MinDigits       sql.NullInt32
+	MaxDigits       sql.NullInt32
+	NumberPattern   sql.NullString
+	CursorCreatedAt sql.NullTime
+	CursorID        sql.NullInt32
+	Limit           int32
+}
+
+func (q *Queries) ListPrimeChecks(ctx context.Context, arg ListPrimeChecksParams) ([]PrimeCheck, error) {
+	rows, err := q.db.QueryContext(ctx, listPrimeChecks,
+		arg.UserID,
+		arg.UserID,
+		arg.Status,
+		arg.Status,
+		arg.IsPrime,
+		arg.IsPrime,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.MinDigits,
+		arg.MinDigits,
+		arg.MaxDigits,
+		arg.MaxDigits,
+		arg.NumberPattern,
+		arg.NumberPattern,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PrimeCheck
+	for rows.Next() {
+		var i PrimeCheck
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.BatchID,
+			&i.NumberText,
+			&i.NumberHash,
+			&i.BitLength,
+			&i.DigitLength,
+			&i.Expression,
+			&i.Operation,
+			&i.Accuracy,
+			&i.CallbackUrl,
+			&i.RequestedAlgorithm,
+			&i.TimeoutSeconds,
+			&i.TraceID,
+			&i.MessageID,
+			&i.IsPrime,
+			&i.Algorithm,
+			&i.AlgorithmVersion,
+			&i.AlgorithmParams,
+			&i.FoundPrime,
+			&i.PrimeGap,
+			&i.Confidence,
+			&i.ErrorBound,
+			&i.WorkerID,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.CalculationUs,
+			&i.Status,
+			&i.CertificateStatus,
+			&i.FactorizationStatus,
+			&i.Cached,
+			&i.Attempt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrimeChecksAsc = `-- name: ListPrimeChecksAsc :many
+SELECT
+    id,
+    user_id,
+    batch_id,
+    number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
+    trace_id,
+    message_id,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
+    status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
+    created_at,
+    updated_at
+FROM prime_checks
+WHERE
+    (? IS NULL OR user_id = ?)
+    AND (? IS NULL OR status = ?)
+    AND (? IS NULL OR is_prime = ?)
+    AND (? IS NULL OR created_at >= ?)
+    AND (? IS NULL OR created_at < ?)
+    AND (? IS NULL OR digit_length >= ?)
+    AND (? IS NULL OR digit_length <= ?)
+    AND (? IS NULL OR number_text LIKE ?)
+    AND (
+        ? IS NULL
+        OR created_at > ?
+        OR (created_at = ? AND id > ?)
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT ?
+`
+
+type ListPrimeChecksAscParams struct {
+	UserID          sql.NullInt32
+	Status          sql.NullString
+	IsPrime         sql.NullBool
+	CreatedFrom     sql.NullTime
+	CreatedTo       sql.NullTime
+	MinDigits       sql.NullInt32
+	MaxDigits       sql.NullInt32
+	NumberPattern   sql.NullString
+	CursorCreatedAt sql.NullTime
+	CursorID        sql.NullInt32
+	Limit           int32
+}
+
+func (q *Queries) ListPrimeChecksAsc(ctx context.Context, arg ListPrimeChecksAscParams) ([]PrimeCheck, error) {
+	rows, err := q.db.QueryContext(ctx, listPrimeChecksAsc,
+		arg.UserID,
+		arg.UserID,
+		arg.Status,
+		arg.Status,
+		arg.IsPrime,
+		arg.IsPrime,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.MinDigits,
+		arg.MinDigits,
+		arg.MaxDigits,
+		arg.MaxDigits,
+		arg.NumberPattern,
+		arg.NumberPattern,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PrimeCheck
+	for rows.Next() {
+		var i PrimeCheck
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.BatchID,
+			&i.NumberText,
+			&i.NumberHash,
+			&i.BitLength,
+			&i.DigitLength,
+			&i.Expression,
+			&i.Operation,
+			&i.Accuracy,
+			&i.CallbackUrl,
+			&i.RequestedAlgorithm,
+			&i.TimeoutSeconds,
+			&i.TraceID,
+			&i.MessageID,
+			&i.IsPrime,
+			&i.Algorithm,
+			&i.AlgorithmVersion,
+			&i.AlgorithmParams,
+			&i.FoundPrime,
+			&i.PrimeGap,
+			&i.Confidence,
+			&i.ErrorBound,
+			&i.WorkerID,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.CalculationUs,
+			&i.Status,
+			&i.CertificateStatus,
+			&i.FactorizationStatus,
+			&i.Cached,
+			&i.Attempt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrimeChecksByBatchAfter = `-- name: ListPrimeChecksByBatchAfter :many
+SELECT
+    id,
+    user_id,
+    batch_id,
+    number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
+    trace_id,
+    message_id,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
+    status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
+    created_at,
+    updated_at
+FROM prime_checks
+WHERE
+    batch_id = ?
+    AND id > ?
+ORDER BY id ASC
+LIMIT ?
+`
+
+type ListPrimeChecksByBatchAfterParams struct {
+	BatchID sql.NullInt32
+	ID      int32
+	Limit   int32
+}
+
+func (q *Queries) ListPrimeChecksByBatchAfter(ctx context.Context, arg ListPrimeChecksByBatchAfterParams) ([]PrimeCheck, error) {
+	rows, err := q.db.QueryContext(ctx, listPrimeChecksByBatchAfter, arg.BatchID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PrimeCheck
+	for rows.Next() {
+		var i PrimeCheck
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.BatchID,
+			&i.NumberText,
+			&i.NumberHash,
+			&i.BitLength,
+			&i.DigitLength,
+			&i.Expression,
+			&i.Operation,
+			&i.Accuracy,
+			&i.CallbackUrl,
+			&i.RequestedAlgorithm,
+			&i.TimeoutSeconds,
+			&i.TraceID,
+			&i.MessageID,
+			&i.IsPrime,
+			&i.Algorithm,
+			&i.AlgorithmVersion,
+			&i.AlgorithmParams,
+			&i.FoundPrime,
+			&i.PrimeGap,
+			&i.Confidence,
+			&i.ErrorBound,
+			&i.WorkerID,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.CalculationUs,
+			&i.Status,
+			&i.CertificateStatus,
+			&i.FactorizationStatus,
+			&i.Cached,
+			&i.Attempt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrimeRangeChunks = `-- name: ListPrimeRangeChunks :many
+SELECT
+    prime_range_id,
+    first_position,
+    end_position,
+    primes,
+    created_at
+FROM prime_range_chunks
+WHERE
+    prime_range_id = ?
+    AND end_position > ?
+    AND first_position < ?
+ORDER BY first_position ASC
+`
+
+type ListPrimeRangeChunksParams struct {
+	PrimeRangeID  int32
+	EndPosition   int64
+	FirstPosition int64
+}
+
+func (q *Queries) ListPrimeRangeChunks(ctx context.Context, arg ListPrimeRangeChunksParams) ([]PrimeRangeChunk, error) {
+	rows, err := q.db.QueryContext(ctx, listPrimeRangeChunks, arg.PrimeRangeID, arg.EndPosition, arg.FirstPosition)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PrimeRangeChunk
+	for rows.Next() {
+		var i PrimeRangeChunk
+		if err := rows.Scan(
+			&i.PrimeRangeID,
+			&i.FirstPosition,
+			&i.EndPosition,
+			&i.Primes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveriesByPrimeCheck = `-- name: ListWebhookDeliveriesByPrimeCheck :many
+SELECT
+    id,
+    prime_check_id,
+    url,
+    attempt,
+    status_code,
+    error_message,
+    success,
+    duration_us,
+    created_at
+FROM webhook_deliveries
+WHERE
+    prime_check_id = ?
+ORDER BY id
+`
+
+func (q *Queries) ListWebhookDeliveriesByPrimeCheck(ctx context.Context, primeCheckID int32) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveriesByPrimeCheck, primeCheckID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.PrimeCheckID,
+			&i.Url,
+			&i.Attempt,
+			&i.StatusCode,
+			&i.ErrorMessage,
+			&i.Success,
+			&i.DurationUs,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPrimeCheckStatus = `-- name: LockPrimeCheckStatus :one
+SELECT
+    status
+FROM prime_checks
+WHERE
+    id = ?
+FOR UPDATE
+`
+
+func (q *Queries) LockPrimeCheckStatus(ctx context.Context, id int32) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, lockPrimeCheckStatus, id)
+	var status sql.NullString
+	err := row.Scan(&status)
+	return status, err
+}
+
+const markOutboxMessageProcessed = `-- name: MarkOutboxMessageProcessed :exec
+UPDATE outbox
+SET
+    processed = TRUE,
     updated_at = CURRENT_TIMESTAMP
 WHERE
     id = ?
@@ -176,33 +1454,322 @@ func (q *Queries) MarkOutboxMessageProcessed(ctx context.Context, id int32) erro
 	return err
 }
 
-const updatePrimeCheckResult = `-- name: UpdatePrimeCheckResult :exec
+const retryPrimeCheck = `-- name: RetryPrimeCheck :execresult
+UPDATE prime_checks
+SET
+    trace_id = NULL,
+    message_id = NULL,
+    is_prime = NULL,
+    algorithm = NULL,
+    algorithm_version = NULL,
+    algorithm_params = NULL,
+    found_prime = NULL,
+    prime_gap = NULL,
+    confidence = NULL,
+    error_bound = NULL,
+    worker_id = NULL,
+    started_at = NULL,
+    finished_at = NULL,
+    calculation_us = NULL,
+    status = 'queued',
+    certificate_status = ?,
+    factorization_status = NULL,
+    attempt = attempt + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+    AND status = ?
+`
+
+type RetryPrimeCheckParams struct {
+	CertificateStatus sql.NullString
+	ID                int32
+	FromStatus        sql.NullString
+}
+
+func (q *Queries) RetryPrimeCheck(ctx context.Context, arg RetryPrimeCheckParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, retryPrimeCheck, arg.CertificateStatus, arg.ID, arg.FromStatus)
+}
+
+const updatePrimeCheckBatchUploadStatus = `-- name: UpdatePrimeCheckBatchUploadStatus :exec
+UPDATE prime_check_batches
+SET
+    upload_status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdatePrimeCheckBatchUploadStatusParams struct {
+	UploadStatus sql.NullString
+	ID           int32
+}
+
+func (q *Queries) UpdatePrimeCheckBatchUploadStatus(ctx context.Context, arg UpdatePrimeCheckBatchUploadStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrimeCheckBatchUploadStatus, arg.UploadStatus, arg.ID)
+	return err
+}
+
+const updatePrimeCheckCertificateStatus = `-- name: UpdatePrimeCheckCertificateStatus :exec
+UPDATE prime_checks
+SET
+    certificate_status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdatePrimeCheckCertificateStatusParams struct {
+	CertificateStatus sql.NullString
+	ID                int32
+}
+
+func (q *Queries) UpdatePrimeCheckCertificateStatus(ctx context.Context, arg UpdatePrimeCheckCertificateStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrimeCheckCertificateStatus, arg.CertificateStatus, arg.ID)
+	return err
+}
+
+const updatePrimeCheckFactorizationStatus = `-- name: UpdatePrimeCheckFactorizationStatus :exec
+UPDATE prime_checks
+SET
+    factorization_status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdatePrimeCheckFactorizationStatusParams struct {
+	FactorizationStatus sql.NullString
+	ID                  int32
+}
+
+func (q *Queries) UpdatePrimeCheckFactorizationStatus(ctx context.Context, arg UpdatePrimeCheckFactorizationStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrimeCheckFactorizationStatus, arg.FactorizationStatus, arg.ID)
+	return err
+}
+
+const updatePrimeCheckFoundPrime = `-- name: UpdatePrimeCheckFoundPrime :exec
+UPDATE prime_checks
+SET
+    found_prime = ?,
+    prime_gap = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdatePrimeCheckFoundPrimeParams struct {
+	FoundPrime sql.NullString
+	PrimeGap   sql.NullInt64
+	ID         int32
+}
+
+func (q *Queries) UpdatePrimeCheckFoundPrime(ctx context.Context, arg UpdatePrimeCheckFoundPrimeParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrimeCheckFoundPrime, arg.FoundPrime, arg.PrimeGap, arg.ID)
+	return err
+}
+
+const updatePrimeCheckResult = `-- name: UpdatePrimeCheckResult :execresult
 UPDATE prime_checks
 SET
     trace_id = ?,
     message_id = ?,
     is_prime = ?,
+    algorithm = ?,
+    algorithm_version = ?,
+    algorithm_params = ?,
+    confidence = ?,
+    error_bound = ?,
+    worker_id = ?,
+    started_at = ?,
+    finished_at = ?,
+    calculation_us = ?,
     status = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE
     id = ?
+    AND status = ?
 `
 
 type UpdatePrimeCheckResultParams struct {
-	TraceID   sql.NullString
-	MessageID sql.NullString
-	IsPrime   sql.NullBool
-	Status    sql.NullString
-	ID        int32
+	TraceID          sql.NullString
+	MessageID        sql.NullString
+	IsPrime          sql.NullBool
+	Algorithm        sql.NullString
+	AlgorithmVersion sql.NullString
+	AlgorithmParams  json.RawMessage
+	Confidence       sql.NullString
+	ErrorBound       sql.NullFloat64
+	WorkerID         sql.NullString
+	StartedAt        sql.NullTime
+	FinishedAt       sql.NullTime
+	CalculationUs    sql.NullInt64
+	Status           sql.NullString
+	ID               int32
+	FromStatus       sql.NullString
 }
 
-func (q *Queries) UpdatePrimeCheckResult(ctx context.Context, arg UpdatePrimeCheckResultParams) error {
-	_, err := q.db.ExecContext(ctx, updatePrimeCheckResult,
+func (q *Queries) UpdatePrimeCheckResult(ctx context.Context, arg UpdatePrimeCheckResultParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updatePrimeCheckResult,
 		arg.TraceID,
 		arg.MessageID,
 		arg.IsPrime,
+		arg.Algorithm,
+		arg.AlgorithmVersion,
+		arg.AlgorithmParams,
+		arg.Confidence,
+		arg.ErrorBound,
+		arg.WorkerID,
+		arg.StartedAt,
+		arg.FinishedAt,
+		arg.CalculationUs,
 		arg.Status,
 		arg.ID,
+		arg.FromStatus,
+	)
+}
+
+const updatePrimeCheckStatus = `-- name: UpdatePrimeCheckStatus :execresult
+UPDATE prime_checks
+SET
+    status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+    AND status = ?
+`
+
+type UpdatePrimeCheckStatusParams struct {
+	Status     sql.NullString
+	ID         int32
+	FromStatus sql.NullString
+}
+
+func (q *Queries) UpdatePrimeCheckStatus(ctx context.Context, arg UpdatePrimeCheckStatusParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updatePrimeCheckStatus, arg.Status, arg.ID, arg.FromStatus)
+}
+
+const updatePrimeRangeResult = `-- name: UpdatePrimeRangeResult :exec
+UPDATE prime_ranges
+SET
+    prime_count = ?,
+    status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdatePrimeRangeResultParams struct {
+	PrimeCount sql.NullInt64
+	Status     sql.NullString
+	ID         int32
+}
+
+func (q *Queries) UpdatePrimeRangeResult(ctx context.Context, arg UpdatePrimeRangeResultParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrimeRangeResult, arg.PrimeCount, arg.Status, arg.ID)
+	return err
+}
+
+const updateUserAuthTokenHash = `-- name: UpdateUserAuthTokenHash :exec
+UPDATE users
+SET
+    auth_token_hash = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdateUserAuthTokenHashParams struct {
+	AuthTokenHash string
+	ID            int32
+}
+
+func (q *Queries) UpdateUserAuthTokenHash(ctx context.Context, arg UpdateUserAuthTokenHashParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserAuthTokenHash, arg.AuthTokenHash, arg.ID)
+	return err
+}
+
+const updateUserWebhookSecret = `-- name: UpdateUserWebhookSecret :exec
+UPDATE users
+SET
+    webhook_secret = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+`
+
+type UpdateUserWebhookSecretParams struct {
+	WebhookSecret sql.NullString
+	ID            int32
+}
+
+func (q *Queries) UpdateUserWebhookSecret(ctx context.Context, arg UpdateUserWebhookSecretParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserWebhookSecret, arg.WebhookSecret, arg.ID)
+	return err
+}
+
+const upsertCachedPrimeResult = `-- name: UpsertCachedPrimeResult :exec
+INSERT INTO prime_result_cache (number_hash, accuracy, number_text, is_prime, algorithm, algorithm_version, algorithm_params, confidence, error_bound) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    is_prime = VALUES(is_prime),
+    algorithm = VALUES(algorithm),
+    algorithm_version = VALUES(algorithm_version),
+    algorithm_params = VALUES(algorithm_params),
+    confidence = VALUES(confidence),
+    error_bound = VALUES(error_bound),
+    updated_at = CURRENT_TIMESTAMP
+`
+
+type UpsertCachedPrimeResultParams struct {
+	NumberHash       string
+	Accuracy         string
+	NumberText       string
+	IsPrime          bool
+	Algorithm        sql.NullString
+	AlgorithmVersion sql.NullString
+	AlgorithmParams  json.RawMessage
+	Confidence       sql.NullString
+	ErrorBound       sql.NullFloat64
+}
+
+func (q *Queries) UpsertCachedPrimeResult(ctx context.Context, arg UpsertCachedPrimeResultParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCachedPrimeResult,
+		arg.NumberHash,
+		arg.Accuracy,
+		arg.NumberText,
+		arg.IsPrime,
+		arg.Algorithm,
+		arg.AlgorithmVersion,
+		arg.AlgorithmParams,
+		arg.Confidence,
+		arg.ErrorBound,
+	)
+	return err
+}
+
+const upsertSettings = `-- name: UpsertSettings :exec
+INSERT INTO settings (id, record_number_success, prime_check_success, email_send_success, dlq_save_success) VALUES (1, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    record_number_success = VALUES(record_number_success),
+    prime_check_success = VALUES(prime_check_success),
+    email_send_success = VALUES(email_send_success),
+    dlq_save_success = VALUES(dlq_save_success),
+    updated_at = CURRENT_TIMESTAMP
+`
+
+type UpsertSettingsParams struct {
+	RecordNumberSuccess bool
+	PrimeCheckSuccess   bool
+	EmailSendSuccess    bool
+	DlqSaveSuccess      bool
+}
+
+func (q *Queries) UpsertSettings(ctx context.Context, arg UpsertSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSettings,
+		arg.RecordNumberSuccess,
+		arg.PrimeCheckSuccess,
+		arg.EmailSendSuccess,
+		arg.DlqSaveSuccess,
 	)
 	return err
 }
@@ -1,6 +1,17 @@
 CREATE TABLE users (
     id INT PRIMARY KEY AUTO_INCREMENT,
-    auth_token VARCHAR(64) NOT NULL UNIQUE,
+    auth_token_hash CHAR(64) NOT NULL UNIQUE,
+    role VARCHAR(20) NOT NULL DEFAULT 'user',
+    webhook_secret CHAR(64),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE prime_check_batches (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    source_format VARCHAR(50),
+    upload_status VARCHAR(20),
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
@@ -8,15 +19,98 @@ CREATE TABLE users (
 CREATE TABLE prime_checks (
     id INT PRIMARY KEY AUTO_INCREMENT,
     user_id INT NOT NULL,
+    batch_id INT,
     number_text TEXT NOT NULL,
+    number_hash CHAR(64),
+    bit_length INT,
+    digit_length INT AS (CHAR_LENGTH(number_text)) STORED,
+    expression TEXT,
+    operation VARCHAR(50),
+    accuracy VARCHAR(50),
+    callback_url VARCHAR(2048),
+    requested_algorithm VARCHAR(50),
+    timeout_seconds INT,
     trace_id VARCHAR(255),
     message_id VARCHAR(255),
     is_prime BOOLEAN,
+    algorithm VARCHAR(50),
+    algorithm_version VARCHAR(20),
+    algorithm_params JSON,
+    found_prime TEXT,
+    prime_gap BIGINT,
+    confidence VARCHAR(50),
+    error_bound DOUBLE,
+    worker_id VARCHAR(255),
+    started_at TIMESTAMP(6) NULL,
+    finished_at TIMESTAMP(6) NULL,
+    calculation_us BIGINT,
+    status VARCHAR(50),
+    certificate_status VARCHAR(50),
+    factorization_status VARCHAR(50),
+    cached BOOLEAN NOT NULL DEFAULT FALSE,
+    attempt INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_prime_checks_batch_id (batch_id),
+    INDEX idx_prime_checks_number_hash (number_hash),
+    INDEX idx_prime_checks_user_id_created_at (user_id, created_at),
+    INDEX idx_prime_checks_created_at (created_at),
+    INDEX idx_prime_checks_status_created_at (status, created_at),
+    INDEX idx_prime_checks_is_prime_created_at (is_prime, created_at),
+    INDEX idx_prime_checks_digit_length (digit_length),
+    INDEX idx_prime_checks_number_text (number_text(64))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE prime_result_cache (
+    number_hash CHAR(64) NOT NULL,
+    accuracy VARCHAR(50) NOT NULL,
+    number_text TEXT NOT NULL,
+    is_prime BOOLEAN NOT NULL,
+    algorithm VARCHAR(50),
+    algorithm_version VARCHAR(20),
+    algorithm_params JSON,
+    confidence VARCHAR(50),
+    error_bound DOUBLE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (number_hash, accuracy)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE prime_certificates (
+    prime_check_id INT PRIMARY KEY,
+    method VARCHAR(50) NOT NULL,
+    certificate JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE prime_factorizations (
+    prime_check_id INT PRIMARY KEY,
+    factors JSON NOT NULL,
+    unfactored TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE prime_ranges (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    range_start BIGINT NOT NULL,
+    range_end BIGINT NOT NULL,
+    count_only BOOLEAN NOT NULL DEFAULT FALSE,
+    prime_count BIGINT,
     status VARCHAR(50),
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
 
+CREATE TABLE prime_range_chunks (
+    prime_range_id INT NOT NULL,
+    first_position BIGINT NOT NULL,
+    end_position BIGINT NOT NULL,
+    primes JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (prime_range_id, first_position)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
 CREATE TABLE outbox (
     id INT PRIMARY KEY AUTO_INCREMENT,
     event_type VARCHAR(255) NOT NULL,
@@ -24,4 +118,58 @@ CREATE TABLE outbox (
     processed BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
-) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
\ No newline at end of file
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE settings (
+    id INT PRIMARY KEY,
+    record_number_success BOOLEAN NOT NULL DEFAULT TRUE,
+    prime_check_success BOOLEAN NOT NULL DEFAULT TRUE,
+    email_send_success BOOLEAN NOT NULL DEFAULT TRUE,
+    dlq_save_success BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE dead_letter_messages (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    subject VARCHAR(255) NOT NULL,
+    message_id VARCHAR(255),
+    payload JSON NOT NULL,
+    error_message TEXT NOT NULL,
+    delivery_count INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_subject_created (subject, created_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE webhook_deliveries (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    prime_check_id INT NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT,
+    error_message TEXT,
+    success BOOLEAN NOT NULL,
+    duration_us BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_webhook_deliveries_prime_check_id (prime_check_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE idempotency_keys (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_fingerprint CHAR(64) NOT NULL,
+    prime_check_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_idempotency_keys_user_key (user_id, idempotency_key)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE prime_check_events (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    prime_check_id INT NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    actor VARCHAR(50) NOT NULL,
+    trace_id VARCHAR(255),
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_prime_check_events_prime_check_id (prime_check_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
@@ -1,15 +1,43 @@
 -- name: CreatePrimeCheck :execresult
-INSERT INTO prime_checks (user_id, number_text, status) VALUES (?, ?, 'processing');
+INSERT INTO prime_checks (user_id, batch_id, number_text, number_hash, bit_length, expression, operation, accuracy, callback_url, requested_algorithm, timeout_seconds, status, certificate_status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'queued', ?);
+
+-- name: CreateCachedPrimeCheck :execresult
+INSERT INTO prime_checks (user_id, batch_id, number_text, number_hash, bit_length, expression, operation, accuracy, callback_url, is_prime, algorithm, algorithm_version, algorithm_params, confidence, error_bound, factorization_status, status, cached) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'completed', TRUE);
 
 -- name: GetPrimeCheck :one
 SELECT
     id,
     user_id,
+    batch_id,
     number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
     trace_id,
     message_id,
     is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
     status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
     created_at,
     updated_at
 FROM prime_checks
@@ -20,15 +48,239 @@ WHERE
 SELECT
     id,
     user_id,
+    batch_id,
     number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
     trace_id,
     message_id,
     is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
     status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
     created_at,
     updated_at
 FROM prime_checks
-ORDER BY created_at DESC;
+WHERE
+    (sqlc.narg('user_id') IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('is_prime') IS NULL OR is_prime = sqlc.narg('is_prime'))
+    AND (sqlc.narg('created_from') IS NULL OR created_at >= sqlc.narg('created_from'))
+    AND (sqlc.narg('created_to') IS NULL OR created_at < sqlc.narg('created_to'))
+    AND (sqlc.narg('min_digits') IS NULL OR digit_length >= sqlc.narg('min_digits'))
+    AND (sqlc.narg('max_digits') IS NULL OR digit_length <= sqlc.narg('max_digits'))
+    AND (sqlc.narg('number_pattern') IS NULL OR number_text LIKE sqlc.narg('number_pattern'))
+    AND (
+        sqlc.narg('cursor_created_at') IS NULL
+        OR created_at < sqlc.narg('cursor_created_at')
+        OR (created_at = sqlc.narg('cursor_created_at') AND id < sqlc.narg('cursor_id'))
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT ?;
+
+-- name: ListPrimeChecksAsc :many
+SELECT
+    id,
+    user_id,
+    batch_id,
+    number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
+    trace_id,
+    message_id,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
+    status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
+    created_at,
+    updated_at
+FROM prime_checks
+WHERE
+    (sqlc.narg('user_id') IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('is_prime') IS NULL OR is_prime = sqlc.narg('is_prime'))
+    AND (sqlc.narg('created_from') IS NULL OR created_at >= sqlc.narg('created_from'))
+    AND (sqlc.narg('created_to') IS NULL OR created_at < sqlc.narg('created_to'))
+    AND (sqlc.narg('min_digits') IS NULL OR digit_length >= sqlc.narg('min_digits'))
+    AND (sqlc.narg('max_digits') IS NULL OR digit_length <= sqlc.narg('max_digits'))
+    AND (sqlc.narg('number_pattern') IS NULL OR number_text LIKE sqlc.narg('number_pattern'))
+    AND (
+        sqlc.narg('cursor_created_at') IS NULL
+        OR created_at > sqlc.narg('cursor_created_at')
+        OR (created_at = sqlc.narg('cursor_created_at') AND id > sqlc.narg('cursor_id'))
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT ?;
+
+-- name: ListPrimeChecksByBatchAfter :many
+SELECT
+    id,
+    user_id,
+    batch_id,
+    number_text,
+    number_hash,
+    bit_length,
+    digit_length,
+    expression,
+    operation,
+    accuracy,
+    callback_url,
+    requested_algorithm,
+    timeout_seconds,
+    trace_id,
+    message_id,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    found_prime,
+    prime_gap,
+    confidence,
+    error_bound,
+    worker_id,
+    started_at,
+    finished_at,
+    calculation_us,
+    status,
+    certificate_status,
+    factorization_status,
+    cached,
+    attempt,
+    created_at,
+    updated_at
+FROM prime_checks
+WHERE
+    batch_id = ?
+    AND id > ?
+ORDER BY id ASC
+LIMIT ?;
+
+-- name: ListPrimeCheckIDsByBatch :many
+SELECT
+    id
+FROM prime_checks
+WHERE
+    batch_id = ?
+    AND id >= ?
+ORDER BY id ASC
+LIMIT ?;
+
+-- name: CreatePrimeCheckBatch :execresult
+INSERT INTO prime_check_batches (user_id, source_format, upload_status) VALUES (?, ?, ?);
+
+-- name: GetPrimeCheckBatch :one
+SELECT
+    id,
+    user_id,
+    source_format,
+    upload_status,
+    created_at,
+    updated_at
+FROM prime_check_batches
+WHERE
+    id = ?;
+
+-- name: UpdatePrimeCheckBatchUploadStatus :exec
+UPDATE prime_check_batches
+SET
+    upload_status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: CountPrimeChecksByBatchStatus :many
+SELECT
+    status,
+    COUNT(*) AS count
+FROM prime_checks
+WHERE
+    batch_id = ?
+GROUP BY status;
+
+-- name: ListCachedPrimeResults :many
+SELECT
+    number_hash,
+    accuracy,
+    number_text,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    confidence,
+    error_bound,
+    created_at,
+    updated_at
+FROM prime_result_cache
+WHERE
+    number_hash = ?;
+
+-- name: ListCachedPrimeResultsByHashes :many
+SELECT
+    number_hash,
+    accuracy,
+    number_text,
+    is_prime,
+    algorithm,
+    algorithm_version,
+    algorithm_params,
+    confidence,
+    error_bound,
+    created_at,
+    updated_at
+FROM prime_result_cache
+WHERE
+    number_hash IN (sqlc.slice('number_hashes'));
+
+-- name: UpsertCachedPrimeResult :exec
+INSERT INTO prime_result_cache (number_hash, accuracy, number_text, is_prime, algorithm, algorithm_version, algorithm_params, confidence, error_bound) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    is_prime = VALUES(is_prime),
+    algorithm = VALUES(algorithm),
+    algorithm_version = VALUES(algorithm_version),
+    algorithm_params = VALUES(algorithm_params),
+    confidence = VALUES(confidence),
+    error_bound = VALUES(error_bound),
+    updated_at = CURRENT_TIMESTAMP;
 
 -- name: CreateOutboxMessage :execresult
 INSERT INTO outbox (event_type, payload) VALUES (?, ?);
@@ -54,13 +306,301 @@ SET
 WHERE
     id = ?;
 
--- name: UpdatePrimeCheckResult :exec
+-- name: UpdatePrimeCheckResult :execresult
 UPDATE prime_checks
 SET
     trace_id = ?,
     message_id = ?,
     is_prime = ?,
+    algorithm = ?,
+    algorithm_version = ?,
+    algorithm_params = ?,
+    confidence = ?,
+    error_bound = ?,
+    worker_id = ?,
+    started_at = ?,
+    finished_at = ?,
+    calculation_us = ?,
     status = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE
-    id = ?;
\ No newline at end of file
+    id = ?
+    AND status = sqlc.arg('from_status');
+
+-- name: GetPrimeCheckStatus :one
+SELECT
+    status,
+    attempt
+FROM prime_checks
+WHERE
+    id = ?;
+
+-- name: LockPrimeCheckStatus :one
+SELECT
+    status
+FROM prime_checks
+WHERE
+    id = ?
+FOR UPDATE;
+
+-- name: UpdatePrimeCheckStatus :execresult
+UPDATE prime_checks
+SET
+    status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+    AND status = sqlc.arg('from_status');
+
+-- name: RetryPrimeCheck :execresult
+UPDATE prime_checks
+SET
+    trace_id = NULL,
+    message_id = NULL,
+    is_prime = NULL,
+    algorithm = NULL,
+    algorithm_version = NULL,
+    algorithm_params = NULL,
+    found_prime = NULL,
+    prime_gap = NULL,
+    confidence = NULL,
+    error_bound = NULL,
+    worker_id = NULL,
+    started_at = NULL,
+    finished_at = NULL,
+    calculation_us = NULL,
+    status = 'queued',
+    certificate_status = ?,
+    factorization_status = NULL,
+    attempt = attempt + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?
+    AND status = sqlc.arg('from_status');
+
+-- name: UpdatePrimeCheckFoundPrime :exec
+UPDATE prime_checks
+SET
+    found_prime = ?,
+    prime_gap = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: UpdatePrimeCheckCertificateStatus :exec
+UPDATE prime_checks
+SET
+    certificate_status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: CreatePrimeCertificate :exec
+INSERT INTO prime_certificates (prime_check_id, method, certificate) VALUES (?, ?, ?);
+
+-- name: GetPrimeCertificate :one
+SELECT
+    prime_check_id,
+    method,
+    certificate,
+    created_at
+FROM prime_certificates
+WHERE
+    prime_check_id = ?;;
+
+-- name: UpdatePrimeCheckFactorizationStatus :exec
+UPDATE prime_checks
+SET
+    factorization_status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: CreatePrimeFactorization :exec
+INSERT INTO prime_factorizations (prime_check_id, factors, unfactored) VALUES (?, ?, ?);
+
+-- name: GetPrimeFactorization :one
+SELECT
+    prime_check_id,
+    factors,
+    unfactored,
+    created_at
+FROM prime_factorizations
+WHERE
+    prime_check_id = ?;
+
+-- name: CreatePrimeRange :execresult
+INSERT INTO prime_ranges (user_id, range_start, range_end, count_only, status) VALUES (?, ?, ?, ?, 'processing');
+
+-- name: GetPrimeRange :one
+SELECT
+    id,
+    user_id,
+    range_start,
+    range_end,
+    count_only,
+    prime_count,
+    status,
+    created_at,
+    updated_at
+FROM prime_ranges
+WHERE
+    id = ?;
+
+-- name: UpdatePrimeRangeResult :exec
+UPDATE prime_ranges
+SET
+    prime_count = ?,
+    status = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: CreatePrimeRangeChunk :exec
+INSERT INTO prime_range_chunks (prime_range_id, first_position, end_position, primes) VALUES (?, ?, ?, ?);
+
+-- name: DeletePrimeRangeChunks :exec
+DELETE FROM prime_range_chunks
+WHERE
+    prime_range_id = ?;
+
+-- name: ListPrimeRangeChunks :many
+SELECT
+    prime_range_id,
+    first_position,
+    end_position,
+    primes,
+    created_at
+FROM prime_range_chunks
+WHERE
+    prime_range_id = ?
+    AND end_position > ?
+    AND first_position < ?
+ORDER BY first_position ASC;
+-- name: GetSettings :one
+SELECT
+    id,
+    record_number_success,
+    prime_check_success,
+    email_send_success,
+    dlq_save_success,
+    updated_at
+FROM settings
+WHERE
+    id = 1;
+
+-- name: UpsertSettings :exec
+INSERT INTO settings (id, record_number_success, prime_check_success, email_send_success, dlq_save_success) VALUES (1, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+    record_number_success = VALUES(record_number_success),
+    prime_check_success = VALUES(prime_check_success),
+    email_send_success = VALUES(email_send_success),
+    dlq_save_success = VALUES(dlq_save_success),
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: CreateDeadLetterMessage :exec
+INSERT INTO dead_letter_messages (subject, message_id, payload, error_message, delivery_count) VALUES (?, ?, ?, ?, ?);
+
+-- name: CreateUser :execresult
+INSERT INTO users (auth_token_hash, webhook_secret) VALUES (?, ?);
+
+-- name: GetUserByAuthTokenHash :one
+SELECT
+    id,
+    auth_token_hash,
+    role,
+    webhook_secret,
+    created_at,
+    updated_at
+FROM users
+WHERE
+    auth_token_hash = ?;
+
+-- name: UpdateUserAuthTokenHash :exec
+UPDATE users
+SET
+    auth_token_hash = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: GetUserWebhookSecret :one
+SELECT
+    webhook_secret
+FROM users
+WHERE
+    id = ?;
+
+-- name: UpdateUserWebhookSecret :exec
+UPDATE users
+SET
+    webhook_secret = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    id = ?;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    prime_check_id,
+    url,
+    attempt,
+    status_code,
+    error_message,
+    success,
+    duration_us
+) VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListWebhookDeliveriesByPrimeCheck :many
+SELECT
+    id,
+    prime_check_id,
+    url,
+    attempt,
+    status_code,
+    error_message,
+    success,
+    duration_us,
+    created_at
+FROM webhook_deliveries
+WHERE
+    prime_check_id = ?
+ORDER BY id;
+
+-- name: CreateIdempotencyKey :exec
+INSERT INTO idempotency_keys (user_id, idempotency_key, request_fingerprint, prime_check_id) VALUES (?, ?, ?, ?);
+
+-- name: GetIdempotencyKey :one
+SELECT
+    id,
+    user_id,
+    idempotency_key,
+    request_fingerprint,
+    prime_check_id,
+    created_at
+FROM idempotency_keys
+WHERE
+    user_id = ?
+    AND idempotency_key = ?;
+
+-- name: CreatePrimeCheckEvent :exec
+INSERT INTO prime_check_events (
+    prime_check_id,
+    from_status,
+    to_status,
+    actor,
+    trace_id
+) VALUES (?, ?, ?, ?, ?);
+
+-- name: ListPrimeCheckEvents :many
+SELECT
+    id,
+    prime_check_id,
+    from_status,
+    to_status,
+    actor,
+    trace_id,
+    created_at
+FROM prime_check_events
+WHERE
+    prime_check_id = ?
+ORDER BY id;
@@ -50,7 +50,7 @@ func (w *EmailSendWorker) HandleMessage(ctx context.Context, msg *message.Messag
 		payload.MessageID,
 	)
 
-	result, err := w.usecase.SendPrimeCheckResult(request)
+	result, err := w.usecase.SendPrimeCheckResult(ctx, request)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to send email: %w", err)
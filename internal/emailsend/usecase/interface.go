@@ -1,5 +1,15 @@
 package usecase
 
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
+)
+
 type EmailRepository interface {
 	SendEmail(to, subject, body, messageID string) error
 }
+
+type FaultInjector interface {
+	Inject(ctx context.Context, step faultinjection.Step) error
+}
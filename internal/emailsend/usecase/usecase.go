@@ -1,25 +1,33 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/ponyo877/prime-checker/internal/emailsend/model"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
 )
 
 type EmailSendUsecase struct {
-	repo EmailRepository
+	repo   EmailRepository
+	faults FaultInjector
 }
 
-func NewEmailSendUsecase(repo EmailRepository) *EmailSendUsecase {
+func NewEmailSendUsecase(repo EmailRepository, faults FaultInjector) *EmailSendUsecase {
 	return &EmailSendUsecase{
-		repo: repo,
+		repo:   repo,
+		faults: faults,
 	}
 }
 
-func (u *EmailSendUsecase) SendPrimeCheckResult(request *model.EmailRequest) (*model.SendResult, error) {
+func (u *EmailSendUsecase) SendPrimeCheckResult(ctx context.Context, request *model.EmailRequest) (*model.SendResult, error) {
 	log.Printf("Sending email to %s for request ID %d", request.Email(), request.RequestID())
 
+	if err := u.faults.Inject(ctx, faultinjection.StepEmailSend); err != nil {
+		return model.NewSendResult(request.RequestID(), model.SendStatusFailed, err), fmt.Errorf("failed to send email: %w", err)
+	}
+
 	var subject, body string
 	if request.IsPrime() {
 		subject = fmt.Sprintf("Prime Check Result: %s is Prime!", request.NumberText())
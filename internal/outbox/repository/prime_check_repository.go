@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/outbox/usecase"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+)
+
+type PrimeCheckRepository struct {
+	db      *sql.DB
+	queries *generated_sql.Queries
+}
+
+func NewPrimeCheckRepository(db *sql.DB) usecase.PrimeCheckRepository {
+	return &PrimeCheckRepository{
+		db:      db,
+		queries: generated_sql.New(db),
+	}
+}
+
+// DispatchPrimeCheck moves a queued prime check on to dispatched. A prime
+// check in any other status, dispatched by an earlier attempt or cancelled
+// meanwhile, stays as it is.
+func (r *PrimeCheckRepository) DispatchPrimeCheck(ctx context.Context, requestID int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	from, err := primecheckstatus.Lock(ctx, txQueries, requestID)
+	if err != nil {
+		return err
+	}
+	if from != primecheckstatus.Queued {
+		return nil
+	}
+	if err := primecheckstatus.Transition(ctx, txQueries, requestID, from, primecheckstatus.Dispatched, primecheckstatus.ActorOutboxPublisher); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
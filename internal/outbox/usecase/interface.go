@@ -12,6 +12,10 @@ type OutboxRepository interface {
 	MarkMessageAsProcessed(ctx context.Context, messageID int32) error
 }
 
+type PrimeCheckRepository interface {
+	DispatchPrimeCheck(ctx context.Context, requestID int32) error
+}
+
 type MessagePublisher interface {
 	PublishMessage(ctx context.Context, subject string, msg *message.Message) error
 }
@@ -14,14 +14,16 @@ import (
 )
 
 type OutboxPublishingUsecase struct {
-	repo      OutboxRepository
-	publisher MessagePublisher
+	repo        OutboxRepository
+	primeChecks PrimeCheckRepository
+	publisher   MessagePublisher
 }
 
-func NewOutboxPublishingUsecase(repo OutboxRepository, publisher MessagePublisher) *OutboxPublishingUsecase {
+func NewOutboxPublishingUsecase(repo OutboxRepository, primeChecks PrimeCheckRepository, publisher MessagePublisher) *OutboxPublishingUsecase {
 	return &OutboxPublishingUsecase{
-		repo:      repo,
-		publisher: publisher,
+		repo:        repo,
+		primeChecks: primeChecks,
+		publisher:   publisher,
 	}
 }
 
@@ -71,6 +73,16 @@ func (u *OutboxPublishingUsecase) publishSingleMessage(ctx context.Context, outb
 		log.Printf("Publishing message ID %d with Trace ID: %s", outboxMsg.ID(), traceID)
 	}
 
+	// A prime check is dispatched before it is published, so that the worker never
+	// receives one that is still queued; a failed publication is retried as dispatched
+	if outboxMsg.EventType() == string(message.MessageTypePrimeCheck) {
+		if err := u.dispatchPrimeCheck(ctx, &msg); err != nil {
+			span.RecordError(err)
+			log.Printf("Failed to dispatch prime check of message ID %d: %v", outboxMsg.ID(), err)
+			return model.NewPublicationResult(outboxMsg.ID(), model.PublicationStatusFailed, err, time.Now())
+		}
+	}
+
 	subject := u.getSubjectForEventType(outboxMsg.EventType())
 	now := time.Now()
 	if err := u.publisher.PublishMessage(ctx, subject, &msg); err != nil {
@@ -82,12 +94,28 @@ func (u *OutboxPublishingUsecase) publishSingleMessage(ctx context.Context, outb
 	return model.NewPublicationResult(outboxMsg.ID(), model.PublicationStatusSuccess, nil, now)
 }
 
+func (u *OutboxPublishingUsecase) dispatchPrimeCheck(ctx context.Context, msg *message.Message) error {
+	payload, err := msg.UnmarshalPrimeCheckPayload()
+	if err != nil {
+		return err
+	}
+	return u.primeChecks.DispatchPrimeCheck(ctx, payload.RequestID)
+}
+
 func (u *OutboxPublishingUsecase) getSubjectForEventType(eventType string) string {
 	switch eventType {
 	case string(message.MessageTypePrimeCheck):
 		return "primecheck"
 	case string(message.MessageTypeEmailSend):
 		return "emailsend"
+	case string(message.MessageTypeFactorization):
+		return "factorization"
+	case string(message.MessageTypePrimeRange):
+		return "primerange"
+	case string(message.MessageTypePrimeCheckResult):
+		return "primecheckresult"
+	case string(message.MessageTypeWebhookDelivery):
+		return "webhookdelivery"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+)
+
+type FactorizationWorker struct {
+	usecase *usecase.FactorizationUsecase
+}
+
+func NewFactorizationWorker(usecase *usecase.FactorizationUsecase) *FactorizationWorker {
+	return &FactorizationWorker{
+		usecase: usecase,
+	}
+}
+
+func (w *FactorizationWorker) HandleMessage(ctx context.Context, msg *message.Message) error {
+	// Extract trace context from message
+	ctx = msg.ExtractTraceContext(ctx)
+
+	tracer := otel.Tracer("factorization-worker")
+	ctx, span := tracer.Start(ctx, "HandleFactorizationMessage")
+	defer span.End()
+
+	traceID := span.SpanContext().TraceID().String()
+	log.Printf("Processing factorization message: %s with Trace ID: %s", msg.ID, traceID)
+
+	payload, err := msg.UnmarshalFactorizationPayload()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	request := model.NewFactorizationRequest(payload.RequestID, payload.UserID, payload.NumberText, time.Now())
+
+	_, err = w.usecase.ProcessFactorizationRequest(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to process factorization request: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+)
+
+type PrimeRangeWorker struct {
+	usecase *usecase.PrimeRangeUsecase
+}
+
+func NewPrimeRangeWorker(usecase *usecase.PrimeRangeUsecase) *PrimeRangeWorker {
+	return &PrimeRangeWorker{
+		usecase: usecase,
+	}
+}
+
+func (w *PrimeRangeWorker) HandleMessage(ctx context.Context, msg *message.Message) error {
+	// Extract trace context from message
+	ctx = msg.ExtractTraceContext(ctx)
+
+	tracer := otel.Tracer("prime-range-worker")
+	ctx, span := tracer.Start(ctx, "HandlePrimeRangeMessage")
+	defer span.End()
+
+	traceID := span.SpanContext().TraceID().String()
+	log.Printf("Processing prime range message: %s with Trace ID: %s", msg.ID, traceID)
+
+	payload, err := msg.UnmarshalPrimeRangePayload()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	request := model.NewPrimeRangeRequest(payload.RangeID, payload.UserID, payload.Start, payload.End, payload.CountOnly, time.Now())
+
+	_, err = w.usecase.ProcessPrimeRangeRequest(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to process prime range request: %w", err)
+	}
+
+	return nil
+}
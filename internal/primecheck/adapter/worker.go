@@ -26,7 +26,7 @@ func NewPrimeCheckWorker(usecase *usecase.PrimeCheckUsecase) *PrimeCheckWorker {
 func (w *PrimeCheckWorker) HandleMessage(ctx context.Context, msg *message.Message) error {
 	// Extract trace context from message
 	ctx = msg.ExtractTraceContext(ctx)
-	
+
 	tracer := otel.Tracer("prime-check-worker")
 	ctx, span := tracer.Start(ctx, "HandlePrimeCheckMessage")
 	defer span.End()
@@ -40,7 +40,13 @@ func (w *PrimeCheckWorker) HandleMessage(ctx context.Context, msg *message.Messa
 		return fmt.Errorf("failed to unmarshal payload: %w", err)
 	}
 
-	request := model.NewPrimeRequest(payload.RequestID, payload.UserID, payload.NumberText, time.Now())
+	// Messages queued before prime searches existed carry no operation
+	operation := model.PrimeOperation(payload.Operation)
+	if operation == "" {
+		operation = model.PrimeOperationIsPrime
+	}
+
+	request := model.NewPrimeRequest(payload.RequestID, payload.UserID, payload.Attempt, payload.NumberText, operation, model.ParseAccuracy(payload.Accuracy), model.PrimalityAlgorithm(payload.Algorithm), time.Duration(payload.TimeoutSeconds)*time.Second, payload.Certify, payload.CallbackURL, time.Now())
 
 	_, err = w.usecase.ProcessPrimeRequest(ctx, request)
 	if err != nil {
@@ -0,0 +1,61 @@
+package model
+
+import "math"
+
+type Accuracy string
+
+const (
+	// A few Miller-Rabin rounds without Baillie-PSW, for a quick low-confidence answer
+	AccuracyFast Accuracy = "fast"
+	// Miller-Rabin rounds plus Baillie-PSW with the rounds of the original checker
+	AccuracyStandard Accuracy = "standard"
+	// Baillie-PSW plus twice as many Miller-Rabin rounds, at every size
+	AccuracyParanoid Accuracy = "paranoid"
+	// A proof where a deterministic test covers the number, paranoid otherwise
+	AccuracyDeterministic Accuracy = "deterministic"
+)
+
+const (
+	// Miller-Rabin rounds of each accuracy; the standard count is the number of
+	// iterations the checker always used, same as UUID v4 collision probability
+	fastRounds     = 10
+	standardRounds = 61
+	paranoidRounds = 128
+)
+
+var deterministicMillerRabinBases = []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+type ConfidenceLevel string
+
+const (
+	// The verdict is certain: a deterministic test, or a witness of compositeness
+	ConfidenceProven ConfidenceLevel = "proven"
+	// The number passed a probabilistic test and may still be composite
+	ConfidenceProbable ConfidenceLevel = "probable"
+)
+
+// Confidence is how sure a verdict is. ErrorBound is an upper bound on the
+// probability that a composite number was reported prime, 0 when proven.
+type Confidence struct {
+	Level      ConfidenceLevel
+	ErrorBound float64
+}
+
+func provenConfidence() Confidence {
+	return Confidence{Level: ConfidenceProven, ErrorBound: 0}
+}
+
+func probableConfidence(rounds int) Confidence {
+	return Confidence{Level: ConfidenceProbable, ErrorBound: math.Pow(4, -float64(rounds))}
+}
+
+// ParseAccuracy maps an accuracy name to its Accuracy, with standard for an
+// empty or unknown name so that older messages keep their behavior.
+func ParseAccuracy(name string) Accuracy {
+	switch accuracy := Accuracy(name); accuracy {
+	case AccuracyFast, AccuracyParanoid, AccuracyDeterministic:
+		return accuracy
+	default:
+		return AccuracyStandard
+	}
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// aks decides primality of 1 < n < 2^primality.AKSMaxBits with the Agrawal-Kayal-Saxena
+// test and returns the modulus r of the polynomial ring it worked in.
+func aks(ctx context.Context, n uint64) (isPrime bool, r uint64, err error) {
+	if n < 2 {
+		return false, 0, nil
+	}
+	if isPerfectPower(n) {
+		return false, 0, nil
+	}
+
+	// Smallest r with ord_r(n) > log2(n)^2
+	lg := math.Log2(float64(n))
+	maxOrder := uint64(math.Floor(lg * lg))
+	for r = 2; ; r++ {
+		if gcdUint64(r, n) != 1 {
+			continue
+		}
+		if multiplicativeOrderExceeds(n, r, maxOrder) {
+			break
+		}
+	}
+
+	for a := uint64(2); a <= r && a < n; a++ {
+		if g := gcdUint64(a, n); g > 1 && g < n {
+			return false, r, nil
+		}
+	}
+	if n <= r {
+		return true, r, nil
+	}
+
+	// (X+a)^n = X^n + a mod (X^r - 1, n) for every a up to sqrt(phi(r)) log2(n)
+	limit := uint64(math.Floor(math.Sqrt(float64(eulerPhi(r))) * lg))
+	for a := uint64(1); a <= limit; a++ {
+		if err := ctx.Err(); err != nil {
+			return false, r, err
+		}
+		lhs := aksPolyPow([]uint64{a % n, 1}, n, r, n)
+		rhs := make([]uint64, r)
+		rhs[n%r] = 1
+		rhs[0] = (rhs[0] + a) % n
+		for i := range lhs {
+			if lhs[i] != rhs[i] {
+				return false, r, nil
+			}
+		}
+	}
+	return true, r, nil
+}
+
+// aksPolyPow raises the polynomial base to the power e modulo (X^r - 1, n).
+func aksPolyPow(base []uint64, e, r, n uint64) []uint64 {
+	b := make([]uint64, r)
+	for i, c := range base {
+		b[uint64(i)%r] = (b[uint64(i)%r] + c) % n
+	}
+	result := make([]uint64, r)
+	result[0] = 1
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = aksPolyMul(result, b, r, n)
+		}
+		if e > 1 {
+			b = aksPolyMul(b, b, r, n)
+		}
+	}
+	return result
+}
+
+// aksPolyMul multiplies two polynomials of degree < r modulo (X^r - 1, n).
+// Coefficients stay below 2^AKSMaxBits and r below 2^(64-2*AKSMaxBits) (see
+// package primality), so the sums of products fit in a uint64 and are reduced
+// only once.
+func aksPolyMul(x, y []uint64, r, n uint64) []uint64 {
+	product := make([]uint64, r)
+	for i, xi := range x {
+		if xi == 0 {
+			continue
+		}
+		k := uint64(i)
+		for _, yj := range y {
+			product[k] += xi * yj
+			if k++; k == r {
+				k = 0
+			}
+		}
+	}
+	for k := range product {
+		product[k] %= n
+	}
+	return product
+}
+
+// multiplicativeOrderExceeds reports whether the order of n modulo r is larger than bound.
+func multiplicativeOrderExceeds(n, r, bound uint64) bool {
+	v := uint64(1)
+	for k := uint64(1); k <= bound; k++ {
+		v = v * (n % r) % r
+		if v == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isPerfectPower reports whether n = a^b for some a > 1 and b > 1.
+func isPerfectPower(n uint64) bool {
+	v := new(big.Int).SetUint64(n)
+	root := new(big.Int)
+	for b := 2; b <= v.BitLen(); b++ {
+		a := uint64(math.Round(math.Pow(float64(n), 1/float64(b))))
+		for _, c := range []uint64{a - 1, a, a + 1} {
+			if c < 2 {
+				continue
+			}
+			if root.Exp(root.SetUint64(c), big.NewInt(int64(b)), nil).Cmp(v) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func eulerPhi(n uint64) uint64 {
+	result := n
+	for p := uint64(2); p*p <= n; p++ {
+		if n%p != 0 {
+			continue
+		}
+		for n%p == 0 {
+			n /= p
+		}
+		result -= result / p
+	}
+	if n > 1 {
+		result -= result / n
+	}
+	return result
+}
+
+func gcdUint64(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
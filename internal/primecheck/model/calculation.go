@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Calculation records which worker calculated a request and when.
+type Calculation struct {
+	WorkerID   string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (c Calculation) Duration() time.Duration {
+	return c.FinishedAt.Sub(c.StartedAt)
+}
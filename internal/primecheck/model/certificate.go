@@ -0,0 +1,246 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+type CertificateMethod string
+
+const (
+	// n is below primality.TrialDivisionLimit and is checked directly
+	CertificateMethodTrialDivision CertificateMethod = "trial_division"
+	// Pocklington's criterion on a partial factorization of n-1 (a Pratt certificate when n-1 is fully factored)
+	CertificateMethodPocklington CertificateMethod = "pocklington"
+	// Goldwasser–Kilian criterion on an elliptic curve found by the Atkin–Morain method
+	CertificateMethodECPP CertificateMethod = "ecpp"
+)
+
+var errNotPrime = errors.New("number is not prime")
+
+// Certificate is a self-contained primality proof for Number. Every step
+// proves one number prime under the assumption that the smaller primes it
+// references are prime; each of those must in turn have its own step or lie
+// below primality.TrialDivisionLimit. Steps are ordered from Number downwards.
+type Certificate struct {
+	Number string            `json:"number"`
+	Steps  []CertificateStep `json:"steps"`
+}
+
+// CertificateStep holds the data for one proof step. Only the fields of its Method are set.
+type CertificateStep struct {
+	Method CertificateMethod `json:"method"`
+	N      string            `json:"n"`
+
+	// Pocklington: distinct primes q dividing n-1 with F = prod q^v_q(n-1) > sqrt(n),
+	// and for each q a base a with a^(n-1) = 1 and gcd(a^((n-1)/q) - 1, n) = 1
+	Factors   []string `json:"factors,omitempty"`
+	Witnesses []string `json:"witnesses,omitempty"`
+
+	// ECPP: curve y^2 = x^3 + ax + b (mod n) of order m, a prime q | m with
+	// q > (n^(1/4)+1)^2, and a point P = (x, y) with [m/q]P != O and [m]P = O
+	A string `json:"a,omitempty"`
+	B string `json:"b,omitempty"`
+	M string `json:"m,omitempty"`
+	Q string `json:"q,omitempty"`
+	X string `json:"x,omitempty"`
+	Y string `json:"y,omitempty"`
+}
+
+// Method returns the method used to prove the top-level number.
+func (c *Certificate) Method() CertificateMethod {
+	if len(c.Steps) == 0 {
+		return ""
+	}
+	return c.Steps[0].Method
+}
+
+// VerifyCertificate checks a certificate from scratch. It trusts nothing that
+// the generator computed: every step is re-derived with plain modular
+// arithmetic, and every prime a step depends on must be strictly smaller and
+// proven by another step or by trial division.
+func VerifyCertificate(cert *Certificate) error {
+	root, err := parseCertificateNumber(cert.Number)
+	if err != nil {
+		return err
+	}
+
+	steps := make(map[string]*CertificateStep, len(cert.Steps))
+	for i := range cert.Steps {
+		step := &cert.Steps[i]
+		n, err := parseCertificateNumber(step.N)
+		if err != nil {
+			return err
+		}
+		key := n.String()
+		if _, ok := steps[key]; ok {
+			return fmt.Errorf("duplicate certificate step for %s", key)
+		}
+		steps[key] = step
+	}
+
+	verified := make(map[string]bool)
+	var verify func(n *big.Int) error
+	verify = func(n *big.Int) error {
+		key := n.String()
+		if verified[key] {
+			return nil
+		}
+
+		step, ok := steps[key]
+		if !ok {
+			if isPrimeByTrialDivision(n) {
+				verified[key] = true
+				return nil
+			}
+			return fmt.Errorf("no certificate step for %s", key)
+		}
+
+		var dependencies []*big.Int
+		switch step.Method {
+		case CertificateMethodTrialDivision:
+			if !isPrimeByTrialDivision(n) {
+				err = fmt.Errorf("trial division does not prove %s prime", key)
+			}
+		case CertificateMethodPocklington:
+			dependencies, err = verifyPocklingtonStep(n, step)
+		case CertificateMethodECPP:
+			dependencies, err = verifyECPPStep(n, step)
+		default:
+			err = fmt.Errorf("unknown certificate method %q", step.Method)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, dep := range dependencies {
+			if dep.Cmp(n) >= 0 {
+				return fmt.Errorf("step for %s depends on larger number %s", key, dep)
+			}
+			if err := verify(dep); err != nil {
+				return err
+			}
+		}
+		verified[key] = true
+		return nil
+	}
+
+	return verify(root)
+}
+
+func verifyPocklingtonStep(n *big.Int, step *CertificateStep) ([]*big.Int, error) {
+	if len(step.Factors) == 0 || len(step.Factors) != len(step.Witnesses) {
+		return nil, fmt.Errorf("pocklington step for %s needs one witness per factor", n)
+	}
+
+	one := big.NewInt(1)
+	nm1 := new(big.Int).Sub(n, one)
+	f := big.NewInt(1)
+	factors := make([]*big.Int, len(step.Factors))
+	seen := make(map[string]bool, len(step.Factors))
+	for i, text := range step.Factors {
+		q, err := parseCertificateNumber(text)
+		if err != nil {
+			return nil, err
+		}
+		if seen[q.String()] {
+			return nil, fmt.Errorf("factor %s listed twice for %s", q, n)
+		}
+		seen[q.String()] = true
+		a, err := parseCertificateNumber(step.Witnesses[i])
+		if err != nil {
+			return nil, err
+		}
+
+		// Accumulate the full power of q dividing n-1 into F
+		rest, r := new(big.Int).QuoRem(nm1, q, new(big.Int))
+		if q.Cmp(one) <= 0 || r.Sign() != 0 {
+			return nil, fmt.Errorf("%s does not divide %s-1", q, n)
+		}
+		f.Mul(f, q)
+		for {
+			next, r := new(big.Int).QuoRem(rest, q, new(big.Int))
+			if r.Sign() != 0 {
+				break
+			}
+			rest = next
+			f.Mul(f, q)
+		}
+
+		if new(big.Int).Exp(a, nm1, n).Cmp(one) != 0 {
+			return nil, fmt.Errorf("witness %s fails a^(n-1) = 1 mod %s", a, n)
+		}
+		e := new(big.Int).Quo(nm1, q)
+		t := new(big.Int).Exp(a, e, n)
+		t.Sub(t, one)
+		if new(big.Int).GCD(nil, nil, t, n).Cmp(one) != 0 {
+			return nil, fmt.Errorf("witness %s fails gcd(a^((n-1)/%s)-1, n) = 1 for %s", a, q, n)
+		}
+		factors[i] = q
+	}
+
+	if new(big.Int).Mul(f, f).Cmp(n) <= 0 {
+		return nil, fmt.Errorf("factored part of %s-1 does not exceed its square root", n)
+	}
+	return factors, nil
+}
+
+func verifyECPPStep(n *big.Int, step *CertificateStep) ([]*big.Int, error) {
+	values := make([]*big.Int, 0, 6)
+	for _, text := range []string{step.A, step.B, step.X, step.Y, step.M, step.Q} {
+		v, ok := new(big.Int).SetString(text, 10)
+		if !ok || v.Sign() < 0 {
+			return nil, fmt.Errorf("invalid certificate number %q", text)
+		}
+		values = append(values, v)
+	}
+	a, b, x, y, m, q := values[0], values[1], values[2], values[3], values[4], values[5]
+	if m.Sign() == 0 || q.Sign() == 0 {
+		return nil, fmt.Errorf("ecpp step for %s needs a positive curve order and factor", n)
+	}
+
+	if new(big.Int).GCD(nil, nil, n, big.NewInt(6)).Cmp(big.NewInt(1)) != 0 {
+		return nil, fmt.Errorf("ecpp step requires %s coprime to 6", n)
+	}
+	curve := newEllipticCurve(a, b, n)
+	if !curve.isNonSingular() {
+		return nil, fmt.Errorf("curve for %s is singular", n)
+	}
+	p := ecPoint{x: new(big.Int).Mod(x, n), y: new(big.Int).Mod(y, n)}
+	if !curve.contains(p) {
+		return nil, fmt.Errorf("point is not on the curve for %s", n)
+	}
+
+	cofactor, r := new(big.Int).QuoRem(m, q, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, fmt.Errorf("%s does not divide curve order %s", q, m)
+	}
+	if !exceedsECPPBound(q, n) {
+		return nil, fmt.Errorf("%s is too small to certify %s", q, n)
+	}
+
+	qp, err := curve.multiply(p, cofactor)
+	if err != nil {
+		return nil, fmt.Errorf("ecpp step for %s: %w", n, err)
+	}
+	if qp.infinity {
+		return nil, fmt.Errorf("[m/q]P is the identity for %s", n)
+	}
+	o, err := curve.multiply(qp, q)
+	if err != nil {
+		return nil, fmt.Errorf("ecpp step for %s: %w", n, err)
+	}
+	if !o.infinity {
+		return nil, fmt.Errorf("[m]P is not the identity for %s", n)
+	}
+	return []*big.Int{q}, nil
+}
+
+func parseCertificateNumber(text string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(text, 10)
+	if !ok || n.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid certificate number %q", text)
+	}
+	return n, nil
+}
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
+)
+
+const (
+	// Numbers up to this size are proven with Pocklington first, larger ones with ECPP
+	pocklingtonMaxBits = 128
+	// Pollard rho budget per composite piece of n-1
+	pocklingtonRhoIterations = 1 << 18
+	// Largest base tried as a Pocklington witness
+	pocklingtonMaxWitness = 1000
+	// Largest number a certificate is generated for
+	maxCertificateBits = 1024
+	// Rounds used for the probable prime checks that guide the search (0 means Baillie-PSW only)
+	certificateProbablePrimeRounds = 0
+	// Alternative ECPP steps tried for one number before giving up on it
+	maxECPPBacktracks = 4
+)
+
+var (
+	errCertificateTooLarge   = fmt.Errorf("numbers above %d bits cannot be certified", maxCertificateBits)
+	errPocklingtonIncomplete = errors.New("could not factor enough of n-1 for Pocklington")
+)
+
+// generateCertificate builds a primality certificate for n by proving it and
+// then every prime the proof relies on, until only numbers small enough for
+// trial division remain. It gives up with the error of ctx once ctx is done.
+func generateCertificate(ctx context.Context, n *big.Int) (*Certificate, error) {
+	if n.Cmp(big.NewInt(2)) < 0 || !n.ProbablyPrime(certificateProbablePrimeRounds) {
+		return nil, errNotPrime
+	}
+	if n.BitLen() > maxCertificateBits {
+		return nil, errCertificateTooLarge
+	}
+
+	cert := &Certificate{Number: n.String()}
+	if n.Cmp(primality.TrialDivisionLimit) < 0 {
+		cert.Steps = append(cert.Steps, CertificateStep{
+			Method: CertificateMethodTrialDivision,
+			N:      n.String(),
+		})
+		return cert, nil
+	}
+
+	g := &certificateGenerator{
+		// Seeded from n so that the same number always yields the same certificate
+		rnd:    rand.New(rand.NewSource(int64(n.Uint64()))),
+		proven: make(map[string]bool),
+	}
+	steps, err := g.prove(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	cert.Steps = steps
+	return cert, nil
+}
+
+type certificateGenerator struct {
+	rnd    *rand.Rand
+	proven map[string]bool
+}
+
+// prove returns the steps proving n and everything below it. Pocklington is
+// tried first for small n; otherwise the ECPP descent backtracks to the next
+// candidate curve whenever the chain below a candidate cannot be completed.
+func (g *certificateGenerator) prove(ctx context.Context, n *big.Int) ([]CertificateStep, error) {
+	if n.Cmp(primality.TrialDivisionLimit) < 0 || g.proven[n.String()] {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !n.ProbablyPrime(certificateProbablePrimeRounds) {
+		return nil, errNotPrime
+	}
+
+	if n.BitLen() <= pocklingtonMaxBits {
+		step, dependencies, err := pocklingtonStep(ctx, n)
+		if err == nil {
+			steps := []CertificateStep{*step}
+			for _, dep := range dependencies {
+				rest, err := g.prove(ctx, dep)
+				if err != nil {
+					return nil, err
+				}
+				steps = append(steps, rest...)
+			}
+			g.proven[n.String()] = true
+			return steps, nil
+		}
+		if errors.Is(err, errNotPrime) || ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	search := newECPPSearch(n, g.rnd)
+	for attempt := 0; attempt < maxECPPBacktracks; attempt++ {
+		step, q, err := search.next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to certify %s: %w", n, err)
+		}
+		rest, err := g.prove(ctx, q)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			continue
+		}
+		g.proven[n.String()] = true
+		return append([]CertificateStep{*step}, rest...), nil
+	}
+	return nil, fmt.Errorf("failed to certify %s: %w", n, errNoECPPStep)
+}
+
+// pocklingtonStep factors n-1 by trial division and Pollard's rho until the
+// factored part F exceeds sqrt(n), then finds a witness for every prime of F.
+func pocklingtonStep(ctx context.Context, n *big.Int) (*CertificateStep, []*big.Int, error) {
+	one := big.NewInt(1)
+	nm1 := new(big.Int).Sub(n, one)
+
+	cofactor, primes := removeSmallFactors(nm1)
+	f := big.NewInt(1)
+	seen := make(map[string]bool)
+	for _, q := range primes {
+		f.Mul(f, primePowerPart(nm1, q))
+		seen[q.String()] = true
+	}
+
+	var pending []*big.Int
+	if cofactor.Cmp(one) > 0 {
+		pending = append(pending, cofactor)
+	}
+	for len(pending) > 0 && new(big.Int).Mul(f, f).Cmp(n) <= 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		c := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if c.ProbablyPrime(certificateProbablePrimeRounds) {
+			if !seen[c.String()] {
+				seen[c.String()] = true
+				primes = append(primes, c)
+				f.Mul(f, primePowerPart(nm1, c))
+			}
+			continue
+		}
+		if d := pollardRho(ctx, c, pocklingtonRhoIterations); d != nil {
+			pending = append(pending, d, new(big.Int).Quo(c, d))
+		}
+	}
+	if new(big.Int).Mul(f, f).Cmp(n) <= 0 {
+		return nil, nil, errPocklingtonIncomplete
+	}
+
+	sort.Slice(primes, func(i, j int) bool { return primes[i].Cmp(primes[j]) < 0 })
+	step := &CertificateStep{
+		Method: CertificateMethodPocklington,
+		N:      n.String(),
+	}
+	for _, q := range primes {
+		witness, err := pocklingtonWitness(ctx, n, nm1, q)
+		if err != nil {
+			return nil, nil, err
+		}
+		step.Factors = append(step.Factors, q.String())
+		step.Witnesses = append(step.Witnesses, witness.String())
+	}
+	return step, primes, nil
+}
+
+func pocklingtonWitness(ctx context.Context, n, nm1, q *big.Int) (*big.Int, error) {
+	one := big.NewInt(1)
+	e := new(big.Int).Quo(nm1, q)
+	for a := int64(2); a < pocklingtonMaxWitness; a++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		base := big.NewInt(a)
+		if new(big.Int).Exp(base, nm1, n).Cmp(one) != 0 {
+			return nil, errNotPrime
+		}
+		t := new(big.Int).Exp(base, e, n)
+		g := new(big.Int).GCD(nil, nil, t.Sub(t, one), n)
+		if g.Cmp(one) == 0 {
+			return base, nil
+		}
+		if g.Cmp(n) != 0 {
+			return nil, errNotPrime
+		}
+	}
+	return nil, fmt.Errorf("no Pocklington witness below %d for factor %s of %s-1", pocklingtonMaxWitness, q, n)
+}
+
+// primePowerPart returns q^v where v is the exponent of the prime q in n.
+func primePowerPart(n, q *big.Int) *big.Int {
+	part := big.NewInt(1)
+	rest := new(big.Int).Set(n)
+	r := new(big.Int)
+	for {
+		next, _ := new(big.Int).QuoRem(rest, q, r)
+		if r.Sign() != 0 {
+			return part
+		}
+		rest = next
+		part.Mul(part, q)
+	}
+}
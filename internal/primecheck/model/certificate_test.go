@@ -0,0 +1,154 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestGenerateCertificate(t *testing.T) {
+	tests := []struct {
+		name       string
+		number     string
+		wantMethod CertificateMethod
+	}{
+		{name: "two", number: "2", wantMethod: CertificateMethodTrialDivision},
+		{name: "below trial division limit", number: "65537", wantMethod: CertificateMethodTrialDivision},
+		{name: "first prime above trial division limit", number: "4294967311", wantMethod: CertificateMethodPocklington},
+		{name: "mersenne 2^61-1", number: "2305843009213693951", wantMethod: CertificateMethodPocklington},
+		{name: "mersenne 2^127-1", number: "170141183460469231731687303715884105727", wantMethod: CertificateMethodPocklington},
+		{name: "2^160+7", number: "1461501637330902918203684832716283019655932542983", wantMethod: CertificateMethodECPP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, err := generateCertificate(context.Background(), mustBigInt(t, tt.number))
+			if err != nil {
+				t.Fatalf("generateCertificate() error = %v", err)
+			}
+			if cert.Number != tt.number {
+				t.Errorf("Number = %s, want %s", cert.Number, tt.number)
+			}
+			if cert.Method() != tt.wantMethod {
+				t.Errorf("Method() = %s, want %s", cert.Method(), tt.wantMethod)
+			}
+			if err := VerifyCertificate(cert); err != nil {
+				t.Errorf("VerifyCertificate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateCertificateRejectsComposites(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+	}{
+		{name: "one", number: "1"},
+		{name: "even", number: "4294967312"},
+		{name: "carmichael 561", number: "561"},
+		{name: "carmichael 41041", number: "41041"},
+		{name: "carmichael 9746347772161", number: "9746347772161"},
+		{name: "strong pseudoprime to bases 2, 3, 5 and 7", number: "3215031751"},
+		{name: "semiprime", number: "1000000016000000063"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generateCertificate(context.Background(), mustBigInt(t, tt.number))
+			if !errors.Is(err, errNotPrime) {
+				t.Errorf("generateCertificate() error = %v, want %v", err, errNotPrime)
+			}
+		})
+	}
+}
+
+func TestVerifyCertificateRejectsForgeries(t *testing.T) {
+	pocklington, err := generateCertificate(context.Background(), mustBigInt(t, "2305843009213693951"))
+	if err != nil {
+		t.Fatalf("generateCertificate() error = %v", err)
+	}
+	ecpp, err := generateCertificate(context.Background(), mustBigInt(t, "1461501637330902918203684832716283019655932542983"))
+	if err != nil {
+		t.Fatalf("generateCertificate() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		cert  *Certificate
+		forge func(cert *Certificate)
+	}{
+		{
+			name: "carmichael by trial division",
+			cert: &Certificate{Number: "561", Steps: []CertificateStep{{Method: CertificateMethodTrialDivision, N: "561"}}},
+		},
+		{
+			name: "composite above trial division limit without a step",
+			cert: &Certificate{Number: "1000000016000000063"},
+		},
+		{
+			name:  "pocklington witness replaced",
+			cert:  pocklington,
+			forge: func(cert *Certificate) { cert.Steps[0].Witnesses[0] = "1" },
+		},
+		{
+			name: "pocklington factored part below sqrt(n)",
+			cert: pocklington,
+			forge: func(cert *Certificate) {
+				cert.Steps[0].Factors, cert.Steps[0].Witnesses = cert.Steps[0].Factors[:1], cert.Steps[0].Witnesses[:1]
+			},
+		},
+		{
+			name:  "ecpp point moved",
+			cert:  ecpp,
+			forge: func(cert *Certificate) { cert.Steps[0].X = addOne(cert.Steps[0].X) },
+		},
+		{
+			name:  "ecpp curve order changed",
+			cert:  ecpp,
+			forge: func(cert *Certificate) { cert.Steps[0].M = addOne(cert.Steps[0].M) },
+		},
+		{
+			name:  "step of a dependency missing",
+			cert:  ecpp,
+			forge: func(cert *Certificate) { cert.Steps = cert.Steps[:1] },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := cloneCertificate(tt.cert)
+			if tt.forge != nil {
+				tt.forge(cert)
+			}
+			if err := VerifyCertificate(cert); err == nil {
+				t.Error("VerifyCertificate() error = nil")
+			}
+		})
+	}
+}
+
+func cloneCertificate(cert *Certificate) *Certificate {
+	clone := &Certificate{Number: cert.Number, Steps: make([]CertificateStep, len(cert.Steps))}
+	for i, step := range cert.Steps {
+		step.Factors = append([]string(nil), step.Factors...)
+		step.Witnesses = append([]string(nil), step.Witnesses...)
+		clone.Steps[i] = step
+	}
+	return clone
+}
+
+func addOne(text string) string {
+	n, _ := new(big.Int).SetString(text, 10)
+	return n.Add(n, big.NewInt(1)).String()
+}
+
+func mustBigInt(t *testing.T, text string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		t.Fatalf("invalid number %q", text)
+	}
+	return n
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+)
+
+// ecmStages lists the stage 1 bounds of Lenstra's elliptic curve method with
+// the number of curves tried at each, following the usual table for finding
+// factors of roughly 15, 20 and 25 digits.
+var ecmStages = []struct {
+	bound  uint64
+	curves int
+}{
+	{bound: 2000, curves: 25},
+	{bound: 11000, curves: 90},
+	{bound: 50000, curves: 300},
+}
+
+// ecmContextCheckInterval is the number of primes multiplied in before a curve checks for cancellation.
+const ecmContextCheckInterval = 256
+
+// ecm searches for a non-trivial factor of the composite n with Lenstra's
+// elliptic curve method. It returns nil when every stage is exhausted or ctx is done.
+func ecm(ctx context.Context, n *big.Int, rnd *rand.Rand) *big.Int {
+	for _, stage := range ecmStages {
+		for i := 0; i < stage.curves; i++ {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if d := ecmCurve(ctx, n, stage.bound, rnd); d != nil {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// ecmCurve runs stage 1 on one random curve through a random point: it
+// multiplies the point by every prime power up to bound and reports the
+// divisor exposed when an inversion modulo n fails.
+func ecmCurve(ctx context.Context, n *big.Int, bound uint64, rnd *rand.Rand) *big.Int {
+	x := new(big.Int).Rand(rnd, n)
+	y := new(big.Int).Rand(rnd, n)
+	a := new(big.Int).Rand(rnd, n)
+
+	// b = y^2 - x^3 - ax puts (x, y) on the curve
+	b := new(big.Int).Mul(y, y)
+	b.Sub(b, new(big.Int).Exp(x, big.NewInt(3), n))
+	b.Sub(b, new(big.Int).Mul(a, x))
+	curve := newEllipticCurve(a, b, n)
+
+	disc := new(big.Int).Exp(curve.a, big.NewInt(3), n)
+	disc.Mul(disc, big.NewInt(4))
+	disc.Add(disc, new(big.Int).Mul(new(big.Int).Mul(curve.b, curve.b), big.NewInt(27)))
+	if g := new(big.Int).GCD(nil, nil, disc.Mod(disc, n), n); g.Cmp(n) == 0 {
+		return nil
+	} else if g.Cmp(big.NewInt(1)) > 0 {
+		return g
+	}
+
+	point := ecPoint{x: x, y: y}
+	k := new(big.Int)
+	for i, p := range smallPrimesUpTo(bound) {
+		if i%ecmContextCheckInterval == 0 && ctx.Err() != nil {
+			return nil
+		}
+
+		power := p
+		for power <= bound/p {
+			power *= p
+		}
+		next, err := curve.multiply(point, k.SetUint64(power))
+		if err != nil {
+			var nie *notInvertibleError
+			if errors.As(err, &nie) {
+				return nie.factor(n)
+			}
+			return nil
+		}
+		if next.infinity {
+			return nil
+		}
+		point = next
+	}
+	return nil
+}
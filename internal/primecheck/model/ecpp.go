@@ -0,0 +1,347 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+const (
+	// Largest |D| and class number considered when searching CM discriminants
+	maxECPPDiscriminant  = 20000
+	maxECPPClassNumber   = 24
+	maxECPPCurveAttempts = 64
+	jInvariant1728       = 1728
+)
+
+var errNoECPPStep = errors.New("no suitable ECPP discriminant found")
+
+type cmDiscriminant struct {
+	d           int64
+	classNumber int
+}
+
+var (
+	cmDiscriminantsOnce sync.Once
+	cmDiscriminantTable []cmDiscriminant
+)
+
+// cmDiscriminants lists the negative fundamental discriminants used by the
+// Atkin–Morain descent, cheapest (smallest class number, then |D|) first.
+func cmDiscriminants() []cmDiscriminant {
+	cmDiscriminantsOnce.Do(func() {
+		for d := int64(-3); d >= -maxECPPDiscriminant; d-- {
+			if !isFundamentalDiscriminant(d) {
+				continue
+			}
+			if h := len(reducedForms(d)); h <= maxECPPClassNumber {
+				cmDiscriminantTable = append(cmDiscriminantTable, cmDiscriminant{d: d, classNumber: h})
+			}
+		}
+		sort.SliceStable(cmDiscriminantTable, func(i, j int) bool {
+			return cmDiscriminantTable[i].classNumber < cmDiscriminantTable[j].classNumber
+		})
+	})
+	return cmDiscriminantTable
+}
+
+func isFundamentalDiscriminant(d int64) bool {
+	switch ((d % 4) + 4) % 4 {
+	case 1:
+		return isSquarefree(-d)
+	case 0:
+		m := d / 4
+		r := ((m % 4) + 4) % 4
+		return (r == 2 || r == 3) && isSquarefree(-m)
+	default:
+		return false
+	}
+}
+
+func isSquarefree(n int64) bool {
+	for p := int64(2); p*p <= n; p++ {
+		if n%(p*p) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// cornacchia solves 4n = t^2 + |d| v^2 for the probable prime n with the
+// modified Cornacchia algorithm.
+func cornacchia(d int64, n *big.Int) (*big.Int, *big.Int, bool) {
+	dMod := new(big.Int).Mod(big.NewInt(d), n)
+	if big.Jacobi(dMod, n) != 1 {
+		return nil, nil, false
+	}
+	x := new(big.Int).ModSqrt(dMod, n)
+	if x == nil {
+		return nil, nil, false
+	}
+	if x.Bit(0) != uint(d&1) {
+		x.Sub(n, x)
+	}
+
+	fourN := new(big.Int).Lsh(n, 2)
+	limit := new(big.Int).Sqrt(fourN)
+	a, b := new(big.Int).Lsh(n, 1), x
+	for b.Cmp(limit) > 0 {
+		a, b = b, new(big.Int).Mod(a, b)
+	}
+
+	rest := new(big.Int).Mul(b, b)
+	rest.Sub(fourN, rest)
+	absD := big.NewInt(-d)
+	v2, r := new(big.Int).QuoRem(rest, absD, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, nil, false
+	}
+	v := new(big.Int).Sqrt(v2)
+	if new(big.Int).Mul(v, v).Cmp(v2) != 0 {
+		return nil, nil, false
+	}
+	return b, v, true
+}
+
+// curveOrders lists the possible orders n+1-t of curves with CM by d, given a
+// solution of 4n = t^2 + |d| v^2. Besides the curve and its quadratic twist,
+// j = 1728 (d = -4) has quartic and j = 0 (d = -3) sextic twists.
+func curveOrders(d int64, n, t, v *big.Int) []*big.Int {
+	traces := []*big.Int{t}
+	switch d {
+	case -4:
+		traces = append(traces, new(big.Int).Lsh(v, 1))
+	case -3:
+		v3 := new(big.Int).Mul(v, big.NewInt(3))
+		traces = append(traces,
+			new(big.Int).Rsh(new(big.Int).Add(t, v3), 1),
+			new(big.Int).Rsh(new(big.Int).Abs(new(big.Int).Sub(t, v3)), 1),
+		)
+	}
+
+	base := new(big.Int).Add(n, big.NewInt(1))
+	orders := make([]*big.Int, 0, 2*len(traces))
+	for _, trace := range traces {
+		orders = append(orders, new(big.Int).Sub(base, trace), new(big.Int).Add(base, trace))
+	}
+	return orders
+}
+
+// exceedsECPPBound reports whether q > (n^(1/4) + 1)^2, which is implied by (floor(sqrt(q)) - 1)^4 > n.
+func exceedsECPPBound(q, n *big.Int) bool {
+	s := new(big.Int).Sqrt(q)
+	s.Sub(s, big.NewInt(1))
+	if s.Sign() <= 0 {
+		return false
+	}
+	s.Exp(s, big.NewInt(4), nil)
+	return s.Cmp(n) > 0
+}
+
+type ecppCandidate struct {
+	disc cmDiscriminant
+	m, q *big.Int
+}
+
+// ecppSearch walks the discriminant table for one n and yields descent steps
+// lazily, so that the generator can come back for another step when the
+// prime q of an earlier one turns out to be a dead end.
+type ecppSearch struct {
+	n       *big.Int
+	rnd     *rand.Rand
+	index   int
+	pending []ecppCandidate
+}
+
+func newECPPSearch(n *big.Int, rnd *rand.Rand) *ecppSearch {
+	return &ecppSearch{n: n, rnd: rnd}
+}
+
+// next performs one Atkin–Morain descent step: it finds a CM curve whose
+// order m has a probable prime factor q large enough for the Goldwasser–Kilian
+// criterion, returning the step and q. It checks ctx before every discriminant
+// and candidate curve order.
+func (s *ecppSearch) next(ctx context.Context) (*CertificateStep, *big.Int, error) {
+	discriminants := cmDiscriminants()
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		for len(s.pending) > 0 {
+			c := s.pending[0]
+			s.pending = s.pending[1:]
+
+			step, err := ecppCurve(ctx, s.n, c.disc, c.m, c.q, s.rnd)
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			if err != nil {
+				var nie *notInvertibleError
+				if errors.Is(err, errNotPrime) || (errors.As(err, &nie) && nie.factor(s.n) != nil) {
+					return nil, nil, errNotPrime
+				}
+				continue
+			}
+			return step, c.q, nil
+		}
+
+		if s.index >= len(discriminants) {
+			return nil, nil, errNoECPPStep
+		}
+		disc := discriminants[s.index]
+		s.index++
+
+		t, v, ok := cornacchia(disc.d, s.n)
+		if !ok {
+			continue
+		}
+		for _, m := range curveOrders(disc.d, s.n, t, v) {
+			q, _ := removeSmallFactors(m)
+			if q.Cmp(s.n) >= 0 || !exceedsECPPBound(q, s.n) || !q.ProbablyPrime(certificateProbablePrimeRounds) {
+				continue
+			}
+			s.pending = append(s.pending, ecppCandidate{disc: disc, m: m, q: q})
+		}
+	}
+}
+
+// ecppCurve builds a curve of order m with complex multiplication by d and a point P whose multiple [m/q]P has order q.
+func ecppCurve(ctx context.Context, n *big.Int, disc cmDiscriminant, m, q *big.Int, rnd *rand.Rand) (*CertificateStep, error) {
+	nextCurve, err := ecppCurveCandidates(n, disc, rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	cofactor := new(big.Int).Quo(m, q)
+	for attempt := 0; attempt < maxECPPCurveAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		curve := nextCurve(attempt)
+		if !curve.isNonSingular() {
+			continue
+		}
+		p, err := randomCurvePoint(curve, rnd)
+		if err != nil {
+			return nil, err
+		}
+
+		mp, err := curve.multiply(p, m)
+		if err != nil {
+			return nil, err
+		}
+		if !mp.infinity {
+			continue
+		}
+		qp, err := curve.multiply(p, cofactor)
+		if err != nil {
+			return nil, err
+		}
+		if qp.infinity {
+			continue
+		}
+
+		return &CertificateStep{
+			Method: CertificateMethodECPP,
+			N:      n.String(),
+			A:      curve.a.String(),
+			B:      curve.b.String(),
+			M:      m.String(),
+			Q:      q.String(),
+			X:      p.x.String(),
+			Y:      p.y.String(),
+		}, nil
+	}
+	return nil, errNoECPPStep
+}
+
+// ecppCurveCandidates returns a generator of curves with j-invariant a root of
+// the Hilbert class polynomial of d, alternating between a curve and its
+// quadratic twist. For d = -3 and d = -4 (j = 0 and j = 1728) the extra twists
+// are reached by drawing random coefficients.
+func ecppCurveCandidates(n *big.Int, disc cmDiscriminant, rnd *rand.Rand) (func(int) *ellipticCurve, error) {
+	zero := big.NewInt(0)
+	switch disc.d {
+	case -3:
+		return func(int) *ellipticCurve {
+			return newEllipticCurve(zero, randomUnit(n, rnd), n)
+		}, nil
+	case -4:
+		return func(int) *ellipticCurve {
+			return newEllipticCurve(randomUnit(n, rnd), zero, n)
+		}, nil
+	}
+
+	coefficients, err := hilbertClassPolynomial(disc.d)
+	if err != nil {
+		return nil, err
+	}
+	j, err := newModPolynomial(coefficients, n).findRoot(n, rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// k = j / (1728 - j), E: y^2 = x^3 + 3k x + 2k
+	denominator := new(big.Int).Sub(big.NewInt(jInvariant1728), j)
+	inv := new(big.Int).ModInverse(denominator.Mod(denominator, n), n)
+	if inv == nil {
+		return nil, errNoECPPStep
+	}
+	k := new(big.Int).Mul(j, inv)
+	a := new(big.Int).Mul(k, big.NewInt(3))
+	b := new(big.Int).Mul(k, big.NewInt(2))
+
+	c := quadraticNonResidue(n, rnd)
+	c2 := new(big.Int).Mul(c, c)
+	c3 := new(big.Int).Mul(c2, c)
+	twistA := new(big.Int).Mul(a, c2)
+	twistB := new(big.Int).Mul(b, c3)
+
+	return func(attempt int) *ellipticCurve {
+		if attempt%2 == 0 {
+			return newEllipticCurve(a, b, n)
+		}
+		return newEllipticCurve(twistA, twistB, n)
+	}, nil
+}
+
+func randomUnit(n *big.Int, rnd *rand.Rand) *big.Int {
+	for {
+		v := new(big.Int).Rand(rnd, n)
+		if v.Sign() != 0 {
+			return v
+		}
+	}
+}
+
+func quadraticNonResidue(n *big.Int, rnd *rand.Rand) *big.Int {
+	for {
+		c := randomUnit(n, rnd)
+		if big.Jacobi(c, n) == -1 {
+			return c
+		}
+	}
+}
+
+// randomCurvePoint picks a random affine point by lifting a random abscissa.
+func randomCurvePoint(curve *ellipticCurve, rnd *rand.Rand) (ecPoint, error) {
+	for {
+		x := new(big.Int).Rand(rnd, curve.n)
+		rhs := curve.rhs(x)
+		if rhs.Sign() == 0 || big.Jacobi(rhs, curve.n) != 1 {
+			continue
+		}
+		y := new(big.Int).ModSqrt(rhs, curve.n)
+		if y == nil {
+			continue
+		}
+		p := ecPoint{x: x, y: y}
+		if !curve.contains(p) {
+			return ecPoint{}, errNotPrime
+		}
+		return p, nil
+	}
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ellipticCurve is the short Weierstrass curve y^2 = x^3 + ax + b over Z/nZ.
+// n is not assumed to be prime: whenever a division is impossible the
+// operation fails with a notInvertibleError carrying gcd(denominator, n).
+type ellipticCurve struct {
+	a, b, n *big.Int
+}
+
+// ecPoint is an affine point; the zero value with infinity set is the identity.
+type ecPoint struct {
+	x, y     *big.Int
+	infinity bool
+}
+
+type notInvertibleError struct {
+	divisor *big.Int
+}
+
+func (e *notInvertibleError) Error() string {
+	return fmt.Sprintf("element not invertible, gcd with modulus is %s", e.divisor)
+}
+
+// factor returns the non-trivial divisor of the modulus exposed by the failure, if any.
+func (e *notInvertibleError) factor(n *big.Int) *big.Int {
+	if e.divisor.Cmp(big.NewInt(1)) > 0 && e.divisor.Cmp(n) < 0 {
+		return e.divisor
+	}
+	return nil
+}
+
+func newEllipticCurve(a, b, n *big.Int) *ellipticCurve {
+	return &ellipticCurve{
+		a: new(big.Int).Mod(a, n),
+		b: new(big.Int).Mod(b, n),
+		n: n,
+	}
+}
+
+// isNonSingular reports whether the discriminant 4a^3 + 27b^2 is a unit modulo n.
+func (c *ellipticCurve) isNonSingular() bool {
+	disc := new(big.Int).Exp(c.a, big.NewInt(3), c.n)
+	disc.Mul(disc, big.NewInt(4))
+	b2 := new(big.Int).Mul(c.b, c.b)
+	disc.Add(disc, b2.Mul(b2, big.NewInt(27)))
+	disc.Mod(disc, c.n)
+	return new(big.Int).GCD(nil, nil, disc, c.n).Cmp(big.NewInt(1)) == 0
+}
+
+// rhs evaluates x^3 + ax + b modulo n.
+func (c *ellipticCurve) rhs(x *big.Int) *big.Int {
+	v := new(big.Int).Mul(x, x)
+	v.Add(v, c.a)
+	v.Mul(v, x)
+	v.Add(v, c.b)
+	return v.Mod(v, c.n)
+}
+
+func (c *ellipticCurve) contains(p ecPoint) bool {
+	if p.infinity {
+		return true
+	}
+	lhs := new(big.Int).Mul(p.y, p.y)
+	lhs.Mod(lhs, c.n)
+	return lhs.Cmp(c.rhs(p.x)) == 0
+}
+
+func (c *ellipticCurve) inverse(v *big.Int) (*big.Int, error) {
+	inv := new(big.Int).ModInverse(v, c.n)
+	if inv == nil {
+		return nil, &notInvertibleError{divisor: new(big.Int).GCD(nil, nil, v, c.n)}
+	}
+	return inv, nil
+}
+
+func (c *ellipticCurve) add(p, q ecPoint) (ecPoint, error) {
+	if p.infinity {
+		return q, nil
+	}
+	if q.infinity {
+		return p, nil
+	}
+
+	dx := new(big.Int).Sub(q.x, p.x)
+	dx.Mod(dx, c.n)
+	if dx.Sign() == 0 {
+		sy := new(big.Int).Add(p.y, q.y)
+		sy.Mod(sy, c.n)
+		if sy.Sign() == 0 {
+			return ecPoint{infinity: true}, nil
+		}
+		if p.y.Cmp(q.y) == 0 {
+			return c.double(p)
+		}
+		// Equal x but y neither equal nor opposite can only happen modulo a composite
+		return ecPoint{}, &notInvertibleError{divisor: new(big.Int).GCD(nil, nil, sy, c.n)}
+	}
+
+	inv, err := c.inverse(dx)
+	if err != nil {
+		return ecPoint{}, err
+	}
+	lambda := new(big.Int).Sub(q.y, p.y)
+	lambda.Mul(lambda, inv)
+	lambda.Mod(lambda, c.n)
+
+	return c.chord(p, q.x, lambda), nil
+}
+
+func (c *ellipticCurve) double(p ecPoint) (ecPoint, error) {
+	if p.infinity || p.y.Sign() == 0 {
+		return ecPoint{infinity: true}, nil
+	}
+
+	inv, err := c.inverse(new(big.Int).Lsh(p.y, 1))
+	if err != nil {
+		return ecPoint{}, err
+	}
+	lambda := new(big.Int).Mul(p.x, p.x)
+	lambda.Mul(lambda, big.NewInt(3))
+	lambda.Add(lambda, c.a)
+	lambda.Mul(lambda, inv)
+	lambda.Mod(lambda, c.n)
+
+	return c.chord(p, p.x, lambda), nil
+}
+
+// chord completes an addition of p and a point with abscissa qx along slope lambda.
+func (c *ellipticCurve) chord(p ecPoint, qx, lambda *big.Int) ecPoint {
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, p.x)
+	x.Sub(x, qx)
+	x.Mod(x, c.n)
+
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, lambda)
+	y.Sub(y, p.y)
+	y.Mod(y, c.n)
+
+	return ecPoint{x: x, y: y}
+}
+
+// multiply computes [k]p with left-to-right double-and-add for k >= 0.
+func (c *ellipticCurve) multiply(p ecPoint, k *big.Int) (ecPoint, error) {
+	result := ecPoint{infinity: true}
+	var err error
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		if result, err = c.double(result); err != nil {
+			return ecPoint{}, err
+		}
+		if k.Bit(i) == 1 {
+			if result, err = c.add(result, p); err != nil {
+				return ecPoint{}, err
+			}
+		}
+	}
+	return result, nil
+}
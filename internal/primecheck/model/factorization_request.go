@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type FactorizationRequest struct {
+	requestID  int32
+	userID     int32
+	numberText string
+	timestamp  time.Time
+}
+
+func NewFactorizationRequest(requestID, userID int32, numberText string, now time.Time) *FactorizationRequest {
+	return &FactorizationRequest{
+		requestID:  requestID,
+		userID:     userID,
+		numberText: numberText,
+		timestamp:  now,
+	}
+}
+
+func (f *FactorizationRequest) RequestID() int32 {
+	return f.requestID
+}
+
+func (f *FactorizationRequest) UserID() int32 {
+	return f.userID
+}
+
+func (f *FactorizationRequest) NumberText() string {
+	return f.numberText
+}
+
+func (f *FactorizationRequest) Timestamp() time.Time {
+	return f.timestamp
+}
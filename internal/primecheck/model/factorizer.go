@@ -0,0 +1,257 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
+)
+
+const (
+	// Rounds of Miller-Rabin (on top of Baillie-PSW) used to accept a factor as prime
+	factorProbablePrimeRounds = 20
+	// Pollard rho budget per composite before moving on to p-1 and ECM
+	factorRhoIterations = 1 << 16
+	// Stage 1 bound of Pollard's p-1 method
+	pMinusOneBound = primality.SmallPrimeLimit - 1
+)
+
+type FactorizationStatus string
+
+const (
+	// The factorization job is queued or running
+	FactorizationStatusPending FactorizationStatus = "pending"
+	// The factorizer rejected the number or crashed
+	FactorizationStatusFailed FactorizationStatus = "failed"
+	// Every factor is (probably) prime
+	FactorizationStatusComplete FactorizationStatus = "complete"
+	// Every method was exhausted with a composite part left
+	FactorizationStatusPartial FactorizationStatus = "partial"
+	// The time budget ran out with a composite part left
+	FactorizationStatusTimedOut FactorizationStatus = "timed_out"
+)
+
+// ErrNotFactorizable is returned for a number that has no factorization, one
+// that is not an integer greater than 1.
+var ErrNotFactorizable = errors.New("only integers greater than 1 can be factorized")
+
+// PrimeFactor is a prime p with its exponent in the factorized number.
+type PrimeFactor struct {
+	Prime    string `json:"prime"`
+	Exponent int    `json:"exponent"`
+}
+
+// Factorization is the result of factorizing Number. Factors are sorted by
+// prime; Unfactored is the product of the composite parts that could not be
+// split, and of the parts not reached before the time budget ran out, and is
+// empty when the factorization is complete.
+type Factorization struct {
+	Number     string              `json:"number"`
+	Factors    []PrimeFactor       `json:"factors"`
+	Unfactored string              `json:"unfactored,omitempty"`
+	Status     FactorizationStatus `json:"status"`
+}
+
+type Factorizer struct {
+	number *big.Int
+}
+
+func NewFactorizer(numberText string) (*Factorizer, error) {
+	bigNum := new(big.Int)
+	if _, ok := bigNum.SetString(numberText, 10); !ok || bigNum.Cmp(big.NewInt(2)) < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFactorizable, numberText)
+	}
+	return &Factorizer{number: bigNum}, nil
+}
+
+// IsFactorizable reports whether numberText is an integer greater than 1. Of
+// the numbers that are not prime, only those are composite and have a
+// factorization; 0, 1 and negative numbers are neither prime nor composite.
+func IsFactorizable(numberText string) bool {
+	bigNum, ok := new(big.Int).SetString(numberText, 10)
+	return ok && bigNum.Cmp(big.NewInt(2)) >= 0
+}
+
+// factorPart is a factor found along the way together with its multiplicity in the number.
+type factorPart struct {
+	value        *big.Int
+	multiplicity int
+}
+
+// Factorize splits the number with trial division over the sieved small
+// primes, then attacks each remaining composite with Pollard's rho, Pollard's
+// p-1 and the elliptic curve method in that order. Every step checks ctx, and
+// when it is done the factors found so far are returned with the rest of the
+// number as Unfactored and FactorizationStatusTimedOut.
+func (f *Factorizer) Factorize(ctx context.Context) *Factorization {
+	exponents := make(map[string]int)
+	primes := make(map[string]*big.Int)
+	addPrime := func(p *big.Int, multiplicity int) {
+		key := p.String()
+		if _, ok := primes[key]; !ok {
+			primes[key] = p
+		}
+		exponents[key] += multiplicity
+	}
+
+	cofactor, small := removeSmallFactors(f.number)
+	for _, p := range small {
+		addPrime(p, multiplicity(f.number, p))
+	}
+
+	// Seeded from n so that the same number always takes the same path
+	rnd := rand.New(rand.NewSource(int64(f.number.Uint64())))
+
+	var pending []factorPart
+	if cofactor.Cmp(big.NewInt(1)) > 0 {
+		pending = append(pending, factorPart{value: cofactor, multiplicity: 1})
+	}
+	unfactored := big.NewInt(1)
+	timedOut := false
+	leaveUnfactored := func(part factorPart) {
+		unfactored.Mul(unfactored, new(big.Int).Exp(part.value, big.NewInt(int64(part.multiplicity)), nil))
+	}
+	for len(pending) > 0 {
+		part := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		// The parts left once the time budget ran out are reported as they are
+		if ctx.Err() != nil {
+			timedOut = true
+			leaveUnfactored(part)
+			continue
+		}
+
+		// Every prime below primality.SmallPrimeLimit is gone, so anything under its square is prime
+		isPrime := part.value.Cmp(primality.TrialDivisionLimit) < 0
+		if !isPrime {
+			var err error
+			if isPrime, err = probablyPrimeContext(ctx, part.value, factorProbablePrimeRounds); err != nil {
+				timedOut = true
+				leaveUnfactored(part)
+				continue
+			}
+		}
+		if isPrime {
+			addPrime(part.value, part.multiplicity)
+			continue
+		}
+
+		base, k, err := perfectPower(ctx, part.value)
+		if err != nil {
+			timedOut = true
+			leaveUnfactored(part)
+			continue
+		}
+		if k > 1 {
+			pending = append(pending, factorPart{value: base, multiplicity: part.multiplicity * k})
+			continue
+		}
+
+		d := findFactor(ctx, part.value, rnd)
+		if d == nil {
+			if ctx.Err() != nil {
+				timedOut = true
+			}
+			leaveUnfactored(part)
+			continue
+		}
+		pending = append(pending,
+			factorPart{value: d, multiplicity: part.multiplicity},
+			factorPart{value: new(big.Int).Quo(part.value, d), multiplicity: part.multiplicity},
+		)
+	}
+
+	result := &Factorization{
+		Number: f.number.String(),
+		Status: FactorizationStatusComplete,
+	}
+	keys := make([]string, 0, len(primes))
+	for key := range primes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return primes[keys[i]].Cmp(primes[keys[j]]) < 0 })
+	for _, key := range keys {
+		result.Factors = append(result.Factors, PrimeFactor{Prime: key, Exponent: exponents[key]})
+	}
+	if unfactored.Cmp(big.NewInt(1)) > 0 {
+		result.Unfactored = unfactored.String()
+		result.Status = FactorizationStatusPartial
+		if timedOut {
+			result.Status = FactorizationStatusTimedOut
+		}
+	}
+	return result
+}
+
+// findFactor returns a non-trivial factor of the composite n, or nil when
+// every method gave up or ctx is done.
+func findFactor(ctx context.Context, n *big.Int, rnd *rand.Rand) *big.Int {
+	if d := pollardRho(ctx, n, factorRhoIterations); d != nil {
+		return d
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if d := pollardPMinusOne(ctx, n, pMinusOneBound); d != nil {
+		return d
+	}
+	return ecm(ctx, n, rnd)
+}
+
+// multiplicity returns the exponent of the prime p in n.
+func multiplicity(n, p *big.Int) int {
+	count := 0
+	rest := new(big.Int).Set(n)
+	r := new(big.Int)
+	for {
+		q, _ := new(big.Int).QuoRem(rest, p, r)
+		if r.Sign() != 0 {
+			return count
+		}
+		rest = q
+		count++
+	}
+}
+
+// perfectPower returns (b, k) with n = b^k for the largest such k, or (n, 1)
+// when n is not a perfect power. It checks ctx before every root it takes.
+func perfectPower(ctx context.Context, n *big.Int) (*big.Int, int, error) {
+	for k := n.BitLen(); k >= 2; k-- {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		root := integerRoot(n, k)
+		if root.Cmp(big.NewInt(1)) > 0 && new(big.Int).Exp(root, big.NewInt(int64(k)), nil).Cmp(n) == 0 {
+			return root, k, nil
+		}
+	}
+	return n, 1, nil
+}
+
+// integerRoot returns floor(n^(1/k)) for n > 0 using Newton's method.
+func integerRoot(n *big.Int, k int) *big.Int {
+	if k == 2 {
+		return new(big.Int).Sqrt(n)
+	}
+	bigK := big.NewInt(int64(k))
+	kMinus1 := big.NewInt(int64(k - 1))
+
+	// Start above the root: 2^ceil(bits/k) > n^(1/k)
+	x := new(big.Int).Lsh(big.NewInt(1), uint((n.BitLen()+k-1)/k))
+	for {
+		// y = ((k-1)x + n / x^(k-1)) / k
+		y := new(big.Int).Exp(x, kMinus1, nil)
+		y.Quo(n, y)
+		y.Add(y, new(big.Int).Mul(kMinus1, x))
+		y.Quo(y, bigK)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x = y
+	}
+}
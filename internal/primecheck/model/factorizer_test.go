@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFactorizerFactorize(t *testing.T) {
+	tests := []struct {
+		name        string
+		number      string
+		wantFactors []PrimeFactor
+	}{
+		{
+			name:        "prime",
+			number:      "2",
+			wantFactors: []PrimeFactor{{Prime: "2", Exponent: 1}},
+		},
+		{
+			name:        "prime power",
+			number:      "1024",
+			wantFactors: []PrimeFactor{{Prime: "2", Exponent: 10}},
+		},
+		{
+			name:        "carmichael 561",
+			number:      "561",
+			wantFactors: []PrimeFactor{{Prime: "3", Exponent: 1}, {Prime: "11", Exponent: 1}, {Prime: "17", Exponent: 1}},
+		},
+		{
+			name:        "carmichael 1729",
+			number:      "1729",
+			wantFactors: []PrimeFactor{{Prime: "7", Exponent: 1}, {Prime: "13", Exponent: 1}, {Prime: "19", Exponent: 1}},
+		},
+		{
+			name:        "fermat number 2^64+1",
+			number:      "18446744073709551617",
+			wantFactors: []PrimeFactor{{Prime: "274177", Exponent: 1}, {Prime: "67280421310721", Exponent: 1}},
+		},
+		{
+			name:        "semiprime of twin-sized primes",
+			number:      "1000000016000000063",
+			wantFactors: []PrimeFactor{{Prime: "1000000007", Exponent: 1}, {Prime: "1000000009", Exponent: 1}},
+		},
+		{
+			name:        "perfect power of a small prime",
+			number:      "12157665459056928801",
+			wantFactors: []PrimeFactor{{Prime: "3", Exponent: 40}},
+		},
+		{
+			name:        "mersenne prime 2^127-1",
+			number:      "170141183460469231731687303715884105727",
+			wantFactors: []PrimeFactor{{Prime: "170141183460469231731687303715884105727", Exponent: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factorizer, err := NewFactorizer(tt.number)
+			if err != nil {
+				t.Fatalf("NewFactorizer() error = %v", err)
+			}
+			got := factorizer.Factorize(context.Background())
+			if got.Status != FactorizationStatusComplete {
+				t.Errorf("Status = %s, want %s", got.Status, FactorizationStatusComplete)
+			}
+			if got.Unfactored != "" {
+				t.Errorf("Unfactored = %s, want empty", got.Unfactored)
+			}
+			if !reflect.DeepEqual(got.Factors, tt.wantFactors) {
+				t.Errorf("Factors = %v, want %v", got.Factors, tt.wantFactors)
+			}
+		})
+	}
+}
+
+func TestNewFactorizerRejectsNumbersWithoutFactorization(t *testing.T) {
+	for _, number := range []string{"-7", "0", "1", "12a", ""} {
+		t.Run(number, func(t *testing.T) {
+			if _, err := NewFactorizer(number); !errors.Is(err, ErrNotFactorizable) {
+				t.Errorf("NewFactorizer() error = %v, want %v", err, ErrNotFactorizable)
+			}
+			if IsFactorizable(number) {
+				t.Error("IsFactorizable() = true, want false")
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+)
+
+// quadraticForm is a primitive positive definite binary quadratic form ax^2 + bxy + cy^2.
+type quadraticForm struct {
+	a, b, c int64
+}
+
+// reducedForms enumerates the reduced forms of discriminant d < 0; their count is the class number h(d).
+func reducedForms(d int64) []quadraticForm {
+	var forms []quadraticForm
+	for a := int64(1); 3*a*a <= -d; a++ {
+		for b := -a + 1; b <= a; b++ {
+			num := b*b - d
+			if num%(4*a) != 0 {
+				continue
+			}
+			c := num / (4 * a)
+			if c < a || (a == c && b < 0) {
+				continue
+			}
+			if gcd64(gcd64(a, b), c) != 1 {
+				continue
+			}
+			forms = append(forms, quadraticForm{a: a, b: b, c: c})
+		}
+	}
+	return forms
+}
+
+func gcd64(a, b int64) int64 {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+var hilbertCache sync.Map // int64 discriminant -> []*big.Int
+
+// hilbertClassPolynomial returns the integer coefficients (lowest degree first)
+// of the Hilbert class polynomial H_d(X) = prod (X - j(tau_f)) over the reduced
+// forms f of discriminant d, evaluated with enough floating point precision to
+// round every coefficient exactly.
+func hilbertClassPolynomial(d int64) ([]*big.Int, error) {
+	if cached, ok := hilbertCache.Load(d); ok {
+		return cached.([]*big.Int), nil
+	}
+
+	forms := reducedForms(d)
+	sqrtAbsD := math.Sqrt(float64(-d))
+	bits := 64.0 + 2*float64(len(forms))
+	for _, f := range forms {
+		bits += math.Pi * sqrtAbsD / float64(f.a) / math.Ln2
+	}
+	prec := uint(bits)
+
+	pi := piFloat(prec)
+	poly := []complexFloat{newComplexFloat(prec, 1, 0)}
+	for _, f := range forms {
+		j := jInvariant(f, d, pi, prec)
+		next := make([]complexFloat, len(poly)+1)
+		for i := range next {
+			next[i] = newComplexFloat(prec, 0, 0)
+			if i > 0 {
+				next[i] = next[i].add(poly[i-1])
+			}
+			if i < len(poly) {
+				next[i] = next[i].sub(poly[i].mul(j))
+			}
+		}
+		poly = next
+	}
+
+	half := big.NewFloat(0.5)
+	tolerance := big.NewFloat(0.25)
+	coefficients := make([]*big.Int, len(poly))
+	for i, c := range poly {
+		rounded := new(big.Float).SetPrec(prec)
+		if c.re.Sign() >= 0 {
+			rounded.Add(c.re, half)
+		} else {
+			rounded.Sub(c.re, half)
+		}
+		coefficients[i], _ = rounded.Int(nil)
+
+		residual := new(big.Float).SetPrec(prec).SetInt(coefficients[i])
+		residual.Sub(residual, c.re)
+		if residual.Abs(residual).Cmp(tolerance) > 0 || new(big.Float).Abs(c.im).Cmp(tolerance) > 0 {
+			return nil, fmt.Errorf("insufficient precision for Hilbert class polynomial of discriminant %d", d)
+		}
+	}
+
+	hilbertCache.Store(d, coefficients)
+	return coefficients, nil
+}
+
+// jInvariant evaluates j(tau) = E4(tau)^3 / Delta(tau) at tau = (-b + sqrt(d)) / 2a
+// using the q-expansions of the Eisenstein series E4 and of the discriminant
+// Delta = q * prod (1 - q^n)^24, the product taken from Euler's pentagonal series.
+func jInvariant(f quadraticForm, d int64, pi *big.Float, prec uint) complexFloat {
+	// q = exp(2*pi*i*tau) = exp(-pi*sqrt(|d|)/a) * exp(-i*pi*b/a)
+	sqrtAbsD := new(big.Float).SetPrec(prec).SetInt64(-d)
+	sqrtAbsD.Sqrt(sqrtAbsD)
+	a := new(big.Float).SetPrec(prec).SetInt64(f.a)
+
+	modulus := new(big.Float).SetPrec(prec).Mul(pi, sqrtAbsD)
+	modulus.Quo(modulus, a)
+	modulus.Neg(modulus)
+	modulus = expFloat(modulus, prec)
+
+	angle := new(big.Float).SetPrec(prec).SetInt64(-f.b)
+	angle.Mul(angle, pi)
+	angle.Quo(angle, a)
+	sin, cos := sinCosFloat(angle, prec)
+
+	q := complexFloat{
+		re: new(big.Float).SetPrec(prec).Mul(modulus, cos),
+		im: new(big.Float).SetPrec(prec).Mul(modulus, sin),
+	}
+
+	// |q|^terms must drop below 2^-prec
+	decay := math.Pi * math.Sqrt(float64(-d)) / float64(f.a) / math.Ln2
+	terms := int(float64(prec)/decay) + 2
+
+	powers := make([]complexFloat, terms+1)
+	powers[0] = newComplexFloat(prec, 1, 0)
+	for i := 1; i <= terms; i++ {
+		powers[i] = powers[i-1].mul(q)
+	}
+
+	// prod (1 - q^n) = sum_k (-1)^k q^(k(3k-1)/2) over all integers k
+	eta := newComplexFloat(prec, 1, 0)
+	for k := int64(1); ; k++ {
+		g1 := k * (3*k - 1) / 2
+		g2 := k * (3*k + 1) / 2
+		if g1 > int64(terms) {
+			break
+		}
+		term := powers[g1]
+		if g2 <= int64(terms) {
+			term = term.add(powers[g2])
+		}
+		if k%2 == 1 {
+			eta = eta.sub(term)
+		} else {
+			eta = eta.add(term)
+		}
+	}
+	eta2 := eta.mul(eta)
+	eta4 := eta2.mul(eta2)
+	eta8 := eta4.mul(eta4)
+	eta24 := eta8.mul(eta8).mul(eta8)
+	delta := q.mul(eta24)
+
+	// E4 = 1 + 240 * sum sigma_3(n) q^n
+	sum := newComplexFloat(prec, 0, 0)
+	for n := 1; n <= terms; n++ {
+		sum = sum.add(powers[n].scale(sigma3(int64(n))))
+	}
+	e4 := newComplexFloat(prec, 1, 0).add(sum.scale(240))
+
+	return e4.mul(e4).mul(e4).div(delta)
+}
+
+func sigma3(n int64) int64 {
+	var s int64
+	for i := int64(1); i*i <= n; i++ {
+		if n%i == 0 {
+			s += i * i * i
+			if j := n / i; j != i {
+				s += j * j * j
+			}
+		}
+	}
+	return s
+}
+
+// complexFloat is a minimal arbitrary precision complex number.
+type complexFloat struct {
+	re, im *big.Float
+}
+
+func newComplexFloat(prec uint, re, im int64) complexFloat {
+	return complexFloat{
+		re: new(big.Float).SetPrec(prec).SetInt64(re),
+		im: new(big.Float).SetPrec(prec).SetInt64(im),
+	}
+}
+
+func (z complexFloat) prec() uint {
+	return z.re.Prec()
+}
+
+func (z complexFloat) add(w complexFloat) complexFloat {
+	return complexFloat{
+		re: new(big.Float).SetPrec(z.prec()).Add(z.re, w.re),
+		im: new(big.Float).SetPrec(z.prec()).Add(z.im, w.im),
+	}
+}
+
+func (z complexFloat) sub(w complexFloat) complexFloat {
+	return complexFloat{
+		re: new(big.Float).SetPrec(z.prec()).Sub(z.re, w.re),
+		im: new(big.Float).SetPrec(z.prec()).Sub(z.im, w.im),
+	}
+}
+
+func (z complexFloat) mul(w complexFloat) complexFloat {
+	prec := z.prec()
+	t := new(big.Float).SetPrec(prec)
+	re := new(big.Float).SetPrec(prec).Mul(z.re, w.re)
+	re.Sub(re, t.Mul(z.im, w.im))
+	im := new(big.Float).SetPrec(prec).Mul(z.re, w.im)
+	im.Add(im, t.Mul(z.im, w.re))
+	return complexFloat{re: re, im: im}
+}
+
+func (z complexFloat) scale(k int64) complexFloat {
+	f := new(big.Float).SetPrec(z.prec()).SetInt64(k)
+	return complexFloat{
+		re: new(big.Float).SetPrec(z.prec()).Mul(z.re, f),
+		im: new(big.Float).SetPrec(z.prec()).Mul(z.im, f),
+	}
+}
+
+func (z complexFloat) div(w complexFloat) complexFloat {
+	prec := z.prec()
+	t := new(big.Float).SetPrec(prec)
+	norm := new(big.Float).SetPrec(prec).Mul(w.re, w.re)
+	norm.Add(norm, t.Mul(w.im, w.im))
+
+	re := new(big.Float).SetPrec(prec).Mul(z.re, w.re)
+	re.Add(re, t.Mul(z.im, w.im))
+	re.Quo(re, norm)
+	im := new(big.Float).SetPrec(prec).Mul(z.im, w.re)
+	im.Sub(im, t.Mul(z.re, w.im))
+	im.Quo(im, norm)
+	return complexFloat{re: re, im: im}
+}
+
+// belowEpsilon reports whether |x| < 2^-prec.
+func belowEpsilon(x *big.Float, prec uint) bool {
+	return x.Sign() == 0 || x.MantExp(nil) < -int(prec)
+}
+
+// piFloat computes pi with Machin's formula 16*atan(1/5) - 4*atan(1/239).
+func piFloat(prec uint) *big.Float {
+	wp := prec + 16
+	pi := new(big.Float).SetPrec(wp).Mul(atanInverse(5, wp), big.NewFloat(16))
+	pi.Sub(pi, new(big.Float).SetPrec(wp).Mul(atanInverse(239, wp), big.NewFloat(4)))
+	return pi.SetPrec(prec)
+}
+
+// atanInverse computes atan(1/k) = sum (-1)^n / ((2n+1) k^(2n+1)).
+func atanInverse(k int64, prec uint) *big.Float {
+	kk := new(big.Float).SetPrec(prec).SetInt64(k * k)
+	power := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), new(big.Float).SetPrec(prec).SetInt64(k))
+	sum := new(big.Float).SetPrec(prec)
+	term := new(big.Float).SetPrec(prec)
+	for n := int64(0); ; n++ {
+		term.Quo(power, new(big.Float).SetPrec(prec).SetInt64(2*n+1))
+		if n%2 == 0 {
+			sum.Add(sum, term)
+		} else {
+			sum.Sub(sum, term)
+		}
+		if belowEpsilon(term, prec) {
+			return sum
+		}
+		power.Quo(power, kk)
+	}
+}
+
+// expFloat computes e^x by halving the argument until the Taylor series
+// converges quickly and squaring the result back.
+func expFloat(x *big.Float, prec uint) *big.Float {
+	halvings := 0
+	if x.Sign() != 0 {
+		halvings = max(0, x.MantExp(nil)+8)
+	}
+	wp := prec + uint(halvings) + 16
+
+	r := new(big.Float).SetPrec(wp).SetMantExp(x, -halvings)
+	sum := new(big.Float).SetPrec(wp).SetInt64(1)
+	term := new(big.Float).SetPrec(wp).SetInt64(1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetPrec(wp).SetInt64(n))
+		sum.Add(sum, term)
+		if belowEpsilon(term, wp) {
+			break
+		}
+	}
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetPrec(prec)
+}
+
+// sinCosFloat evaluates the Taylor series of sin and cos for |x| <= pi.
+func sinCosFloat(x *big.Float, prec uint) (*big.Float, *big.Float) {
+	wp := prec + 16
+	sin := new(big.Float).SetPrec(wp)
+	cos := new(big.Float).SetPrec(wp)
+	term := new(big.Float).SetPrec(wp).SetInt64(1)
+	for n := int64(0); ; n++ {
+		switch n % 4 {
+		case 0:
+			cos.Add(cos, term)
+		case 1:
+			sin.Add(sin, term)
+		case 2:
+			cos.Sub(cos, term)
+		case 3:
+			sin.Sub(sin, term)
+		}
+		term.Mul(term, x)
+		term.Quo(term, new(big.Float).SetPrec(wp).SetInt64(n+1))
+		if n > 4 && belowEpsilon(term, wp) {
+			break
+		}
+	}
+	return sin.SetPrec(prec), cos.SetPrec(prec)
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+)
+
+const (
+	// Moduli below this size use big.Int.Exp and big.Int.ProbablyPrime directly,
+	// which finish quickly enough that they need no cancellation points
+	cancellableExpBits = 4096
+	// Exponent bits processed between two cancellation checks
+	expContextCheckInterval = 64
+)
+
+// expContext computes x^y mod m like big.Int.Exp, but checks ctx between
+// blocks of exponent bits. For large moduli the plain square-and-multiply
+// with Karatsuba products is also faster than the word-by-word Montgomery
+// reduction big.Int.Exp uses.
+func expContext(ctx context.Context, x, y, m *big.Int) (*big.Int, error) {
+	if m.BitLen() < cancellableExpBits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return new(big.Int).Exp(x, y, m), nil
+	}
+
+	base := new(big.Int).Mod(x, m)
+	result := big.NewInt(1)
+	t := new(big.Int)
+	for i := y.BitLen() - 1; i >= 0; i-- {
+		if i%expContextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		t.Mul(result, result)
+		result.Mod(t, m)
+		if y.Bit(i) == 1 {
+			t.Mul(result, base)
+			result.Mod(t, m)
+		}
+	}
+	return result, nil
+}
+
+// probablyPrimeContext is big.Int.ProbablyPrime(rounds) with cancellation.
+// Small n go straight to ProbablyPrime. Larger n run the Miller-Rabin rounds
+// through expContext with base 2 followed by pseudorandom bases seeded from n;
+// the Baillie-PSW part is left out there because it cannot be interrupted and
+// the rounds alone already bound the error by 4^-rounds.
+func probablyPrimeContext(ctx context.Context, n *big.Int, rounds int) (bool, error) {
+	if n.BitLen() < cancellableExpBits {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		return n.ProbablyPrime(rounds), nil
+	}
+	if _, factors := removeSmallFactors(n); len(factors) > 0 {
+		return false, nil
+	}
+	return millerRabinContext(ctx, n, rounds)
+}
+
+// millerRabinContext runs Miller-Rabin with base 2 followed by rounds
+// pseudorandom bases seeded from n, without the Baillie-PSW test of
+// ProbablyPrime, so that a composite passes with probability at most 4^-rounds.
+func millerRabinContext(ctx context.Context, n *big.Int, rounds int) (bool, error) {
+	if isPrime, decided := decideTinyOrEven(n); decided {
+		return isPrime, nil
+	}
+
+	rnd := rand.New(rand.NewSource(int64(n.Uint64())))
+	limit := new(big.Int).Sub(n, big.NewInt(3))
+	base := big.NewInt(2)
+	for round := 0; round <= rounds; round++ {
+		if round > 0 {
+			// base in [2, n-2]
+			base.Rand(rnd, limit).Add(base, big.NewInt(2))
+		}
+		probable, err := strongProbablePrime(ctx, n, base)
+		if err != nil || !probable {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// millerRabinBasesContext runs Miller-Rabin with the given fixed bases, which
+// is a proof of primality below the bound the base set is known to cover.
+// Bases are reduced mod n, and those divisible by n are skipped.
+func millerRabinBasesContext(ctx context.Context, n *big.Int, bases []int64) (bool, error) {
+	if isPrime, decided := decideTinyOrEven(n); decided {
+		return isPrime, nil
+	}
+
+	base := new(big.Int)
+	for _, b := range bases {
+		if base.Mod(base.SetInt64(b), n).Sign() == 0 {
+			continue
+		}
+		probable, err := strongProbablePrime(ctx, n, base)
+		if err != nil || !probable {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// decideTinyOrEven settles n < 5 and even n, which the Miller-Rabin rounds
+// cannot take.
+func decideTinyOrEven(n *big.Int) (isPrime bool, decided bool) {
+	if n.Cmp(big.NewInt(5)) < 0 {
+		return n.Cmp(big.NewInt(2)) == 0 || n.Cmp(big.NewInt(3)) == 0, true
+	}
+	if n.Bit(0) == 0 {
+		return false, true
+	}
+	return false, false
+}
+
+// strongProbablePrime reports whether odd n > 4 is a strong probable prime to
+// the given base, that is whether the base is not a witness of compositeness.
+func strongProbablePrime(ctx context.Context, n, base *big.Int) (bool, error) {
+	one := big.NewInt(1)
+	nm1 := new(big.Int).Sub(n, one)
+	s := nm1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nm1, s)
+
+	y, err := expContext(ctx, base, d, n)
+	if err != nil {
+		return false, err
+	}
+	if y.Cmp(one) == 0 || y.Cmp(nm1) == 0 {
+		return true, nil
+	}
+	t := new(big.Int)
+	for j := uint(1); j < s; j++ {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		t.Mul(y, y)
+		y.Mod(t, n)
+		if y.Cmp(nm1) == 0 {
+			return true, nil
+		}
+		if y.Cmp(one) == 0 {
+			return false, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,193 @@
+package model
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+)
+
+// modPolynomial is a polynomial over Z/nZ with coefficients stored lowest degree first.
+// The zero polynomial is the empty slice.
+type modPolynomial []*big.Int
+
+var errNoPolynomialRoot = errors.New("polynomial has no root modulo n")
+
+func newModPolynomial(coefficients []*big.Int, n *big.Int) modPolynomial {
+	p := make(modPolynomial, len(coefficients))
+	for i, c := range coefficients {
+		p[i] = new(big.Int).Mod(c, n)
+	}
+	return p.trim()
+}
+
+func (p modPolynomial) trim() modPolynomial {
+	for len(p) > 0 && p[len(p)-1].Sign() == 0 {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+func (p modPolynomial) degree() int {
+	return len(p) - 1
+}
+
+func (p modPolynomial) sub(q modPolynomial, n *big.Int) modPolynomial {
+	size := max(len(p), len(q))
+	r := make(modPolynomial, size)
+	for i := range r {
+		r[i] = new(big.Int)
+		if i < len(p) {
+			r[i].Set(p[i])
+		}
+		if i < len(q) {
+			r[i].Sub(r[i], q[i])
+		}
+		r[i].Mod(r[i], n)
+	}
+	return r.trim()
+}
+
+func (p modPolynomial) mul(q modPolynomial, n *big.Int) modPolynomial {
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	r := make(modPolynomial, len(p)+len(q)-1)
+	for i := range r {
+		r[i] = new(big.Int)
+	}
+	t := new(big.Int)
+	for i, a := range p {
+		for j, b := range q {
+			r[i+j].Add(r[i+j], t.Mul(a, b))
+		}
+	}
+	for _, c := range r {
+		c.Mod(c, n)
+	}
+	return r.trim()
+}
+
+// divMod divides p by q, failing with notInvertibleError when the leading
+// coefficient of q is not a unit modulo n.
+func (p modPolynomial) divMod(q modPolynomial, n *big.Int) (modPolynomial, modPolynomial, error) {
+	if len(q) == 0 {
+		return nil, nil, errors.New("polynomial division by zero")
+	}
+	lead := q[len(q)-1]
+	inv := new(big.Int).ModInverse(lead, n)
+	if inv == nil {
+		return nil, nil, &notInvertibleError{divisor: new(big.Int).GCD(nil, nil, lead, n)}
+	}
+
+	rem := make(modPolynomial, len(p))
+	for i, c := range p {
+		rem[i] = new(big.Int).Set(c)
+	}
+	if len(p) < len(q) {
+		return nil, rem, nil
+	}
+
+	quo := make(modPolynomial, len(p)-len(q)+1)
+	t := new(big.Int)
+	for i := len(p) - len(q); i >= 0; i-- {
+		coeff := new(big.Int).Mul(rem[i+len(q)-1], inv)
+		coeff.Mod(coeff, n)
+		quo[i] = coeff
+		if coeff.Sign() == 0 {
+			continue
+		}
+		for j, c := range q {
+			rem[i+j].Sub(rem[i+j], t.Mul(coeff, c))
+			rem[i+j].Mod(rem[i+j], n)
+		}
+	}
+	return quo.trim(), rem.trim(), nil
+}
+
+func (p modPolynomial) mulMod(q, m modPolynomial, n *big.Int) (modPolynomial, error) {
+	_, r, err := p.mul(q, n).divMod(m, n)
+	return r, err
+}
+
+// powMod computes p^e modulo m.
+func (p modPolynomial) powMod(e *big.Int, m modPolynomial, n *big.Int) (modPolynomial, error) {
+	result := modPolynomial{big.NewInt(1)}
+	_, base, err := p.divMod(m, n)
+	if err != nil {
+		return nil, err
+	}
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		if result, err = result.mulMod(result, m, n); err != nil {
+			return nil, err
+		}
+		if e.Bit(i) == 1 {
+			if result, err = result.mulMod(base, m, n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+func (p modPolynomial) gcd(q modPolynomial, n *big.Int) (modPolynomial, error) {
+	a, b := p, q
+	for len(b) > 0 {
+		_, r, err := a.divMod(b, n)
+		if err != nil {
+			return nil, err
+		}
+		a, b = b, r
+	}
+	return a, nil
+}
+
+// findRoot returns a root of p modulo the probable prime n. It isolates the
+// product of linear factors with gcd(X^n - X, p) and then splits it with
+// random equal-degree (Cantor–Zassenhaus) gcds.
+func (p modPolynomial) findRoot(n *big.Int, rnd *rand.Rand) (*big.Int, error) {
+	x := modPolynomial{big.NewInt(0), big.NewInt(1)}
+	xn, err := x.powMod(n, p, n)
+	if err != nil {
+		return nil, err
+	}
+	g, err := xn.sub(x, n).gcd(p, n)
+	if err != nil {
+		return nil, err
+	}
+	if g.degree() < 1 {
+		return nil, errNoPolynomialRoot
+	}
+
+	half := new(big.Int).Rsh(n, 1)
+	one := modPolynomial{big.NewInt(1)}
+	for attempts := 0; g.degree() > 1; attempts++ {
+		if attempts > 64 {
+			return nil, errNoPolynomialRoot
+		}
+		delta := new(big.Int).Rand(rnd, n)
+		shifted := modPolynomial{delta, big.NewInt(1)}
+		power, err := shifted.powMod(half, g, n)
+		if err != nil {
+			return nil, err
+		}
+		h, err := power.sub(one, n).gcd(g, n)
+		if err != nil {
+			return nil, err
+		}
+		if h.degree() > 0 && h.degree() < g.degree() {
+			if other, _, err := g.divMod(h, n); err == nil && other.degree() < h.degree() {
+				h = other
+			}
+			g = h
+		}
+	}
+
+	// g = g1*X + g0, so the root is -g0/g1
+	inv := new(big.Int).ModInverse(g[1], n)
+	if inv == nil {
+		return nil, &notInvertibleError{divisor: new(big.Int).GCD(nil, nil, g[1], n)}
+	}
+	root := new(big.Int).Neg(g[0])
+	root.Mul(root, inv)
+	return root.Mod(root, n), nil
+}
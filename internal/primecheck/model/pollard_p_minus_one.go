@@ -0,0 +1,45 @@
+package model
+
+import (
+	"context"
+	"math/big"
+)
+
+// pollardPMinusOne runs stage 1 of Pollard's p-1 method: it raises a base to
+// every prime power up to bound and looks for a factor p of n whose p-1 is
+// bound-smooth. It returns nil when no factor is exposed or ctx is done.
+func pollardPMinusOne(ctx context.Context, n *big.Int, bound uint64) *big.Int {
+	one := big.NewInt(1)
+	// Base 3 rather than 2, which is useless against factors of Fermat numbers
+	a := big.NewInt(3)
+	g := new(big.Int)
+	e := new(big.Int)
+
+	for i, p := range smallPrimesUpTo(bound) {
+		// Every exponentiation is costly next to the check on numbers this method gets
+		if ctx.Err() != nil {
+			return nil
+		}
+		power := p
+		for power <= bound/p {
+			power *= p
+		}
+		a.Exp(a, e.SetUint64(power), n)
+
+		if i%pollardRhoBatch == 0 {
+			g.GCD(nil, nil, new(big.Int).Sub(a, one), n)
+			if g.Cmp(n) == 0 {
+				return nil
+			}
+			if g.Cmp(one) > 0 {
+				return g
+			}
+		}
+	}
+
+	g.GCD(nil, nil, new(big.Int).Sub(a, one), n)
+	if g.Cmp(one) > 0 && g.Cmp(n) < 0 {
+		return g
+	}
+	return nil
+}
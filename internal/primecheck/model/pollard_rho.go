@@ -0,0 +1,81 @@
+package model
+
+import (
+	"context"
+	"math/big"
+)
+
+const (
+	// Number of polynomial constants x^2+c tried before Pollard's rho gives up
+	pollardRhoAttempts = 8
+	// Number of products accumulated before each gcd in Brent's cycle search
+	pollardRhoBatch = 128
+)
+
+// pollardRho searches for a non-trivial factor of the composite n with Brent's
+// variant of Pollard's rho. It returns nil when no factor is found within
+// maxIterations steps per polynomial or when ctx is done, which it checks
+// every pollardRhoBatch steps.
+func pollardRho(ctx context.Context, n *big.Int, maxIterations int) *big.Int {
+	if n.Bit(0) == 0 {
+		return big.NewInt(2)
+	}
+
+	one := big.NewInt(1)
+	for c := int64(1); c <= pollardRhoAttempts; c++ {
+		constant := big.NewInt(c)
+		step := func(v *big.Int) {
+			v.Mul(v, v)
+			v.Add(v, constant)
+			v.Mod(v, n)
+		}
+
+		x, ys := new(big.Int), new(big.Int)
+		y := big.NewInt(2)
+		product := big.NewInt(1)
+		g := big.NewInt(1)
+		diff := new(big.Int)
+
+		iterations := 0
+		for r := 1; g.Cmp(one) == 0 && iterations < maxIterations; r *= 2 {
+			x.Set(y)
+			for i := 0; i < r; i++ {
+				if i%pollardRhoBatch == 0 && ctx.Err() != nil {
+					return nil
+				}
+				step(y)
+			}
+			for k := 0; k < r && g.Cmp(one) == 0; k += pollardRhoBatch {
+				if ctx.Err() != nil {
+					return nil
+				}
+				ys.Set(y)
+				batch := min(pollardRhoBatch, r-k)
+				for i := 0; i < batch; i++ {
+					step(y)
+					diff.Sub(x, y)
+					product.Mul(product, diff.Abs(diff))
+					product.Mod(product, n)
+				}
+				g.GCD(nil, nil, product, n)
+				iterations += batch
+			}
+			iterations += r
+		}
+
+		if g.Cmp(n) == 0 {
+			// The batch overshot; replay it one step at a time
+			g.SetInt64(1)
+			for i := 0; i < pollardRhoBatch && g.Cmp(one) == 0; i++ {
+				step(ys)
+				diff.Sub(x, ys)
+				g.GCD(nil, nil, diff.Abs(diff), n)
+			}
+		}
+
+		if g.Cmp(one) > 0 && g.Cmp(n) < 0 {
+			return g
+		}
+	}
+	return nil
+}
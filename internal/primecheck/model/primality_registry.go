@@ -0,0 +1,397 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
+)
+
+const (
+	// Numbers up to this size are decided by trial division
+	tinyNumberBits = 24
+	// Miller-Rabin with these bases is deterministic below 2^64 (Sinclair, 2011)
+	word64Bits = 64
+)
+
+var word64MillerRabinBases = []int64{2, 325, 9375, 28178, 450775, 9780504, 1795265022}
+
+// Versions of the test implementations, raised whenever a change could alter a
+// verdict or its confidence so that stored results can be traced to the code
+const (
+	trialDivisionVersion            = "1.0"
+	specialFormVersion              = "1.0"
+	deterministicMillerRabinVersion = "1.0"
+	millerRabinVersion              = "1.0"
+	bailliePSWVersion               = "1.0"
+	aksVersion                      = "1.0"
+)
+
+var (
+	ErrUnknownAlgorithm     = errors.New("unknown primality algorithm")
+	ErrUnsupportedAlgorithm = errors.New("primality algorithm cannot decide this number")
+
+	// Returned by a test that turned out not to apply after it was selected,
+	// so that the registry moves on to the next one
+	errTestNotApplicable = errors.New("primality test not applicable")
+)
+
+// Attribution identifies the code that produced a verdict: the algorithm,
+// the version of its implementation and the parameters it ran with.
+type Attribution struct {
+	Algorithm PrimalityAlgorithm
+	Version   string
+	Params    map[string]string
+}
+
+func (a Attribution) String() string {
+	if a.Algorithm == "" {
+		return "no algorithm"
+	}
+	s := fmt.Sprintf("%s %s", a.Algorithm, a.Version)
+	if len(a.Params) == 0 {
+		return s
+	}
+	keys := make([]string, 0, len(a.Params))
+	for key := range a.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := make([]string, len(keys))
+	for i, key := range keys {
+		params[i] = key + "=" + a.Params[key]
+	}
+	return s + " (" + strings.Join(params, ", ") + ")"
+}
+
+// PrimalityTest is one implementation in the PrimalityTestRegistry.
+type PrimalityTest interface {
+	Algorithm() PrimalityAlgorithm
+	Version() string
+	// Supports reports whether the test can decide n when it is asked for by name
+	Supports(n *big.Int) bool
+	// Selects reports whether the selection policy picks the test for n at the accuracy
+	Selects(n *big.Int, accuracy Accuracy) bool
+	// Test decides n and returns the confidence of a prime verdict and the parameters it ran with
+	Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error)
+}
+
+// PrimalityTestRegistry holds the primality tests in the order of the
+// selection policy: the first test that selects a number decides it.
+type PrimalityTestRegistry struct {
+	tests []PrimalityTest
+}
+
+func NewPrimalityTestRegistry(tests ...PrimalityTest) *PrimalityTestRegistry {
+	return &PrimalityTestRegistry{tests: tests}
+}
+
+// NewDefaultPrimalityTestRegistry selects by size: trial division for tiny
+// numbers, the special form tests for Mersenne, Fermat and Proth numbers,
+// deterministic Miller-Rabin below 2^64, and above that the test the
+// accuracy asks for. AKS is never selected and only runs when asked for.
+func NewDefaultPrimalityTestRegistry() *PrimalityTestRegistry {
+	return NewPrimalityTestRegistry(
+		&trialDivisionTest{},
+		&lucasLehmerTest{},
+		&pepinTest{},
+		&prothTest{},
+		&deterministicMillerRabinTest{maxBits: word64Bits, bases: word64MillerRabinBases},
+		&deterministicMillerRabinTest{bound: primality.DeterministicMillerRabinBound, bases: deterministicMillerRabinBases},
+		&millerRabinTest{},
+		&bailliePSWTest{},
+		&aksTest{},
+	)
+}
+
+// Check decides n with the test the policy selects, or with the named
+// algorithm when one is given. A composite verdict is always proven, since
+// every test only reports composite after finding a witness. When ctx is done
+// the error is returned with the attribution of the test that was running.
+func (r *PrimalityTestRegistry) Check(ctx context.Context, n *big.Int, accuracy Accuracy, algorithm PrimalityAlgorithm) (bool, Attribution, Confidence, error) {
+	if algorithm != "" {
+		test, err := r.lookup(algorithm, n)
+		if err != nil {
+			return false, Attribution{}, Confidence{}, err
+		}
+		isPrime, attribution, confidence, err := runPrimalityTest(ctx, test, n, accuracy)
+		if errors.Is(err, errTestNotApplicable) {
+			err = fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+		}
+		return isPrime, attribution, confidence, err
+	}
+
+	for _, test := range r.tests {
+		if !test.Selects(n, accuracy) {
+			continue
+		}
+		isPrime, attribution, confidence, err := runPrimalityTest(ctx, test, n, accuracy)
+		if errors.Is(err, errTestNotApplicable) {
+			continue
+		}
+		return isPrime, attribution, confidence, err
+	}
+	return false, Attribution{}, Confidence{}, fmt.Errorf("no primality test selects %s", n)
+}
+
+func (r *PrimalityTestRegistry) lookup(algorithm PrimalityAlgorithm, n *big.Int) (PrimalityTest, error) {
+	known := false
+	for _, test := range r.tests {
+		if test.Algorithm() != algorithm {
+			continue
+		}
+		known = true
+		if test.Supports(n) {
+			return test, nil
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, algorithm)
+	}
+	return nil, fmt.Errorf("%w: %s on a %d-bit number", ErrUnsupportedAlgorithm, algorithm, n.BitLen())
+}
+
+func runPrimalityTest(ctx context.Context, test PrimalityTest, n *big.Int, accuracy Accuracy) (bool, Attribution, Confidence, error) {
+	isPrime, confidence, params, err := test.Test(ctx, n, accuracy)
+	attribution := Attribution{Algorithm: test.Algorithm(), Version: test.Version(), Params: params}
+	if err != nil {
+		return false, attribution, Confidence{}, err
+	}
+	if !isPrime {
+		return false, attribution, provenConfidence(), nil
+	}
+	return true, attribution, confidence, nil
+}
+
+type trialDivisionTest struct{}
+
+func (t *trialDivisionTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmTrialDivision }
+func (t *trialDivisionTest) Version() string               { return trialDivisionVersion }
+
+func (t *trialDivisionTest) Supports(n *big.Int) bool {
+	return n.Cmp(primality.TrialDivisionLimit) < 0
+}
+
+func (t *trialDivisionTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	return n.BitLen() <= tinyNumberBits
+}
+
+func (t *trialDivisionTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return false, Confidence{}, nil, err
+	}
+	return isPrimeByTrialDivision(n), provenConfidence(), nil, nil
+}
+
+type lucasLehmerTest struct{}
+
+func (t *lucasLehmerTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmLucasLehmer }
+func (t *lucasLehmerTest) Version() string               { return specialFormVersion }
+
+func (t *lucasLehmerTest) Supports(n *big.Int) bool {
+	_, ok := primality.MersenneExponent(n)
+	return ok
+}
+
+func (t *lucasLehmerTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	return t.Supports(n)
+}
+
+func (t *lucasLehmerTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	p, _ := primality.MersenneExponent(n)
+	isPrime, err := lucasLehmer(ctx, n, p)
+	return isPrime, provenConfidence(), map[string]string{"p": strconv.Itoa(p)}, err
+}
+
+type pepinTest struct{}
+
+func (t *pepinTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmPepin }
+func (t *pepinTest) Version() string               { return specialFormVersion }
+
+func (t *pepinTest) Supports(n *big.Int) bool {
+	return primality.IsFermatNumber(n)
+}
+
+func (t *pepinTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	return t.Supports(n)
+}
+
+func (t *pepinTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	isPrime, err := pepin(ctx, n)
+	return isPrime, provenConfidence(), map[string]string{"base": "3"}, err
+}
+
+type prothTest struct{}
+
+func (t *prothTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmProth }
+func (t *prothTest) Version() string               { return specialFormVersion }
+
+func (t *prothTest) Supports(n *big.Int) bool {
+	return primality.IsProthNumber(n)
+}
+
+func (t *prothTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	return t.Supports(n)
+}
+
+func (t *prothTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	isPrime, base, ok, err := proth(ctx, n)
+	if !ok {
+		return false, Confidence{}, nil, errTestNotApplicable
+	}
+	var params map[string]string
+	if base > 0 {
+		params = map[string]string{"base": strconv.FormatInt(base, 10)}
+	}
+	return isPrime, provenConfidence(), params, err
+}
+
+// deterministicMillerRabinTest is Miller-Rabin with a fixed set of bases known
+// to have no strong pseudoprime below maxBits bits, or below bound when set.
+type deterministicMillerRabinTest struct {
+	maxBits int
+	bound   *big.Int
+	bases   []int64
+}
+
+func (t *deterministicMillerRabinTest) Algorithm() PrimalityAlgorithm {
+	return PrimalityAlgorithmDeterministicMillerRabin
+}
+
+func (t *deterministicMillerRabinTest) Version() string { return deterministicMillerRabinVersion }
+
+func (t *deterministicMillerRabinTest) Supports(n *big.Int) bool {
+	if t.bound != nil {
+		return n.Cmp(t.bound) < 0
+	}
+	return n.BitLen() <= t.maxBits
+}
+
+// Selects takes every number below 2^64, where the test is both exact and
+// cheaper than the probabilistic ones, and larger numbers it covers only when
+// a deterministic verdict is asked for.
+func (t *deterministicMillerRabinTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	if t.bound != nil && accuracy != AccuracyDeterministic {
+		return false
+	}
+	return t.Supports(n)
+}
+
+func (t *deterministicMillerRabinTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	isPrime, err := millerRabinBasesContext(ctx, n, t.bases)
+	return isPrime, provenConfidence(), map[string]string{"bases": formatBases(t.bases)}, err
+}
+
+// millerRabinTest runs Miller-Rabin with pseudorandom bases and no Baillie-PSW.
+// The policy selects it for fast checks, and for standard checks of numbers
+// too large for the uninterruptible Baillie-PSW test.
+type millerRabinTest struct{}
+
+func (t *millerRabinTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmMillerRabin }
+func (t *millerRabinTest) Version() string               { return millerRabinVersion }
+
+func (t *millerRabinTest) Supports(n *big.Int) bool {
+	return true
+}
+
+func (t *millerRabinTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	switch accuracy {
+	case AccuracyFast:
+		return true
+	case AccuracyStandard:
+		return n.BitLen() >= cancellableExpBits
+	default:
+		return false
+	}
+}
+
+func (t *millerRabinTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	rounds := roundsFor(accuracy)
+	params := map[string]string{"rounds": strconv.Itoa(rounds)}
+	if n.BitLen() >= cancellableExpBits {
+		if _, factors := removeSmallFactors(n); len(factors) > 0 {
+			return false, Confidence{}, params, nil
+		}
+	}
+	isPrime, err := millerRabinContext(ctx, n, rounds)
+	return isPrime, probableConfidence(rounds), params, err
+}
+
+// bailliePSWTest runs Miller-Rabin rounds followed by Baillie-PSW, which has
+// no known counterexample.
+type bailliePSWTest struct{}
+
+func (t *bailliePSWTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmBailliePSW }
+func (t *bailliePSWTest) Version() string               { return bailliePSWVersion }
+
+func (t *bailliePSWTest) Supports(n *big.Int) bool {
+	return true
+}
+
+func (t *bailliePSWTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	return accuracy != AccuracyFast
+}
+
+func (t *bailliePSWTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	rounds := roundsFor(accuracy)
+	params := map[string]string{"rounds": strconv.Itoa(rounds)}
+	isPrime, err := probablyPrimeContext(ctx, n, rounds)
+	if err != nil || !isPrime {
+		return false, Confidence{}, params, err
+	}
+	// probablyPrimeContext leaves Baillie-PSW out for large numbers. It runs
+	// last and cannot be interrupted, but by then the Miller-Rabin rounds have
+	// already rejected almost every composite.
+	if n.BitLen() >= cancellableExpBits && !n.ProbablyPrime(0) {
+		return false, Confidence{}, params, nil
+	}
+	return true, probableConfidence(rounds), params, nil
+}
+
+type aksTest struct{}
+
+func (t *aksTest) Algorithm() PrimalityAlgorithm { return PrimalityAlgorithmAKS }
+func (t *aksTest) Version() string               { return aksVersion }
+
+func (t *aksTest) Supports(n *big.Int) bool {
+	return n.Sign() >= 0 && n.BitLen() <= primality.AKSMaxBits
+}
+
+func (t *aksTest) Selects(n *big.Int, accuracy Accuracy) bool {
+	return false
+}
+
+func (t *aksTest) Test(ctx context.Context, n *big.Int, accuracy Accuracy) (bool, Confidence, map[string]string, error) {
+	isPrime, r, err := aks(ctx, n.Uint64())
+	var params map[string]string
+	if r > 0 {
+		params = map[string]string{"r": strconv.FormatUint(r, 10)}
+	}
+	return isPrime, provenConfidence(), params, err
+}
+
+// roundsFor returns the Miller-Rabin rounds of the accuracy; deterministic
+// checks that reach a probabilistic test run as many as paranoid ones.
+func roundsFor(accuracy Accuracy) int {
+	switch accuracy {
+	case AccuracyFast:
+		return fastRounds
+	case AccuracyParanoid, AccuracyDeterministic:
+		return paranoidRounds
+	default:
+		return standardRounds
+	}
+}
+
+func formatBases(bases []int64) string {
+	parts := make([]string, len(bases))
+	for i, base := range bases {
+		parts[i] = strconv.FormatInt(base, 10)
+	}
+	return strings.Join(parts, ",")
+}
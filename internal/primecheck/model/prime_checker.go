@@ -1,27 +1,57 @@
 package model
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 )
 
-const (
-	// Number of iterations for primality test, same as UUID v4 collision probability
-	iterations = 61
-)
-
 type PrimeChecker struct {
-	number *big.Int
+	number    *big.Int
+	accuracy  Accuracy
+	registry  *PrimalityTestRegistry
+	algorithm PrimalityAlgorithm
 }
 
-func NewPrimeChecker(numberText string) (*PrimeChecker, error) {
+func NewPrimeChecker(numberText string, accuracy Accuracy) (*PrimeChecker, error) {
+	return NewPrimeCheckerWithRegistry(numberText, accuracy, NewDefaultPrimalityTestRegistry(), "")
+}
+
+// NewPrimeCheckerWithRegistry creates a checker that decides with the tests
+// of the registry: the named algorithm when one is given, otherwise the test
+// the registry selects for each number.
+func NewPrimeCheckerWithRegistry(numberText string, accuracy Accuracy, registry *PrimalityTestRegistry, algorithm PrimalityAlgorithm) (*PrimeChecker, error) {
 	bigNum := new(big.Int)
 	if _, ok := bigNum.SetString(numberText, 10); !ok {
 		return nil, errors.New("Invalid number format")
 	}
-	return &PrimeChecker{number: bigNum}, nil
+	return &PrimeChecker{number: bigNum, accuracy: accuracy, registry: registry, algorithm: algorithm}, nil
 }
 
 func (c *PrimeChecker) IsPrime() bool {
-	return c.number.ProbablyPrime(iterations)
+	isPrime, _, _, _ := c.Check(context.Background())
+	return isPrime
+}
+
+// Check decides primality and reports the test that produced the verdict and
+// how sure it is. The tests poll ctx and return its error when it is done,
+// together with the attribution of the test that was running.
+func (c *PrimeChecker) Check(ctx context.Context) (bool, Attribution, Confidence, error) {
+	return c.registry.Check(ctx, c.number, c.accuracy, c.algorithm)
+}
+
+// Certify produces a primality certificate for the number. The certificate is
+// checked with VerifyCertificate before it is returned, so a bug in the
+// generator can never hand out an invalid proof. Generating it stops with the
+// error of ctx once ctx is done.
+func (c *PrimeChecker) Certify(ctx context.Context) (*Certificate, error) {
+	cert, err := generateCertificate(ctx, c.number)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyCertificate(cert); err != nil {
+		return nil, fmt.Errorf("generated certificate failed verification: %w", err)
+	}
+	return cert, nil
 }
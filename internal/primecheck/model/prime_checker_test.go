@@ -0,0 +1,61 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPrimeCheckerCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{name: "negative", number: "-7", want: false},
+		{name: "zero", number: "0", want: false},
+		{name: "one", number: "1", want: false},
+		{name: "two", number: "2", want: true},
+		{name: "largest 32-bit prime", number: "4294967291", want: true},
+		{name: "mersenne 2^61-1", number: "2305843009213693951", want: true},
+		{name: "first prime above 2^64", number: "18446744073709551629", want: true},
+		{name: "mersenne 2^127-1", number: "170141183460469231731687303715884105727", want: true},
+		{name: "2^160+7", number: "1461501637330902918203684832716283019655932542983", want: true},
+		{name: "carmichael 561", number: "561", want: false},
+		{name: "carmichael 1105", number: "1105", want: false},
+		{name: "carmichael 1729", number: "1729", want: false},
+		{name: "carmichael 2465", number: "2465", want: false},
+		{name: "carmichael 2821", number: "2821", want: false},
+		{name: "carmichael 6601", number: "6601", want: false},
+		{name: "carmichael 8911", number: "8911", want: false},
+		{name: "carmichael 41041", number: "41041", want: false},
+		{name: "carmichael 825265", number: "825265", want: false},
+		{name: "carmichael 321197185", number: "321197185", want: false},
+		{name: "carmichael 5394826801", number: "5394826801", want: false},
+		{name: "carmichael 232250619601", number: "232250619601", want: false},
+		{name: "carmichael 9746347772161", number: "9746347772161", want: false},
+		{name: "strong pseudoprime to bases 2, 3, 5 and 7", number: "3215031751", want: false},
+		{name: "strong pseudoprime to the first 9 prime bases", number: "3825123056546413051", want: false},
+		{name: "strong pseudoprime to the first 12 prime bases", number: "318665857834031151167461", want: false},
+	}
+
+	for _, accuracy := range []Accuracy{AccuracyFast, AccuracyStandard, AccuracyParanoid, AccuracyDeterministic} {
+		for _, tt := range tests {
+			t.Run(string(accuracy)+"/"+tt.name, func(t *testing.T) {
+				checker, err := NewPrimeChecker(tt.number, accuracy)
+				if err != nil {
+					t.Fatalf("NewPrimeChecker() error = %v", err)
+				}
+				got, _, confidence, err := checker.Check(context.Background())
+				if err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("Check() = %v, want %v", got, tt.want)
+				}
+				if !got && confidence.Level != ConfidenceProven {
+					t.Errorf("Check() confidence = %s, want %s for a composite", confidence.Level, ConfidenceProven)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type PrimeRangeRequest struct {
+	rangeID   int32
+	userID    int32
+	start     uint64
+	end       uint64
+	countOnly bool
+	timestamp time.Time
+}
+
+func NewPrimeRangeRequest(rangeID, userID int32, start, end uint64, countOnly bool, now time.Time) *PrimeRangeRequest {
+	return &PrimeRangeRequest{
+		rangeID:   rangeID,
+		userID:    userID,
+		start:     start,
+		end:       end,
+		countOnly: countOnly,
+		timestamp: now,
+	}
+}
+
+func (p *PrimeRangeRequest) RangeID() int32 {
+	return p.rangeID
+}
+
+func (p *PrimeRangeRequest) UserID() int32 {
+	return p.userID
+}
+
+func (p *PrimeRangeRequest) Start() uint64 {
+	return p.start
+}
+
+func (p *PrimeRangeRequest) End() uint64 {
+	return p.end
+}
+
+// CountOnly reports whether only the number of primes is wanted, so the primes themselves are not stored.
+func (p *PrimeRangeRequest) CountOnly() bool {
+	return p.countOnly
+}
+
+func (p *PrimeRangeRequest) Timestamp() time.Time {
+	return p.timestamp
+}
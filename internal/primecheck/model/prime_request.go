@@ -1,20 +1,46 @@
 package model
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrRequestCancelled is returned for a request whose user cancelled it.
+	ErrRequestCancelled = errors.New("prime check request was cancelled")
+	// ErrStaleAttempt is returned for a request of an attempt that a retry has superseded.
+	ErrStaleAttempt = errors.New("prime check request was retried")
+)
 
 type PrimeRequest struct {
-	requestID  int32
-	userID     int32
-	numberText string
-	timestamp  time.Time
+	requestID   int32
+	userID      int32
+	attempt     int32
+	numberText  string
+	operation   PrimeOperation
+	accuracy    Accuracy
+	algorithm   PrimalityAlgorithm
+	timeout     time.Duration
+	certify     bool
+	callbackURL string
+	timestamp   time.Time
 }
 
-func NewPrimeRequest(requestID, userID int32, numberText string, now time.Time) *PrimeRequest {
+// NewPrimeRequest creates a request of the given attempt. Messages queued
+// before attempts were counted carry none, and are first attempts.
+func NewPrimeRequest(requestID, userID, attempt int32, numberText string, operation PrimeOperation, accuracy Accuracy, algorithm PrimalityAlgorithm, timeout time.Duration, certify bool, callbackURL string, now time.Time) *PrimeRequest {
 	return &PrimeRequest{
-		requestID:  requestID,
-		userID:     userID,
-		numberText: numberText,
-		timestamp:  now,
+		requestID:   requestID,
+		userID:      userID,
+		attempt:     max(attempt, 1),
+		numberText:  numberText,
+		operation:   operation,
+		accuracy:    accuracy,
+		algorithm:   algorithm,
+		timeout:     timeout,
+		certify:     certify,
+		callbackURL: callbackURL,
+		timestamp:   now,
 	}
 }
 
@@ -26,10 +52,42 @@ func (p *PrimeRequest) UserID() int32 {
 	return p.userID
 }
 
+// Attempt counts the times the request was queued, from 1.
+func (p *PrimeRequest) Attempt() int32 {
+	return p.attempt
+}
+
 func (p *PrimeRequest) NumberText() string {
 	return p.numberText
 }
 
+func (p *PrimeRequest) Operation() PrimeOperation {
+	return p.operation
+}
+
+func (p *PrimeRequest) Accuracy() Accuracy {
+	return p.accuracy
+}
+
+// Algorithm is the primality test the request asked for, empty to let the registry select one.
+func (p *PrimeRequest) Algorithm() PrimalityAlgorithm {
+	return p.algorithm
+}
+
+// Timeout is the time budget the request asked for, 0 for the longest one.
+func (p *PrimeRequest) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *PrimeRequest) Certify() bool {
+	return p.certify
+}
+
+// CallbackURL is where the result is posted once the check is final, empty for none.
+func (p *PrimeRequest) CallbackURL() string {
+	return p.callbackURL
+}
+
 func (p *PrimeRequest) Timestamp() time.Time {
 	return p.timestamp
 }
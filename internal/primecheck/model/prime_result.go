@@ -7,16 +7,22 @@ type PrimeResult struct {
 	userID          int32
 	numberText      string
 	isPrime         bool
+	attribution     Attribution
+	confidence      Confidence
+	foundPrime      *FoundPrime
 	calculatedAt    time.Time
 	calculationTime time.Duration
 }
 
-func NewPrimeResult(requestID, userID int32, numberText string, isPrime bool, now time.Time, calculationTime time.Duration) *PrimeResult {
+func NewPrimeResult(requestID, userID int32, numberText string, isPrime bool, attribution Attribution, confidence Confidence, foundPrime *FoundPrime, now time.Time, calculationTime time.Duration) *PrimeResult {
 	return &PrimeResult{
 		requestID:       requestID,
 		userID:          userID,
 		numberText:      numberText,
 		isPrime:         isPrime,
+		attribution:     attribution,
+		confidence:      confidence,
+		foundPrime:      foundPrime,
 		calculatedAt:    now,
 		calculationTime: calculationTime,
 	}
@@ -38,6 +44,20 @@ func (p *PrimeResult) IsPrime() bool {
 	return p.isPrime
 }
 
+// Attribution identifies the test that produced the verdict.
+func (p *PrimeResult) Attribution() Attribution {
+	return p.attribution
+}
+
+func (p *PrimeResult) Confidence() Confidence {
+	return p.confidence
+}
+
+// FoundPrime is the result of a next_prime or prev_prime search, nil for a plain primality check.
+func (p *PrimeResult) FoundPrime() *FoundPrime {
+	return p.foundPrime
+}
+
 func (p *PrimeResult) CalculatedAt() time.Time {
 	return p.calculatedAt
 }
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+type PrimeOperation string
+
+const (
+	// Decide whether the number itself is prime
+	PrimeOperationIsPrime PrimeOperation = "is_prime"
+	// Find the smallest prime >= the number
+	PrimeOperationNextPrime PrimeOperation = "next_prime"
+	// Find the largest prime <= the number
+	PrimeOperationPrevPrime PrimeOperation = "prev_prime"
+)
+
+// Primes up to this bound are used to skip candidates before the full primality test
+const primeSearchSieveBound = 2000
+
+var errNoPreviousPrime = errors.New("there is no prime below 2")
+
+// FoundPrime is the answer to a next_prime or prev_prime search: the prime
+// and its distance from the number the search started at.
+type FoundPrime struct {
+	Operation PrimeOperation
+	Prime     string
+	Gap       int64
+}
+
+// FindPrime searches upwards (next_prime) or downwards (prev_prime) from the
+// number, including the number itself, and returns the first prime together
+// with the attribution of the test that decided it and its confidence. Candidates divisible by a small prime
+// are skipped by tracking their residues, so only likely primes reach Check.
+func (c *PrimeChecker) FindPrime(ctx context.Context, operation PrimeOperation) (*FoundPrime, Attribution, Confidence, error) {
+	step := int64(1)
+	switch operation {
+	case PrimeOperationNextPrime:
+	case PrimeOperationPrevPrime:
+		step = -1
+		if c.number.Cmp(big.NewInt(2)) < 0 {
+			return nil, Attribution{}, Confidence{}, errNoPreviousPrime
+		}
+	default:
+		return nil, Attribution{}, Confidence{}, fmt.Errorf("unsupported prime search operation %q", operation)
+	}
+
+	// The smallest prime >= n for n <= 2 is 2
+	start := new(big.Int).Set(c.number)
+	if start.Cmp(big.NewInt(2)) < 0 {
+		start.SetInt64(2)
+	}
+
+	primes := smallPrimesUpTo(primeSearchSieveBound)
+	residues := make([]int64, len(primes))
+	m := new(big.Int)
+	for i, p := range primes {
+		residues[i] = m.Mod(start, m.SetUint64(p)).Int64()
+	}
+
+	candidate := new(big.Int).Set(start)
+	stepInt := big.NewInt(step)
+	for offset := int64(0); ; offset++ {
+		if offset > 0 {
+			candidate.Add(candidate, stepInt)
+		}
+		if candidate.Cmp(big.NewInt(2)) < 0 {
+			return nil, Attribution{}, Confidence{}, errNoPreviousPrime
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, Attribution{}, Confidence{}, err
+		}
+
+		if hasSmallFactor(candidate, primes, residues, step*offset) {
+			continue
+		}
+
+		isPrime, attribution, confidence, err := c.registry.Check(ctx, candidate, c.accuracy, c.algorithm)
+		if err != nil {
+			return nil, attribution, Confidence{}, err
+		}
+		if isPrime {
+			return &FoundPrime{
+				Operation: operation,
+				Prime:     candidate.String(),
+				Gap:       new(big.Int).Sub(candidate, c.number).Int64() * step,
+			}, attribution, confidence, nil
+		}
+	}
+}
+
+// hasSmallFactor reports whether start+shift, whose residues for start are
+// given, is divisible by one of the small primes without being that prime.
+func hasSmallFactor(candidate *big.Int, primes []uint64, residues []int64, shift int64) bool {
+	for i, p := range primes {
+		r := (residues[i] + shift%int64(p)) % int64(p)
+		if r == 0 {
+			return !(candidate.IsUint64() && candidate.Uint64() == p)
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPrimeCheckerFindPrime(t *testing.T) {
+	tests := []struct {
+		name      string
+		number    string
+		operation PrimeOperation
+		wantPrime string
+		wantGap   int64
+	}{
+		{name: "next from a negative number", number: "-5", operation: PrimeOperationNextPrime, wantPrime: "2", wantGap: 7},
+		{name: "next from zero", number: "0", operation: PrimeOperationNextPrime, wantPrime: "2", wantGap: 2},
+		{name: "next from one", number: "1", operation: PrimeOperationNextPrime, wantPrime: "2", wantGap: 1},
+		{name: "next from two", number: "2", operation: PrimeOperationNextPrime, wantPrime: "2", wantGap: 0},
+		{name: "next from three", number: "3", operation: PrimeOperationNextPrime, wantPrime: "3", wantGap: 0},
+		{name: "next from four", number: "4", operation: PrimeOperationNextPrime, wantPrime: "5", wantGap: 1},
+		{name: "next from a carmichael", number: "561", operation: PrimeOperationNextPrime, wantPrime: "563", wantGap: 2},
+		{name: "next from 2^32", number: "4294967296", operation: PrimeOperationNextPrime, wantPrime: "4294967311", wantGap: 15},
+		{name: "next from 2^64", number: "18446744073709551616", operation: PrimeOperationNextPrime, wantPrime: "18446744073709551629", wantGap: 13},
+		{name: "next from 2^100", number: "1267650600228229401496703205376", operation: PrimeOperationNextPrime, wantPrime: "1267650600228229401496703205653", wantGap: 277},
+		{name: "next from a mersenne prime", number: "170141183460469231731687303715884105727", operation: PrimeOperationNextPrime, wantPrime: "170141183460469231731687303715884105727", wantGap: 0},
+		{name: "previous from two", number: "2", operation: PrimeOperationPrevPrime, wantPrime: "2", wantGap: 0},
+		{name: "previous from three", number: "3", operation: PrimeOperationPrevPrime, wantPrime: "3", wantGap: 0},
+		{name: "previous from four", number: "4", operation: PrimeOperationPrevPrime, wantPrime: "3", wantGap: 1},
+		{name: "previous from 100", number: "100", operation: PrimeOperationPrevPrime, wantPrime: "97", wantGap: 3},
+		{name: "previous from a carmichael", number: "1105", operation: PrimeOperationPrevPrime, wantPrime: "1103", wantGap: 2},
+		{name: "previous from 2^64", number: "18446744073709551616", operation: PrimeOperationPrevPrime, wantPrime: "18446744073709551557", wantGap: 59},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, err := NewPrimeChecker(tt.number, AccuracyStandard)
+			if err != nil {
+				t.Fatalf("NewPrimeChecker() error = %v", err)
+			}
+			found, _, _, err := checker.FindPrime(context.Background(), tt.operation)
+			if err != nil {
+				t.Fatalf("FindPrime() error = %v", err)
+			}
+			if found.Operation != tt.operation {
+				t.Errorf("Operation = %s, want %s", found.Operation, tt.operation)
+			}
+			if found.Prime != tt.wantPrime {
+				t.Errorf("Prime = %s, want %s", found.Prime, tt.wantPrime)
+			}
+			if found.Gap != tt.wantGap {
+				t.Errorf("Gap = %d, want %d", found.Gap, tt.wantGap)
+			}
+		})
+	}
+}
+
+func TestPrimeCheckerFindPrimeBelowTwo(t *testing.T) {
+	for _, number := range []string{"1", "0", "-3"} {
+		t.Run(number, func(t *testing.T) {
+			checker, err := NewPrimeChecker(number, AccuracyStandard)
+			if err != nil {
+				t.Fatalf("NewPrimeChecker() error = %v", err)
+			}
+			if _, _, _, err := checker.FindPrime(context.Background(), PrimeOperationPrevPrime); !errors.Is(err, errNoPreviousPrime) {
+				t.Errorf("FindPrime() error = %v, want %v", err, errNoPreviousPrime)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
+)
+
+const (
+	// Odd numbers covered by one sieve segment, one byte each so a segment stays in L2 cache
+	sieveSegmentSize = 1 << 18
+	// Largest upper bound accepted by the sieve; its square root bounds the base prime table
+	maxSieveEnd = 1_000_000_000_000_000
+)
+
+// SegmentedSieve enumerates the primes in [start, end] with the segmented
+// sieve of Eratosthenes: the primes up to sqrt(end) are sieved once, then
+// the range is walked in fixed size segments of odd numbers so that memory
+// stays constant however wide the range is.
+type SegmentedSieve struct {
+	start uint64
+	end   uint64
+}
+
+func NewSegmentedSieve(start, end uint64) (*SegmentedSieve, error) {
+	if start > end {
+		return nil, fmt.Errorf("range start %d is above range end %d", start, end)
+	}
+	if end > maxSieveEnd {
+		return nil, fmt.Errorf("range end %d exceeds %d", end, uint64(maxSieveEnd))
+	}
+	return &SegmentedSieve{start: start, end: end}, nil
+}
+
+// Run calls handle with the primes of each segment in ascending order. The
+// slice is reused between calls, so handle must copy what it keeps. Segments
+// without primes are skipped. ctx is checked before every segment.
+func (s *SegmentedSieve) Run(ctx context.Context, handle func(primes []uint64) error) error {
+	var primes []uint64
+	if s.start <= 2 && s.end >= 2 {
+		primes = append(primes, 2)
+	}
+
+	low := max(s.start, 3) | 1
+	if low > s.end {
+		if len(primes) > 0 {
+			return handle(primes)
+		}
+		return nil
+	}
+
+	basePrimes := oddPrimesUpTo(integerSqrt(s.end))
+	composite := make([]bool, sieveSegmentSize)
+	for segmentLow := low; segmentLow <= s.end; segmentLow += 2 * sieveSegmentSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		segmentHigh := min(s.end, segmentLow+2*sieveSegmentSize-1)
+		size := (segmentHigh-segmentLow)/2 + 1
+		clear(composite[:size])
+		for _, p := range basePrimes {
+			if p*p > segmentHigh {
+				break
+			}
+			// First odd multiple of p in the segment, never below p^2
+			m := max(p*p, (segmentLow+p-1)/p*p)
+			if m%2 == 0 {
+				m += p
+			}
+			for j := (m - segmentLow) / 2; j < size; j += p {
+				composite[j] = true
+			}
+		}
+
+		for i := uint64(0); i < size; i++ {
+			if !composite[i] && segmentLow+2*i > 1 {
+				primes = append(primes, segmentLow+2*i)
+			}
+		}
+		if len(primes) > 0 {
+			if err := handle(primes); err != nil {
+				return err
+			}
+		}
+		primes = primes[:0]
+	}
+	return nil
+}
+
+// oddPrimesUpTo sieves the odd primes not exceeding bound.
+func oddPrimesUpTo(bound uint64) []uint64 {
+	if bound < primality.SmallPrimeLimit {
+		primes := smallPrimesUpTo(bound)
+		if len(primes) > 0 && primes[0] == 2 {
+			primes = primes[1:]
+		}
+		return primes
+	}
+
+	// composite[i] stands for 2i+1
+	composite := make([]bool, bound/2+1)
+	var primes []uint64
+	for i := uint64(1); 2*i+1 <= bound; i++ {
+		if composite[i] {
+			continue
+		}
+		p := 2*i + 1
+		primes = append(primes, p)
+		for j := p * p / 2; j < uint64(len(composite)); j += p {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// integerSqrt returns floor(sqrt(n)).
+func integerSqrt(n uint64) uint64 {
+	r := uint64(math.Sqrt(float64(n)))
+	for r*r > n {
+		r--
+	}
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// End of the first segment, which starts at 3
+const firstSegmentEnd = 3 + 2*sieveSegmentSize - 1
+
+func TestSegmentedSieve(t *testing.T) {
+	tests := []struct {
+		name  string
+		start uint64
+		end   uint64
+	}{
+		{name: "zero", start: 0, end: 0},
+		{name: "zero to one", start: 0, end: 1},
+		{name: "zero to two", start: 0, end: 2},
+		{name: "two", start: 2, end: 2},
+		{name: "two to three", start: 2, end: 3},
+		{name: "three", start: 3, end: 3},
+		{name: "even without primes", start: 4, end: 4},
+		{name: "prime gap", start: 24, end: 28},
+		{name: "even bounds", start: 10, end: 100},
+		{name: "square of a base prime", start: 289, end: 289},
+		{name: "first segment end", start: firstSegmentEnd - 20, end: firstSegmentEnd},
+		{name: "second segment start", start: firstSegmentEnd + 1, end: firstSegmentEnd + 20},
+		{name: "across a segment edge", start: firstSegmentEnd - 100, end: firstSegmentEnd + 100},
+		{name: "several segments", start: 0, end: 3*firstSegmentEnd + 7},
+		{name: "segment edge of an odd start", start: 1_000_001, end: 1_000_001 + 2*sieveSegmentSize + 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := primesByEratosthenes(tt.start, tt.end)
+			if got := runSegmentedSieve(t, tt.start, tt.end); !reflect.DeepEqual(got, want) {
+				t.Errorf("Run() found %d primes, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestSegmentedSieveLargestRange(t *testing.T) {
+	start := uint64(maxSieveEnd - 1000)
+	var want []uint64
+	for n := start; n <= maxSieveEnd; n++ {
+		if new(big.Int).SetUint64(n).ProbablyPrime(20) {
+			want = append(want, n)
+		}
+	}
+	if got := runSegmentedSieve(t, start, maxSieveEnd); !reflect.DeepEqual(got, want) {
+		t.Errorf("Run() = %v, want %v", got, want)
+	}
+}
+
+func TestNewSegmentedSieveRejectsInvalidRanges(t *testing.T) {
+	tests := []struct {
+		name  string
+		start uint64
+		end   uint64
+	}{
+		{name: "start above end", start: 3, end: 2},
+		{name: "end above limit", start: 0, end: maxSieveEnd + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSegmentedSieve(tt.start, tt.end); err == nil {
+				t.Error("NewSegmentedSieve() error = nil")
+			}
+		})
+	}
+}
+
+func runSegmentedSieve(t *testing.T, start, end uint64) []uint64 {
+	t.Helper()
+	sieve, err := NewSegmentedSieve(start, end)
+	if err != nil {
+		t.Fatalf("NewSegmentedSieve() error = %v", err)
+	}
+	var primes []uint64
+	err = sieve.Run(context.Background(), func(segment []uint64) error {
+		if len(segment) == 0 {
+			t.Error("Run() handled an empty segment")
+		}
+		primes = append(primes, segment...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return primes
+}
+
+// primesByEratosthenes returns the primes in [start, end] with the plain sieve of Eratosthenes.
+func primesByEratosthenes(start, end uint64) []uint64 {
+	composite := make([]bool, end+1)
+	var primes []uint64
+	for n := uint64(2); n <= end; n++ {
+		if composite[n] {
+			continue
+		}
+		if n >= start {
+			primes = append(primes, n)
+		}
+		for m := n * n; m <= end; m += n {
+			composite[m] = true
+		}
+	}
+	return primes
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"context"
+	"math/big"
+)
+
+type PrimalityAlgorithm string
+
+const (
+	// Division by every prime up to the square root, for tiny numbers
+	PrimalityAlgorithmTrialDivision PrimalityAlgorithm = "trial_division"
+	// Miller-Rabin rounds with pseudorandom bases, probabilistic
+	PrimalityAlgorithmMillerRabin PrimalityAlgorithm = "miller_rabin"
+	// Miller-Rabin with a fixed base set that has no strong pseudoprime below a bound
+	PrimalityAlgorithmDeterministicMillerRabin PrimalityAlgorithm = "miller_rabin_deterministic"
+	// Miller-Rabin rounds plus Baillie-PSW as in big.Int.ProbablyPrime, probabilistic
+	PrimalityAlgorithmBailliePSW PrimalityAlgorithm = "baillie_psw"
+	// Agrawal-Kayal-Saxena, deterministic and polynomial but slow, for demonstrations
+	PrimalityAlgorithmAKS PrimalityAlgorithm = "aks"
+	// Deterministic test for Mersenne numbers 2^p-1
+	PrimalityAlgorithmLucasLehmer PrimalityAlgorithm = "lucas_lehmer"
+	// Deterministic test for Proth numbers k*2^n+1 with odd k < 2^n
+	PrimalityAlgorithmProth PrimalityAlgorithm = "proth"
+	// Deterministic test for Fermat numbers 2^(2^n)+1
+	PrimalityAlgorithmPepin PrimalityAlgorithm = "pepin"
+)
+
+// Largest base tried when searching a quadratic non-residue for Proth's test
+const maxProthBase = 1000
+
+// lucasLehmer decides whether the Mersenne number n = 2^p - 1 is prime:
+// with s_0 = 4 and s_(i+1) = s_i^2 - 2, n is prime iff s_(p-2) = 0 mod n.
+// A composite p makes n composite, so only a prime p runs the sequence.
+func lucasLehmer(ctx context.Context, n *big.Int, p int) (bool, error) {
+	if !big.NewInt(int64(p)).ProbablyPrime(standardRounds) {
+		return false, nil
+	}
+
+	s := big.NewInt(4)
+	sq := new(big.Int)
+	high := new(big.Int)
+	two := big.NewInt(2)
+	for i := 0; i < p-2; i++ {
+		if i%expContextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+		}
+		sq.Mul(s, s)
+		sq.Sub(sq, two)
+		// x mod 2^p-1 = (x & (2^p-1)) + (x >> p), folded until below n
+		for sq.Cmp(n) > 0 {
+			high.Rsh(sq, uint(p))
+			sq.And(sq, n)
+			sq.Add(sq, high)
+		}
+		if sq.Cmp(n) == 0 {
+			sq.SetInt64(0)
+		}
+		s, sq = sq, s
+	}
+	return s.Sign() == 0, nil
+}
+
+// pepin decides whether the Fermat number n = 2^(2^k) + 1 (k >= 1) is prime:
+// n is prime iff 3^((n-1)/2) = -1 mod n.
+func pepin(ctx context.Context, n *big.Int) (bool, error) {
+	nm1 := new(big.Int).Sub(n, big.NewInt(1))
+	e := new(big.Int).Rsh(nm1, 1)
+	r, err := expContext(ctx, big.NewInt(3), e, n)
+	if err != nil {
+		return false, err
+	}
+	return r.Cmp(nm1) == 0, nil
+}
+
+// proth decides whether the Proth number n = k*2^m + 1 is prime. For a
+// quadratic non-residue a, n is prime iff a^((n-1)/2) = -1 mod n. The base
+// that decided n is returned, 0 for a square. ok is false when no non-residue
+// below maxProthBase is found.
+func proth(ctx context.Context, n *big.Int) (isPrime bool, base int64, ok bool, err error) {
+	nm1 := new(big.Int).Sub(n, big.NewInt(1))
+	if r := new(big.Int).Sqrt(n); new(big.Int).Mul(r, r).Cmp(n) == 0 {
+		// Squares have no quadratic non-residues
+		return false, 0, true, nil
+	}
+
+	e := new(big.Int).Rsh(nm1, 1)
+	for a := int64(2); a < maxProthBase; a++ {
+		base := big.NewInt(a)
+		switch big.Jacobi(base, n) {
+		case 0:
+			// a shares a factor with n
+			return n.Cmp(base) == 0, a, true, nil
+		case -1:
+			r, err := expContext(ctx, base, e, n)
+			if err != nil {
+				return false, a, true, err
+			}
+			return r.Cmp(nm1) == 0, a, true, nil
+		}
+	}
+	return false, 0, false, nil
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLucasLehmer(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		p      int
+		want   bool
+	}{
+		{name: "2^3-1", number: "7", p: 3, want: true},
+		{name: "2^11-1", number: "2047", p: 11, want: false},
+		{name: "composite exponent", number: "32767", p: 15, want: false},
+		{name: "2^61-1", number: "2305843009213693951", p: 61, want: true},
+		{name: "2^67-1", number: "147573952589676412927", p: 67, want: false},
+		{name: "2^89-1", number: "618970019642690137449562111", p: 89, want: true},
+		{name: "2^127-1", number: "170141183460469231731687303715884105727", p: 127, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lucasLehmer(context.Background(), mustBigInt(t, tt.number), tt.p)
+			if err != nil {
+				t.Fatalf("lucasLehmer() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("lucasLehmer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPepin(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{name: "F1", number: "5", want: true},
+		{name: "F4", number: "65537", want: true},
+		{name: "F5", number: "4294967297", want: false},
+		{name: "F6", number: "18446744073709551617", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pepin(context.Background(), mustBigInt(t, tt.number))
+			if err != nil {
+				t.Fatalf("pepin() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("pepin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProth(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{name: "3*2^2+1", number: "13", want: true},
+		{name: "square 5*2^3+1", number: "49", want: false},
+		{name: "3*2^30+1", number: "3221225473", want: true},
+		{name: "27*2^40+1", number: "29686813949953", want: true},
+		{name: "3*2^42+1", number: "13194139533313", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok, err := proth(context.Background(), mustBigInt(t, tt.number))
+			if err != nil {
+				t.Fatalf("proth() error = %v", err)
+			}
+			if !ok {
+				t.Fatal("proth() found no quadratic non-residue")
+			}
+			if got != tt.want {
+				t.Errorf("proth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrimeCheckerDispatchesSpecialForms(t *testing.T) {
+	tests := []struct {
+		name          string
+		number        string
+		wantPrime     bool
+		wantAlgorithm PrimalityAlgorithm
+	}{
+		{name: "tiny mersenne", number: "2047", wantPrime: false, wantAlgorithm: PrimalityAlgorithmTrialDivision},
+		{name: "mersenne prime", number: "2305843009213693951", wantPrime: true, wantAlgorithm: PrimalityAlgorithmLucasLehmer},
+		{name: "mersenne composite", number: "147573952589676412927", wantPrime: false, wantAlgorithm: PrimalityAlgorithmLucasLehmer},
+		{name: "tiny fermat", number: "65537", wantPrime: true, wantAlgorithm: PrimalityAlgorithmTrialDivision},
+		{name: "fermat composite", number: "4294967297", wantPrime: false, wantAlgorithm: PrimalityAlgorithmPepin},
+		{name: "proth prime", number: "3221225473", wantPrime: true, wantAlgorithm: PrimalityAlgorithmProth},
+		{name: "proth composite", number: "13194139533313", wantPrime: false, wantAlgorithm: PrimalityAlgorithmProth},
+		{name: "no special form", number: "1000000007", wantPrime: true, wantAlgorithm: PrimalityAlgorithmDeterministicMillerRabin},
+		{name: "carmichael", number: "9746347772161", wantPrime: false, wantAlgorithm: PrimalityAlgorithmDeterministicMillerRabin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, err := NewPrimeChecker(tt.number, AccuracyStandard)
+			if err != nil {
+				t.Fatalf("NewPrimeChecker() error = %v", err)
+			}
+			isPrime, attribution, _, err := checker.Check(context.Background())
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if isPrime != tt.wantPrime {
+				t.Errorf("Check() isPrime = %v, want %v", isPrime, tt.wantPrime)
+			}
+			if attribution.Algorithm != tt.wantAlgorithm {
+				t.Errorf("Check() algorithm = %s, want %s", attribution.Algorithm, tt.wantAlgorithm)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
+)
+
+var (
+	smallPrimesOnce sync.Once
+	smallPrimeTable []uint64
+)
+
+// smallPrimes returns every prime below primality.SmallPrimeLimit, sieved once on first use.
+func smallPrimes() []uint64 {
+	smallPrimesOnce.Do(func() {
+		composite := make([]bool, primality.SmallPrimeLimit)
+		for i := 2; i < primality.SmallPrimeLimit; i++ {
+			if composite[i] {
+				continue
+			}
+			smallPrimeTable = append(smallPrimeTable, uint64(i))
+			for j := i * i; j < primality.SmallPrimeLimit; j += i {
+				composite[j] = true
+			}
+		}
+	})
+	return smallPrimeTable
+}
+
+// smallPrimesUpTo returns the primes from the small prime table that do not exceed bound.
+func smallPrimesUpTo(bound uint64) []uint64 {
+	primes := smallPrimes()
+	end := sort.Search(len(primes), func(i int) bool { return primes[i] > bound })
+	return primes[:end]
+}
+
+// isPrimeByTrialDivision deterministically decides primality of n < primality.TrialDivisionLimit.
+func isPrimeByTrialDivision(n *big.Int) bool {
+	if n.Sign() <= 0 || n.Cmp(primality.TrialDivisionLimit) >= 0 {
+		return false
+	}
+	v := n.Uint64()
+	if v < 2 {
+		return false
+	}
+	for _, p := range smallPrimes() {
+		if p*p > v {
+			return true
+		}
+		if v%p == 0 {
+			return v == p
+		}
+	}
+	return true
+}
+
+// removeSmallFactors divides out every prime below primality.SmallPrimeLimit from n and returns the
+// remaining cofactor together with the distinct small primes that were removed.
+func removeSmallFactors(n *big.Int) (*big.Int, []*big.Int) {
+	cofactor := new(big.Int).Set(n)
+	var factors []*big.Int
+
+	p := new(big.Int)
+	q, r := new(big.Int), new(big.Int)
+	for _, small := range smallPrimes() {
+		p.SetUint64(small)
+		if cofactor.Cmp(p) < 0 {
+			break
+		}
+		found := false
+		for {
+			q.QuoRem(cofactor, p, r)
+			if r.Sign() != 0 {
+				break
+			}
+			cofactor.Set(q)
+			found = true
+		}
+		if found {
+			factors = append(factors, new(big.Int).Set(p))
+		}
+	}
+	return cofactor, factors
+}
@@ -1,21 +1,38 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/ponyo877/prime-checker/internal/primecheck/model"
 	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
 )
 
-type PrimeCalculator struct{}
+// PrimeCalculator decides numbers with the tests of a registry, which selects
+// one by the size of the number unless the request names an algorithm.
+type PrimeCalculator struct {
+	registry *model.PrimalityTestRegistry
+}
+
+func NewPrimeCalculator(registry *model.PrimalityTestRegistry) usecase.PrimeCalculator {
+	return &PrimeCalculator{
+		registry: registry,
+	}
+}
+
+func (c *PrimeCalculator) Calculate(ctx context.Context, numberText string, accuracy model.Accuracy, algorithm model.PrimalityAlgorithm) (bool, model.Attribution, model.Confidence, error) {
+	checker, err := model.NewPrimeCheckerWithRegistry(numberText, accuracy, c.registry, algorithm)
+	if err != nil {
+		return false, model.Attribution{}, model.Confidence{}, err
+	}
 
-func NewPrimeCalculator() usecase.PrimeCalculator {
-	return &PrimeCalculator{}
+	return checker.Check(ctx)
 }
 
-func (c *PrimeCalculator) Calculate(numberText string) (bool, error) {
-	checker, err := model.NewPrimeChecker(numberText)
+func (c *PrimeCalculator) FindPrime(ctx context.Context, numberText string, operation model.PrimeOperation, accuracy model.Accuracy, algorithm model.PrimalityAlgorithm) (*model.FoundPrime, model.Attribution, model.Confidence, error) {
+	checker, err := model.NewPrimeCheckerWithRegistry(numberText, accuracy, c.registry, algorithm)
 	if err != nil {
-		return false, err
+		return nil, model.Attribution{}, model.Confidence{}, err
 	}
 
-	return checker.IsPrime(), nil
+	return checker.FindPrime(ctx, operation)
 }
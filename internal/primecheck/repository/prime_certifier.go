@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+)
+
+type PrimeCertifier struct{}
+
+func NewPrimeCertifier() usecase.PrimeCertifier {
+	return &PrimeCertifier{}
+}
+
+func (c *PrimeCertifier) Certify(ctx context.Context, numberText string) (*model.Certificate, error) {
+	checker, err := model.NewPrimeChecker(numberText, model.AccuracyStandard)
+	if err != nil {
+		return nil, err
+	}
+
+	return checker.Certify(ctx)
+}
@@ -3,9 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 
 	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
 	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+	"github.com/ponyo877/prime-checker/internal/shared/certificatestatus"
+	"github.com/ponyo877/prime-checker/internal/shared/numberhash"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
 )
 
 type PrimeCheckRepository struct {
@@ -20,29 +26,258 @@ func NewPrimeCheckRepository(db *sql.DB) usecase.PrimeCheckRepository {
 	}
 }
 
-func (r *PrimeCheckRepository) UpdatePrimeCheckResult(ctx context.Context, requestID int32, traceID, messageID string, isPrime bool, status string) error {
+// GetPrimeCheckStatus returns the status of a request and the attempt it is on.
+func (r *PrimeCheckRepository) GetPrimeCheckStatus(ctx context.Context, requestID int32) (primecheckstatus.Status, int32, error) {
+	row, err := r.queries.GetPrimeCheckStatus(ctx, requestID)
+	if err != nil {
+		return "", 0, err
+	}
+	return primecheckstatus.Status(row.Status.String), row.Attempt, nil
+}
+
+// StartPrimeCheck moves a dispatched request on to computing, and fails with
+// ErrInvalidTransition for a request in any other status and ErrStaleAttempt
+// for one that a retry has moved on to another attempt.
+func (r *PrimeCheckRepository) StartPrimeCheck(ctx context.Context, requestID, attempt int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	from, err := primecheckstatus.Lock(ctx, txQueries, requestID)
+	if err != nil {
+		return err
+	}
+	// The row is locked, so no retry can start another attempt before the commit
+	current, err := txQueries.GetPrimeCheckStatus(ctx, requestID)
+	if err != nil {
+		return err
+	}
+	if current.Attempt != attempt {
+		return fmt.Errorf("%w: attempt %d superseded by %d", model.ErrStaleAttempt, attempt, current.Attempt)
+	}
+	if err := primecheckstatus.Transition(ctx, txQueries, requestID, from, primecheckstatus.Computing, primecheckstatus.ActorPrimeCheckWorker); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePrimeCheckResult moves a computing request on to status with its
+// result, whose verdict is stored only for a completed request. It returns ErrRequestCancelled, and stores nothing, for a request
+// that was cancelled in the meantime, and ErrInvalidTransition for one that
+// is not computing for another reason.
+func (r *PrimeCheckRepository) UpdatePrimeCheckResult(ctx context.Context, requestID int32, traceID, messageID string, isPrime *bool, attribution model.Attribution, confidence model.Confidence, calculation model.Calculation, status primecheckstatus.Status) error {
 	var traceIDPtr *string
 	var messageIDPtr *string
-	
+	var algorithmPtr *string
+
 	if traceID != "" {
 		traceIDPtr = &traceID
 	}
 	if messageID != "" {
 		messageIDPtr = &messageID
 	}
+	if attribution.Algorithm != "" {
+		algorithmText := string(attribution.Algorithm)
+		algorithmPtr = &algorithmText
+	}
+
+	algorithmParams, err := convertAlgorithmParams(attribution.Params)
+	if err != nil {
+		return err
+	}
+
+	// Timed out and failed requests have no verdict, rather than a composite one
+	verdict := sql.NullBool{}
+	if isPrime != nil && status == primecheckstatus.Completed {
+		verdict = sql.NullBool{Bool: *isPrime, Valid: true}
+	}
+
+	// Results without a verdict, such as timed out ones, have no confidence
+	confidenceLevel := sql.NullString{}
+	errorBound := sql.NullFloat64{}
+	if confidence.Level != "" {
+		confidenceLevel = sql.NullString{String: string(confidence.Level), Valid: true}
+		errorBound = sql.NullFloat64{Float64: confidence.ErrorBound, Valid: true}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	from, err := primecheckstatus.Lock(ctx, txQueries, requestID)
+	if err != nil {
+		return err
+	}
+	if from == primecheckstatus.Cancelled {
+		return model.ErrRequestCancelled
+	}
+	if err := primecheckstatus.CheckTransition(from, status); err != nil {
+		return err
+	}
+
+	result, err := txQueries.UpdatePrimeCheckResult(ctx, generated_sql.UpdatePrimeCheckResultParams{
+		TraceID:          convertStringPtrToNullString(traceIDPtr),
+		MessageID:        convertStringPtrToNullString(messageIDPtr),
+		IsPrime:          verdict,
+		Algorithm:        convertStringPtrToNullString(algorithmPtr),
+		AlgorithmVersion: sql.NullString{String: attribution.Version, Valid: attribution.Version != ""},
+		AlgorithmParams:  algorithmParams,
+		Confidence:       confidenceLevel,
+		ErrorBound:       errorBound,
+		WorkerID:         sql.NullString{String: calculation.WorkerID, Valid: calculation.WorkerID != ""},
+		StartedAt:        sql.NullTime{Time: calculation.StartedAt, Valid: !calculation.StartedAt.IsZero()},
+		FinishedAt:       sql.NullTime{Time: calculation.FinishedAt, Valid: !calculation.FinishedAt.IsZero()},
+		CalculationUs:    sql.NullInt64{Int64: calculation.Duration().Microseconds(), Valid: !calculation.StartedAt.IsZero() && !calculation.FinishedAt.IsZero()},
+		Status:           sql.NullString{String: string(status), Valid: true},
+		ID:               requestID,
+		FromStatus:       sql.NullString{String: string(from), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if err := primecheckstatus.CheckUpdated(result); err != nil {
+		return err
+	}
+	if err := primecheckstatus.Record(ctx, txQueries, requestID, from, status, primecheckstatus.ActorPrimeCheckWorker); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PrimeCheckRepository) SaveFoundPrime(ctx context.Context, requestID int32, foundPrime *model.FoundPrime) error {
+	return r.queries.UpdatePrimeCheckFoundPrime(ctx, generated_sql.UpdatePrimeCheckFoundPrimeParams{
+		FoundPrime: sql.NullString{String: foundPrime.Prime, Valid: true},
+		PrimeGap:   sql.NullInt64{Int64: foundPrime.Gap, Valid: true},
+		ID:         requestID,
+	})
+}
+
+// SaveCachedResult stores a verdict in the shared results cache, where the web
+// server finds it for later requests of the same number.
+func (r *PrimeCheckRepository) SaveCachedResult(ctx context.Context, numberText string, accuracy model.Accuracy, isPrime bool, attribution model.Attribution, confidence model.Confidence) error {
+	algorithmParams, err := convertAlgorithmParams(attribution.Params)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.UpsertCachedPrimeResult(ctx, generated_sql.UpsertCachedPrimeResultParams{
+		NumberHash:       numberhash.Sum(numberText),
+		Accuracy:         string(accuracy),
+		NumberText:       numberText,
+		IsPrime:          isPrime,
+		Algorithm:        sql.NullString{String: string(attribution.Algorithm), Valid: true},
+		AlgorithmVersion: sql.NullString{String: attribution.Version, Valid: true},
+		AlgorithmParams:  algorithmParams,
+		Confidence:       sql.NullString{String: string(confidence.Level), Valid: true},
+		ErrorBound:       sql.NullFloat64{Float64: confidence.ErrorBound, Valid: true},
+	})
+}
+
+// convertAlgorithmParams encodes the parameters of a test as a JSON object,
+// or NULL when the test has none.
+func convertAlgorithmParams(params map[string]string) (json.RawMessage, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(params)
+}
 
-	return r.queries.UpdatePrimeCheckResult(ctx, generated_sql.UpdatePrimeCheckResultParams{
-		TraceID:   convertStringPtrToNullString(traceIDPtr),
-		MessageID: convertStringPtrToNullString(messageIDPtr),
-		IsPrime:   sql.NullBool{Bool: isPrime, Valid: true},
-		Status:    sql.NullString{String: status, Valid: true},
-		ID:        requestID,
+func (r *PrimeCheckRepository) UpdateCertificateStatus(ctx context.Context, requestID int32, status certificatestatus.Status) error {
+	return r.queries.UpdatePrimeCheckCertificateStatus(ctx, generated_sql.UpdatePrimeCheckCertificateStatusParams{
+		CertificateStatus: sql.NullString{String: string(status), Valid: true},
+		ID:                requestID,
 	})
 }
 
+func (r *PrimeCheckRepository) SavePrimeCertificate(ctx context.Context, requestID int32, certificate *model.Certificate) error {
+	certificateBytes, err := json.Marshal(certificate)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	if err := txQueries.CreatePrimeCertificate(ctx, generated_sql.CreatePrimeCertificateParams{
+		PrimeCheckID: requestID,
+		Method:       string(certificate.Method()),
+		Certificate:  certificateBytes,
+	}); err != nil {
+		return err
+	}
+
+	if err := txQueries.UpdatePrimeCheckCertificateStatus(ctx, generated_sql.UpdatePrimeCheckCertificateStatusParams{
+		CertificateStatus: sql.NullString{String: string(certificatestatus.Certified), Valid: true},
+		ID:                requestID,
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PrimeCheckRepository) UpdateFactorizationStatus(ctx context.Context, requestID int32, status model.FactorizationStatus) error {
+	return r.queries.UpdatePrimeCheckFactorizationStatus(ctx, generated_sql.UpdatePrimeCheckFactorizationStatusParams{
+		FactorizationStatus: sql.NullString{String: string(status), Valid: true},
+		ID:                  requestID,
+	})
+}
+
+func (r *PrimeCheckRepository) SavePrimeFactorization(ctx context.Context, requestID int32, factorization *model.Factorization) error {
+	factorsBytes, err := json.Marshal(factorization.Factors)
+	if err != nil {
+		return err
+	}
+
+	var unfactoredPtr *string
+	if factorization.Unfactored != "" {
+		unfactoredPtr = &factorization.Unfactored
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	if err := txQueries.CreatePrimeFactorization(ctx, generated_sql.CreatePrimeFactorizationParams{
+		PrimeCheckID: requestID,
+		Factors:      factorsBytes,
+		Unfactored:   convertStringPtrToNullString(unfactoredPtr),
+	}); err != nil {
+		return err
+	}
+
+	if err := txQueries.UpdatePrimeCheckFactorizationStatus(ctx, generated_sql.UpdatePrimeCheckFactorizationStatusParams{
+		FactorizationStatus: sql.NullString{String: string(factorization.Status), Valid: true},
+		ID:                  requestID,
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func convertStringPtrToNullString(ptr *string) sql.NullString {
 	if ptr == nil {
 		return sql.NullString{Valid: false}
 	}
 	return sql.NullString{String: *ptr, Valid: true}
-}
\ No newline at end of file
+}
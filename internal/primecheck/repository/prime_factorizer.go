@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+)
+
+type PrimeFactorizer struct{}
+
+func NewPrimeFactorizer() usecase.PrimeFactorizer {
+	return &PrimeFactorizer{}
+}
+
+func (f *PrimeFactorizer) Factorize(ctx context.Context, numberText string) (*model.Factorization, error) {
+	factorizer, err := model.NewFactorizer(numberText)
+	if err != nil {
+		return nil, err
+	}
+
+	return factorizer.Factorize(ctx), nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+)
+
+type PrimeRangeRepository struct {
+	queries *generated_sql.Queries
+}
+
+func NewPrimeRangeRepository(db *sql.DB) usecase.PrimeRangeRepository {
+	return &PrimeRangeRepository{
+		queries: generated_sql.New(db),
+	}
+}
+
+func (r *PrimeRangeRepository) ResetPrimeRangeChunks(ctx context.Context, rangeID int32) error {
+	return r.queries.DeletePrimeRangeChunks(ctx, rangeID)
+}
+
+func (r *PrimeRangeRepository) SavePrimeRangeChunk(ctx context.Context, rangeID int32, firstPosition int64, primes []uint64) error {
+	primesBytes, err := json.Marshal(primes)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.CreatePrimeRangeChunk(ctx, generated_sql.CreatePrimeRangeChunkParams{
+		PrimeRangeID:  rangeID,
+		FirstPosition: firstPosition,
+		EndPosition:   firstPosition + int64(len(primes)),
+		Primes:        primesBytes,
+	})
+}
+
+func (r *PrimeRangeRepository) UpdatePrimeRangeResult(ctx context.Context, rangeID int32, primeCount int64, status primecheckstatus.Status) error {
+	return r.queries.UpdatePrimeRangeResult(ctx, generated_sql.UpdatePrimeRangeResultParams{
+		PrimeCount: sql.NullInt64{Int64: primeCount, Valid: true},
+		Status:     sql.NullString{String: string(status), Valid: true},
+		ID:         rangeID,
+	})
+}
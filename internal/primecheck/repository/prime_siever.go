@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
+)
+
+type PrimeSiever struct{}
+
+func NewPrimeSiever() usecase.PrimeSiever {
+	return &PrimeSiever{}
+}
+
+func (s *PrimeSiever) Sieve(ctx context.Context, start, end uint64, handle func(primes []uint64) error) error {
+	sieve, err := model.NewSegmentedSieve(start, end)
+	if err != nil {
+		return err
+	}
+
+	return sieve.Run(ctx, handle)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ponyo877/prime-checker/internal/primecheck/model"
 	"github.com/ponyo877/prime-checker/internal/primecheck/usecase"
 	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
 )
 
 type ResultPublisher struct {
@@ -21,12 +23,21 @@ func NewResultPublisher(outboxRepo usecase.OutboxRepository) usecase.ResultPubli
 }
 
 func (p *ResultPublisher) PublishEmailMessage(ctx context.Context, result *model.PrimeResult, messageID string) error {
+	body := fmt.Sprintf("The number %s is prime: %v (decided by %s)", result.NumberText(), result.IsPrime(), result.Attribution())
+	if found := result.FoundPrime(); found != nil {
+		direction := "next"
+		if found.Operation == model.PrimeOperationPrevPrime {
+			direction = "previous"
+		}
+		body = fmt.Sprintf("The %s prime from %s is %s, a gap of %d (decided by %s)", direction, result.NumberText(), found.Prime, found.Gap, result.Attribution())
+	}
+
 	emailPayload := &message.EmailSendPayload{
 		RequestID:  result.RequestID(),
 		UserID:     result.UserID(),
 		Email:      "user@example.com", // TODO: Get from user profile
 		Subject:    fmt.Sprintf("Prime Check Result for %s", result.NumberText()),
-		Body:       fmt.Sprintf("The number %s is prime: %v", result.NumberText(), result.IsPrime()),
+		Body:       body,
 		IsPrime:    result.IsPrime(),
 		NumberText: result.NumberText(),
 		MessageID:  messageID,
@@ -44,3 +55,69 @@ func (p *ResultPublisher) PublishEmailMessage(ctx context.Context, result *model
 
 	return p.outboxRepo.CreateOutboxMessage(ctx, string(message.MessageTypeEmailSend), msgBytes)
 }
+
+func (p *ResultPublisher) PublishFactorizationMessage(ctx context.Context, result *model.PrimeResult) error {
+	factorizationPayload := &message.FactorizationPayload{
+		RequestID:  result.RequestID(),
+		UserID:     result.UserID(),
+		NumberText: result.NumberText(),
+	}
+
+	factorizationMsg, err := message.NewMessageWithTraceContext(ctx, message.MessageTypeFactorization, factorizationPayload)
+	if err != nil {
+		return fmt.Errorf("failed to create factorization message: %w", err)
+	}
+
+	msgBytes, err := json.Marshal(factorizationMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal factorization message: %w", err)
+	}
+
+	return p.outboxRepo.CreateOutboxMessage(ctx, string(message.MessageTypeFactorization), msgBytes)
+}
+
+func (p *ResultPublisher) PublishResultEvent(ctx context.Context, requestID, userID int32, status primecheckstatus.Status, isPrime *bool) error {
+	resultPayload := &message.PrimeCheckResultPayload{
+		RequestID: requestID,
+		UserID:    userID,
+		Status:    string(status),
+		IsPrime:   isPrime,
+		UpdatedAt: time.Now(),
+	}
+
+	resultMsg, err := message.NewMessageWithTraceContext(ctx, message.MessageTypePrimeCheckResult, resultPayload)
+	if err != nil {
+		return fmt.Errorf("failed to create result event message: %w", err)
+	}
+
+	msgBytes, err := json.Marshal(resultMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result event message: %w", err)
+	}
+
+	return p.outboxRepo.CreateOutboxMessage(ctx, string(message.MessageTypePrimeCheckResult), msgBytes)
+}
+
+func (p *ResultPublisher) PublishWebhookMessage(ctx context.Context, request *model.PrimeRequest, status primecheckstatus.Status, isPrime *bool) error {
+	webhookPayload := &message.WebhookDeliveryPayload{
+		RequestID:   request.RequestID(),
+		UserID:      request.UserID(),
+		CallbackURL: request.CallbackURL(),
+		NumberText:  request.NumberText(),
+		Status:      string(status),
+		IsPrime:     isPrime,
+		UpdatedAt:   time.Now(),
+	}
+
+	webhookMsg, err := message.NewMessageWithTraceContext(ctx, message.MessageTypeWebhookDelivery, webhookPayload)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook message: %w", err)
+	}
+
+	msgBytes, err := json.Marshal(webhookMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook message: %w", err)
+	}
+
+	return p.outboxRepo.CreateOutboxMessage(ctx, string(message.MessageTypeWebhookDelivery), msgBytes)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+)
+
+const (
+	// Time budget for one factorization before the partial result is saved as timed out
+	factorizationTimeout = 60 * time.Second
+)
+
+type FactorizationUsecase struct {
+	factorizer PrimeFactorizer
+	repository PrimeCheckRepository
+}
+
+func NewFactorizationUsecase(factorizer PrimeFactorizer, repository PrimeCheckRepository) *FactorizationUsecase {
+	return &FactorizationUsecase{
+		factorizer: factorizer,
+		repository: repository,
+	}
+}
+
+func (u *FactorizationUsecase) ProcessFactorizationRequest(ctx context.Context, request *model.FactorizationRequest) (*model.Factorization, error) {
+	log.Printf("Processing factorization request for number: %s", request.NumberText())
+
+	factorizeCtx, cancel := context.WithTimeout(ctx, factorizationTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	factorization, err := u.factorizer.Factorize(factorizeCtx, request.NumberText())
+	factorizationTime := time.Since(startTime)
+
+	if errors.Is(err, model.ErrNotFactorizable) {
+		// A redelivery would be rejected again: record it and acknowledge
+		log.Printf("Factorization of %s failed: %v", request.NumberText(), err)
+		if updateErr := u.repository.UpdateFactorizationStatus(ctx, request.RequestID(), model.FactorizationStatusFailed); updateErr != nil {
+			log.Printf("Failed to update factorization status in DB: %v", updateErr)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		// Update DB with failed status
+		if updateErr := u.repository.UpdateFactorizationStatus(ctx, request.RequestID(), model.FactorizationStatusFailed); updateErr != nil {
+			log.Printf("Failed to update factorization status in DB: %v", updateErr)
+		}
+		return nil, fmt.Errorf("failed to factorize: %w", err)
+	}
+
+	log.Printf("Factorization of %s: %d prime factors, status %s (took %v)", request.NumberText(), len(factorization.Factors), factorization.Status, factorizationTime)
+
+	if err := u.repository.SavePrimeFactorization(ctx, request.RequestID(), factorization); err != nil {
+		return nil, fmt.Errorf("failed to save factorization: %w", err)
+	}
+
+	return factorization, nil
+}
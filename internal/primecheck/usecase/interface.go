@@ -5,14 +5,37 @@ import (
 	"encoding/json"
 
 	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/shared/certificatestatus"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
 )
 
 type PrimeCalculator interface {
-	Calculate(numberText string) (bool, error)
+	Calculate(ctx context.Context, numberText string, accuracy model.Accuracy, algorithm model.PrimalityAlgorithm) (bool, model.Attribution, model.Confidence, error)
+	FindPrime(ctx context.Context, numberText string, operation model.PrimeOperation, accuracy model.Accuracy, algorithm model.PrimalityAlgorithm) (*model.FoundPrime, model.Attribution, model.Confidence, error)
+}
+
+type PrimeCertifier interface {
+	Certify(ctx context.Context, numberText string) (*model.Certificate, error)
+}
+
+type PrimeFactorizer interface {
+	Factorize(ctx context.Context, numberText string) (*model.Factorization, error)
+}
+
+type PrimeSiever interface {
+	Sieve(ctx context.Context, start, end uint64, handle func(primes []uint64) error) error
+}
+
+type FaultInjector interface {
+	Inject(ctx context.Context, step faultinjection.Step) error
 }
 
 type ResultPublisher interface {
 	PublishEmailMessage(ctx context.Context, result *model.PrimeResult, messageID string) error
+	PublishFactorizationMessage(ctx context.Context, result *model.PrimeResult) error
+	PublishResultEvent(ctx context.Context, requestID, userID int32, status primecheckstatus.Status, isPrime *bool) error
+	PublishWebhookMessage(ctx context.Context, request *model.PrimeRequest, status primecheckstatus.Status, isPrime *bool) error
 }
 
 type OutboxRepository interface {
@@ -20,5 +43,19 @@ type OutboxRepository interface {
 }
 
 type PrimeCheckRepository interface {
-	UpdatePrimeCheckResult(ctx context.Context, requestID int32, traceID, messageID string, isPrime bool, status string) error
+	GetPrimeCheckStatus(ctx context.Context, requestID int32) (primecheckstatus.Status, int32, error)
+	StartPrimeCheck(ctx context.Context, requestID, attempt int32) error
+	UpdatePrimeCheckResult(ctx context.Context, requestID int32, traceID, messageID string, isPrime *bool, attribution model.Attribution, confidence model.Confidence, calculation model.Calculation, status primecheckstatus.Status) error
+	SaveFoundPrime(ctx context.Context, requestID int32, foundPrime *model.FoundPrime) error
+	SaveCachedResult(ctx context.Context, numberText string, accuracy model.Accuracy, isPrime bool, attribution model.Attribution, confidence model.Confidence) error
+	UpdateCertificateStatus(ctx context.Context, requestID int32, status certificatestatus.Status) error
+	SavePrimeCertificate(ctx context.Context, requestID int32, certificate *model.Certificate) error
+	UpdateFactorizationStatus(ctx context.Context, requestID int32, status model.FactorizationStatus) error
+	SavePrimeFactorization(ctx context.Context, requestID int32, factorization *model.Factorization) error
+}
+
+type PrimeRangeRepository interface {
+	ResetPrimeRangeChunks(ctx context.Context, rangeID int32) error
+	SavePrimeRangeChunk(ctx context.Context, rangeID int32, firstPosition int64, primes []uint64) error
+	UpdatePrimeRangeResult(ctx context.Context, rangeID int32, primeCount int64, status primecheckstatus.Status) error
 }
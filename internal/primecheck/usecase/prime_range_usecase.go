@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+)
+
+const (
+	// Time budget for sieving one range before the primes found so far are saved as timed out
+	primeRangeTimeout = 10 * time.Minute
+)
+
+type PrimeRangeUsecase struct {
+	siever     PrimeSiever
+	repository PrimeRangeRepository
+}
+
+func NewPrimeRangeUsecase(siever PrimeSiever, repository PrimeRangeRepository) *PrimeRangeUsecase {
+	return &PrimeRangeUsecase{
+		siever:     siever,
+		repository: repository,
+	}
+}
+
+// ProcessPrimeRangeRequest sieves the requested range and streams the primes
+// of every segment into the results table as soon as they are found, so that
+// clients can page through a large range while it is still being sieved.
+func (u *PrimeRangeUsecase) ProcessPrimeRangeRequest(ctx context.Context, request *model.PrimeRangeRequest) (int64, error) {
+	log.Printf("Processing prime range request for [%d, %d]", request.Start(), request.End())
+
+	// Drop the chunks of an earlier delivery that was interrupted
+	if err := u.repository.ResetPrimeRangeChunks(ctx, request.RangeID()); err != nil {
+		return 0, fmt.Errorf("failed to reset prime range chunks: %w", err)
+	}
+
+	sieveCtx, cancel := context.WithTimeout(ctx, primeRangeTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	var primeCount int64
+	err := u.siever.Sieve(sieveCtx, request.Start(), request.End(), func(primes []uint64) error {
+		if !request.CountOnly() {
+			if err := u.repository.SavePrimeRangeChunk(ctx, request.RangeID(), primeCount, primes); err != nil {
+				return fmt.Errorf("failed to save prime range chunk: %w", err)
+			}
+		}
+		primeCount += int64(len(primes))
+		return nil
+	})
+	sieveTime := time.Since(startTime)
+
+	status := primecheckstatus.Completed
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		log.Printf("Prime range [%d, %d] timed out after %d primes (took %v)", request.Start(), request.End(), primeCount, sieveTime)
+		status = primecheckstatus.TimedOut
+	case ctx.Err() != nil:
+		// The worker is shutting down: leave the range processing so that it is redelivered
+		return 0, fmt.Errorf("prime range interrupted: %w", ctx.Err())
+	case err != nil:
+		if updateErr := u.repository.UpdatePrimeRangeResult(ctx, request.RangeID(), primeCount, primecheckstatus.Failed); updateErr != nil {
+			log.Printf("Failed to update prime range result in DB: %v", updateErr)
+		}
+		return 0, fmt.Errorf("failed to sieve range: %w", err)
+	default:
+		log.Printf("Prime range [%d, %d]: %d primes (took %v)", request.Start(), request.End(), primeCount, sieveTime)
+	}
+
+	if err := u.repository.UpdatePrimeRangeResult(ctx, request.RangeID(), primeCount, status); err != nil {
+		return 0, fmt.Errorf("failed to update prime range result: %w", err)
+	}
+
+	return primeCount, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -9,32 +10,93 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ponyo877/prime-checker/internal/primecheck/model"
+	"github.com/ponyo877/prime-checker/internal/shared/certificatestatus"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+)
+
+const (
+	// Interval at which a running calculation checks whether its request was cancelled
+	cancellationPollInterval = 2 * time.Second
 )
 
 type PrimeCheckUsecase struct {
 	calculator PrimeCalculator
+	certifier  PrimeCertifier
 	publisher  ResultPublisher
 	repository PrimeCheckRepository
+	faults     FaultInjector
+	workerID   string
 }
 
-func NewPrimeCheckUsecase(calculator PrimeCalculator, publisher ResultPublisher, repository PrimeCheckRepository) *PrimeCheckUsecase {
+func NewPrimeCheckUsecase(calculator PrimeCalculator, certifier PrimeCertifier, publisher ResultPublisher, repository PrimeCheckRepository, faults FaultInjector, workerID string) *PrimeCheckUsecase {
 	return &PrimeCheckUsecase{
 		calculator: calculator,
+		certifier:  certifier,
 		publisher:  publisher,
 		repository: repository,
+		faults:     faults,
+		workerID:   workerID,
 	}
 }
 
 func (u *PrimeCheckUsecase) ProcessPrimeRequest(ctx context.Context, request *model.PrimeRequest) (*model.PrimeResult, error) {
-	log.Printf("Processing prime check request for number: %s", request.NumberText())
+	log.Printf("Processing %s request for number: %s", request.Operation(), request.NumberText())
 
-	startTime := time.Now()
-	isPrime, err := u.calculator.Calculate(request.NumberText())
-	calculationTime := time.Since(startTime)
+	// An injected fault leaves the request dispatched. The message is redelivered
+	// with a growing delay, but never moved to the dead letter queue for it, so
+	// the request recovers once the fault is lifted
+	if err := u.faults.Inject(ctx, faultinjection.StepPrimeCheck); err != nil {
+		return nil, err
+	}
+
+	if started, err := u.startPrimeCheck(ctx, request); err != nil || !started {
+		return nil, err
+	}
+
+	// A request cancelled while it is calculated stops the calculation
+	cancellableCtx, cancelCalculation := context.WithCancelCause(ctx)
+	defer cancelCalculation(nil)
+	go u.watchCancellation(cancellableCtx, request.RequestID(), cancelCalculation)
+
+	// The calculation and the certificate share the time budget of the request
+	deadline := time.Now().Add(timeBudget(request))
+	calculateCtx, cancel := context.WithDeadline(cancellableCtx, deadline)
+	defer cancel()
 
+	calculation := model.Calculation{WorkerID: u.workerID, StartedAt: time.Now()}
+	isPrime, attribution, confidence, foundPrime, err := u.calculate(calculateCtx, request)
+	calculation.FinishedAt = time.Now()
+	calculationTime := calculation.Duration()
+
+	if errors.Is(context.Cause(cancellableCtx), model.ErrRequestCancelled) {
+		log.Printf("Prime check %d was cancelled after %v", request.RequestID(), calculationTime)
+		return nil, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		// The budget ran out: record it and acknowledge, since a retry would time out again
+		log.Printf("Prime check for %s timed out by %s after %v", request.NumberText(), attribution, calculationTime)
+		if updateErr := u.saveResult(ctx, request, getTraceIDFromContext(ctx), "", nil, attribution, model.Confidence{}, calculation, primecheckstatus.TimedOut); updateErr != nil {
+			log.Printf("Failed to update prime check result in DB: %v", updateErr)
+		}
+		return nil, nil
+	}
+	if errors.Is(err, model.ErrUnknownAlgorithm) || errors.Is(err, model.ErrUnsupportedAlgorithm) {
+		// The requested test cannot decide the number: record it and acknowledge, since a retry would fail again
+		log.Printf("Prime check for %s failed: %v", request.NumberText(), err)
+		if updateErr := u.saveResult(ctx, request, "", "", nil, model.Attribution{}, model.Confidence{}, calculation, primecheckstatus.Failed); updateErr != nil {
+			log.Printf("Failed to update prime check result in DB: %v", updateErr)
+		}
+		return nil, nil
+	}
+	if ctx.Err() != nil {
+		// The worker is shutting down: leave the request computing so that it is redelivered
+		return nil, fmt.Errorf("prime calculation interrupted: %w", ctx.Err())
+	}
 	if err != nil {
-		// Update DB with failed status
-		if updateErr := u.repository.UpdatePrimeCheckResult(ctx, request.RequestID(), "", "", false, "failed"); updateErr != nil {
+		// Update DB with failed status; the redelivery finds it failed and leaves it to a retry
+		if updateErr := u.saveResult(ctx, request, "", "", nil, model.Attribution{}, model.Confidence{}, calculation, primecheckstatus.Failed); updateErr != nil {
 			log.Printf("Failed to update prime check result in DB: %v", updateErr)
 		}
 		return nil, fmt.Errorf("failed to calculate prime: %w", err)
@@ -45,29 +107,219 @@ func (u *PrimeCheckUsecase) ProcessPrimeRequest(ctx context.Context, request *mo
 		request.UserID(),
 		request.NumberText(),
 		isPrime,
-		time.Now(),
+		attribution,
+		confidence,
+		foundPrime,
+		calculation.FinishedAt,
 		calculationTime,
 	)
 
-	log.Printf("Prime check result for %s: %v (took %v)", request.NumberText(), isPrime, calculationTime)
+	log.Printf("Prime check result for %s: %v by %s, %s with error bound %g (took %v)", request.NumberText(), isPrime, attribution, confidence.Level, confidence.ErrorBound, calculationTime)
+
+	if foundPrime != nil {
+		if err := u.repository.SaveFoundPrime(ctx, request.RequestID(), foundPrime); err != nil {
+			log.Printf("Failed to save found prime in DB: %v", err)
+		}
+	}
 
 	// Update DB with completed result
 	traceID := getTraceIDFromContext(ctx)
 	messageID := fmt.Sprintf("msg_%d_%d", request.RequestID(), time.Now().Unix())
-	if err := u.repository.UpdatePrimeCheckResult(ctx, request.RequestID(), traceID, messageID, isPrime, "completed"); err != nil {
+	if err := u.saveResult(ctx, request, traceID, messageID, &isPrime, attribution, confidence, calculation, primecheckstatus.Completed); errors.Is(err, model.ErrRequestCancelled) || errors.Is(err, primecheckstatus.ErrInvalidTransition) {
+		// Cancelled between the end of the calculation and saving it, or saved by a
+		// concurrent delivery of the same request: this result is not wanted
+		log.Printf("Prime check %d left computing before its result was saved: %v", request.RequestID(), err)
+		return nil, nil
+	} else if err != nil {
 		log.Printf("Failed to update prime check result in DB: %v", err)
 		// Continue with email publishing even if DB update fails
 	}
 
+	// Later requests of the same number are answered from the cache without a
+	// worker. The cache is keyed by number and accuracy alone, so a verdict of
+	// an algorithm the caller pinned is kept out of it, where it would answer
+	// requests that let the worker choose
+	if foundPrime == nil && request.Algorithm() == "" {
+		if err := u.repository.SaveCachedResult(ctx, request.NumberText(), request.Accuracy(), isPrime, attribution, confidence); err != nil {
+			log.Printf("Failed to save cached result in DB: %v", err)
+		}
+	}
+
 	// Publish result for email notification
 	if err := u.publisher.PublishEmailMessage(ctx, result, messageID); err != nil {
 		log.Printf("Failed to publish email message: %v", err)
 		// Don't return error here - the calculation was successful
 	}
 
+	if request.Certify() {
+		// A prime search certifies the prime it found rather than the number it started from
+		if foundPrime != nil {
+			u.certifyPrime(ctx, request.RequestID(), foundPrime.Prime, true, deadline)
+		} else {
+			u.certifyPrime(ctx, request.RequestID(), request.NumberText(), isPrime, deadline)
+		}
+	}
+
+	// Composite numbers are factorized; the others that are not prime have no factors
+	if !isPrime && foundPrime == nil && model.IsFactorizable(request.NumberText()) {
+		u.requestFactorization(ctx, result)
+	}
+
 	return result, nil
 }
 
+// startPrimeCheck moves a dispatched request on to computing and reports
+// whether it is to be calculated. A request that is already computing was
+// redelivered after its worker stopped, and is calculated again; one in any
+// other status, such as cancelled or finished by an earlier delivery, is
+// acknowledged without a calculation. So is a message of an earlier attempt,
+// left over from before a retry that queued a message of its own.
+func (u *PrimeCheckUsecase) startPrimeCheck(ctx context.Context, request *model.PrimeRequest) (bool, error) {
+	status, attempt, err := u.repository.GetPrimeCheckStatus(ctx, request.RequestID())
+	if err != nil {
+		return false, fmt.Errorf("failed to get prime check status: %w", err)
+	}
+	if attempt != request.Attempt() {
+		log.Printf("Skipping attempt %d of prime check %d, which is on attempt %d", request.Attempt(), request.RequestID(), attempt)
+		return false, nil
+	}
+
+	switch status {
+	case primecheckstatus.Dispatched:
+		err := u.repository.StartPrimeCheck(ctx, request.RequestID(), request.Attempt())
+		if errors.Is(err, primecheckstatus.ErrInvalidTransition) || errors.Is(err, model.ErrStaleAttempt) {
+			// Cancelled or retried since the status was read
+			log.Printf("Skipping prime check %d, which left dispatched: %v", request.RequestID(), err)
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("failed to start prime check: %w", err)
+		}
+		if err := u.publisher.PublishResultEvent(ctx, request.RequestID(), request.UserID(), primecheckstatus.Computing, nil); err != nil {
+			log.Printf("Failed to publish result event: %v", err)
+		}
+		return true, nil
+	case primecheckstatus.Computing:
+		log.Printf("Resuming prime check %d, which was redelivered while computing", request.RequestID())
+		return true, nil
+	default:
+		log.Printf("Skipping prime check %d, which is %s", request.RequestID(), status)
+		return false, nil
+	}
+}
+
+// saveResult stores the result of a request and announces its new status to
+// the clients following it and to its callback URL, if any. Only a completed
+// check has a verdict; the others pass nil. A lost announcement is only
+// logged, since the result itself is saved.
+func (u *PrimeCheckUsecase) saveResult(ctx context.Context, request *model.PrimeRequest, traceID, messageID string, verdict *bool, attribution model.Attribution, confidence model.Confidence, calculation model.Calculation, status primecheckstatus.Status) error {
+	if err := u.repository.UpdatePrimeCheckResult(ctx, request.RequestID(), traceID, messageID, verdict, attribution, confidence, calculation, status); err != nil {
+		return err
+	}
+
+	if err := u.publisher.PublishResultEvent(ctx, request.RequestID(), request.UserID(), status, verdict); err != nil {
+		log.Printf("Failed to publish result event: %v", err)
+	}
+	if request.CallbackURL() != "" {
+		if err := u.publisher.PublishWebhookMessage(ctx, request, status, verdict); err != nil {
+			log.Printf("Failed to publish webhook message: %v", err)
+		}
+	}
+	return nil
+}
+
+// watchCancellation polls the status of a request until ctx is done, and
+// cancels its calculation with ErrRequestCancelled once it is cancelled.
+func (u *PrimeCheckUsecase) watchCancellation(ctx context.Context, requestID int32, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(cancellationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status, _, err := u.repository.GetPrimeCheckStatus(ctx, requestID)
+			if err != nil {
+				// The calculation goes on: the result is not saved over a cancellation anyway
+				log.Printf("Failed to check whether prime check %d was cancelled: %v", requestID, err)
+				continue
+			}
+			if status == primecheckstatus.Cancelled {
+				cancel(model.ErrRequestCancelled)
+				return
+			}
+		}
+	}
+}
+
+// calculate runs the requested operation. A prime search reports the number
+// itself as prime exactly when the prime it found is the number, at gap 0.
+func (u *PrimeCheckUsecase) calculate(ctx context.Context, request *model.PrimeRequest) (bool, model.Attribution, model.Confidence, *model.FoundPrime, error) {
+	if request.Operation() == model.PrimeOperationIsPrime {
+		isPrime, attribution, confidence, err := u.calculator.Calculate(ctx, request.NumberText(), request.Accuracy(), request.Algorithm())
+		return isPrime, attribution, confidence, nil, err
+	}
+
+	foundPrime, attribution, confidence, err := u.calculator.FindPrime(ctx, request.NumberText(), request.Operation(), request.Accuracy(), request.Algorithm())
+	if err != nil {
+		return false, attribution, confidence, nil, err
+	}
+	return foundPrime.Gap == 0, attribution, confidence, foundPrime, nil
+}
+
+// timeBudget is the time a request may take, which the web server already
+// capped at primality.MaxTimeout. Requests that ask for no budget get the
+// longest one, as do messages queued before requests carried a budget.
+func timeBudget(request *model.PrimeRequest) time.Duration {
+	if request.Timeout() <= 0 {
+		return primality.MaxTimeout
+	}
+	return min(request.Timeout(), primality.MaxTimeout)
+}
+
+// certifyPrime attaches a primality certificate to a prime verdict, giving up
+// at the deadline of the request. Failures only mark the certificate status,
+// since the probabilistic result is already saved.
+func (u *PrimeCheckUsecase) certifyPrime(ctx context.Context, requestID int32, numberText string, isPrime bool, deadline time.Time) {
+	if !isPrime {
+		if err := u.repository.UpdateCertificateStatus(ctx, requestID, certificatestatus.NotApplicable); err != nil {
+			log.Printf("Failed to update certificate status in DB: %v", err)
+		}
+		return
+	}
+
+	certifyCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	startTime := time.Now()
+	certificate, err := u.certifier.Certify(certifyCtx, numberText)
+	if err != nil {
+		log.Printf("Failed to certify %s after %v: %v", numberText, time.Since(startTime), err)
+		// Also recorded when the worker is shutting down, since the redelivery skips the completed check
+		if updateErr := u.repository.UpdateCertificateStatus(context.WithoutCancel(ctx), requestID, certificatestatus.Failed); updateErr != nil {
+			log.Printf("Failed to update certificate status in DB: %v", updateErr)
+		}
+		return
+	}
+
+	log.Printf("Certified %s with %s in %d steps (took %v)", numberText, certificate.Method(), len(certificate.Steps), time.Since(startTime))
+
+	if err := u.repository.SavePrimeCertificate(ctx, requestID, certificate); err != nil {
+		log.Printf("Failed to save prime certificate in DB: %v", err)
+	}
+}
+
+// requestFactorization queues the factorization job for a composite verdict.
+func (u *PrimeCheckUsecase) requestFactorization(ctx context.Context, result *model.PrimeResult) {
+	if err := u.repository.UpdateFactorizationStatus(ctx, result.RequestID(), model.FactorizationStatusPending); err != nil {
+		log.Printf("Failed to update factorization status in DB: %v", err)
+	}
+
+	if err := u.publisher.PublishFactorizationMessage(ctx, result); err != nil {
+		log.Printf("Failed to publish factorization message: %v", err)
+	}
+}
+
 func getTraceIDFromContext(ctx context.Context) string {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
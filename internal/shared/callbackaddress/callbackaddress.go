@@ -0,0 +1,123 @@
+// Package callbackaddress keeps webhooks from reaching the services around
+// the deployment: a callback URL may only lead to a public address. The web
+// server checks the addresses a callback host resolves to when a request is
+// submitted, and the webhook worker checks the address it connects to again,
+// since the host may resolve differently by the time the result is posted.
+package callbackaddress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+)
+
+// ErrNotPublic is returned for an address a webhook must not be posted to.
+var ErrNotPublic = errors.New("address is not public")
+
+// Blocks a webhook must not reach: the special-purpose blocks of the IANA
+// registries that are not globally reachable, and multicast
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // This network
+	netip.MustParsePrefix("10.0.0.0/8"),      // Private
+	netip.MustParsePrefix("100.64.0.0/10"),   // Shared address space of carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),     // Loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // Link-local, including cloud metadata services
+	netip.MustParsePrefix("172.16.0.0/12"),   // Private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // Documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // Private
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // Documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // Documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // Multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved, including broadcast
+
+	netip.MustParsePrefix("::/96"),          // Unspecified, loopback and IPv4-compatible
+	netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64
+	netip.MustParsePrefix("100::/64"),       // Discard-only
+	netip.MustParsePrefix("2001::/32"),      // Teredo, tunneled to an IPv4 address
+	netip.MustParsePrefix("2001:2::/48"),    // Benchmarking
+	netip.MustParsePrefix("2001:db8::/32"),  // Documentation
+	netip.MustParsePrefix("3fff::/20"),      // Documentation
+	netip.MustParsePrefix("fc00::/7"),       // Unique local
+	netip.MustParsePrefix("fe80::/10"),      // Link-local
+	netip.MustParsePrefix("fec0::/10"),      // Site-local
+	netip.MustParsePrefix("ff00::/8"),       // Multicast
+}
+
+// IPv6 blocks that reach the IPv4 address embedded in them
+var (
+	// Well-known NAT64 prefix, with the IPv4 address in the last 32 bits
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	// 6to4, with the IPv4 address right after the prefix
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// Check accepts a public address, and rejects an address in any of the denied
+// blocks. A NAT64 or 6to4 address is also rejected when the IPv4 address it
+// embeds is.
+func Check(addr netip.Addr) error {
+	// A zoned address never matches a prefix, and the zone does not change where it leads
+	addr = addr.WithZone("").Unmap()
+	if !addr.IsValid() {
+		return fmt.Errorf("%w: %s", ErrNotPublic, addr)
+	}
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s", ErrNotPublic, addr)
+		}
+	}
+	if embedded, ok := embeddedIPv4(addr); ok {
+		if err := Check(embedded); err != nil {
+			return fmt.Errorf("%w: %s embeds %s", ErrNotPublic, addr, embedded)
+		}
+	}
+	return nil
+}
+
+// embeddedIPv4 returns the IPv4 address a NAT64 or 6to4 address leads to.
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	bytes := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(bytes[12:16])), true
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(bytes[2:6])), true
+	default:
+		return netip.Addr{}, false
+	}
+}
+
+// CheckHost resolves the host of a callback URL, an IP literal or a name, and
+// checks every address it resolves to.
+func CheckHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return Check(addr)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := Check(addr); err != nil {
+			return fmt.Errorf("%s: %w", host, err)
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control function that refuses to connect to an
+// address Check rejects. It runs after the host is resolved, on the very
+// address about to be connected to.
+func Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	return Check(addrPort.Addr())
+}
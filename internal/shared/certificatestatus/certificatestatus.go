@@ -0,0 +1,18 @@
+// Package certificatestatus is the progress of the primality certificate a
+// prime check may request: pending from the moment the check is stored until
+// the prime check worker certifies the prime, fails to, or finds the number
+// composite.
+package certificatestatus
+
+type Status string
+
+const (
+	// The worker has yet to certify the number
+	Pending Status = "pending"
+	// A verified certificate is stored
+	Certified Status = "certified"
+	// The worker could not produce or verify a certificate
+	Failed Status = "failed"
+	// The number is composite, so there is nothing to certify
+	NotApplicable Status = "not_applicable"
+)
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/ponyo877/prime-checker/internal/shared/infrastructure"
 )
@@ -22,3 +25,33 @@ func LoadMessagingConfig() infrastructure.MessagingConfig {
 		Port: os.Getenv("NATS_PORT"),
 	}
 }
+
+// LoadSubscriptionConfig reads SUBSCRIPTION_FETCH_BATCH_SIZE,
+// SUBSCRIPTION_MAX_IN_FLIGHT and SUBSCRIPTION_SHUTDOWN_TIMEOUT (a duration
+// such as 30s), keeping the default of any that is unset or invalid.
+func LoadSubscriptionConfig() infrastructure.SubscriptionConfig {
+	config := infrastructure.DefaultSubscriptionConfig()
+	if size, err := strconv.Atoi(os.Getenv("SUBSCRIPTION_FETCH_BATCH_SIZE")); err == nil && size > 0 {
+		config.FetchBatchSize = size
+	}
+	if maxInFlight, err := strconv.Atoi(os.Getenv("SUBSCRIPTION_MAX_IN_FLIGHT")); err == nil && maxInFlight > 0 {
+		config.MaxInFlight = maxInFlight
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("SUBSCRIPTION_SHUTDOWN_TIMEOUT")); err == nil && timeout >= 0 {
+		config.ShutdownTimeout = timeout
+	}
+	return config
+}
+
+// LoadWorkerID identifies this worker process in stored results: WORKER_ID
+// when set, otherwise the host name and process ID.
+func LoadWorkerID() string {
+	if workerID := os.Getenv("WORKER_ID"); workerID != "" {
+		return workerID
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
@@ -0,0 +1,302 @@
+// Package expression evaluates the arithmetic expressions accepted as prime
+// check input, such as 2^127-1, 3*2^1000+1, 1000!+1 or 0xFFFF_FFFB.
+//
+// Grammar, loosest binding first:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { "*" unary }
+//	unary   = "-" unary | power
+//	power   = postfix [ "^" unary ]
+//	postfix = primary { "!" | "#" }
+//	primary = literal | "(" expr ")"
+//
+// "!" is the factorial and "#" the primorial (product of the primes <= n).
+// Every intermediate result is bounded by MaxBits and the input by
+// MaxLength, so evaluation cost stays small no matter what is submitted.
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	// Longest accepted expression in bytes, enough for a MaxBits number written out in decimal
+	MaxLength = 40_000
+	// Largest accepted magnitude of the result and of every intermediate value, in bits
+	MaxBits = 100_000
+
+	maxTokens = 256
+	maxDepth  = 64
+)
+
+var (
+	ErrSyntax = errors.New("invalid expression")
+	ErrLimit  = errors.New("expression exceeds limits")
+)
+
+// Evaluate parses and evaluates input.
+func Evaluate(input string) (*big.Int, error) {
+	if len(input) > MaxLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrLimit, MaxLength)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, fmt.Errorf("%w: empty expression", ErrSyntax)
+	}
+
+	p := &parser{tokens: tokens}
+	value, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, next.text, next.pos)
+	}
+	return value, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expr() (*big.Int, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested deeper than %d levels", ErrLimit, maxDepth)
+	}
+
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op.kind != tokenPlus && op.kind != tokenMinus {
+			return left, nil
+		}
+		p.next()
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if op.kind == tokenPlus {
+			left = new(big.Int).Add(left, right)
+		} else {
+			left = new(big.Int).Sub(left, right)
+		}
+		if err := checkBits(left); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) term() (*big.Int, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenStar {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if left.BitLen()+right.BitLen() > MaxBits+1 {
+			return nil, fmt.Errorf("%w: product larger than %d bits", ErrLimit, MaxBits)
+		}
+		left = new(big.Int).Mul(left, right)
+		if err := checkBits(left); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (*big.Int, error) {
+	if p.peek().kind != tokenMinus {
+		return p.power()
+	}
+
+	p.next()
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested deeper than %d levels", ErrLimit, maxDepth)
+	}
+	value, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Neg(value), nil
+}
+
+func (p *parser) power() (*big.Int, error) {
+	base, err := p.postfix()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenCaret {
+		return base, nil
+	}
+
+	op := p.next()
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested deeper than %d levels", ErrLimit, maxDepth)
+	}
+	exponent, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return pow(base, exponent, op.pos)
+}
+
+func (p *parser) postfix() (*big.Int, error) {
+	value, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		switch op.kind {
+		case tokenBang:
+			p.next()
+			if value, err = factorial(value, op.pos); err != nil {
+				return nil, err
+			}
+		case tokenHash:
+			p.next()
+			if value, err = primorial(value, op.pos); err != nil {
+				return nil, err
+			}
+		default:
+			return value, nil
+		}
+	}
+}
+
+func (p *parser) primary() (*big.Int, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		if err := checkBits(t.value); err != nil {
+			return nil, err
+		}
+		return t.value, nil
+	case tokenLParen:
+		value, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, fmt.Errorf("%w: missing closing parenthesis at position %d", ErrSyntax, closing.pos)
+		}
+		return value, nil
+	case tokenEOF:
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, t.text, t.pos)
+	}
+}
+
+func pow(base, exponent *big.Int, pos int) (*big.Int, error) {
+	if exponent.Sign() < 0 {
+		return nil, fmt.Errorf("%w: negative exponent at position %d", ErrSyntax, pos)
+	}
+	// 0, 1 and -1 stay small whatever the exponent
+	if base.CmpAbs(big.NewInt(1)) <= 0 {
+		if base.Sign() < 0 && exponent.Bit(0) == 0 {
+			return big.NewInt(1), nil
+		}
+		if base.Sign() == 0 && exponent.Sign() == 0 {
+			return big.NewInt(1), nil
+		}
+		return new(big.Int).Set(base), nil
+	}
+	if !exponent.IsInt64() || exponent.Int64() > MaxBits || (int64(base.BitLen())-1)*exponent.Int64() >= MaxBits {
+		return nil, fmt.Errorf("%w: power at position %d is larger than %d bits", ErrLimit, pos, MaxBits)
+	}
+
+	value := new(big.Int).Exp(base, exponent, nil)
+	if err := checkBits(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func factorial(n *big.Int, pos int) (*big.Int, error) {
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("%w: factorial of a negative number at position %d", ErrSyntax, pos)
+	}
+	// log2(n!) = lgamma(n+1) / ln 2
+	if !n.IsInt64() || n.Int64() > MaxBits {
+		return nil, fmt.Errorf("%w: factorial at position %d is larger than %d bits", ErrLimit, pos, MaxBits)
+	}
+	k := n.Int64()
+	if lg, _ := math.Lgamma(float64(k + 1)); lg/math.Ln2 > MaxBits {
+		return nil, fmt.Errorf("%w: factorial at position %d is larger than %d bits", ErrLimit, pos, MaxBits)
+	}
+	if k < 2 {
+		return big.NewInt(1), nil
+	}
+	return new(big.Int).MulRange(2, k), nil
+}
+
+func primorial(n *big.Int, pos int) (*big.Int, error) {
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("%w: primorial of a negative number at position %d", ErrSyntax, pos)
+	}
+	// log2(n#) is about n / ln 2, so n <= MaxBits is a safe bound for sieving
+	if !n.IsInt64() || n.Int64() > MaxBits {
+		return nil, fmt.Errorf("%w: primorial at position %d is larger than %d bits", ErrLimit, pos, MaxBits)
+	}
+
+	limit := int(n.Int64())
+	composite := make([]bool, limit+1)
+	result := big.NewInt(1)
+	bits := 0.0
+	for i := 2; i <= limit; i++ {
+		if composite[i] {
+			continue
+		}
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+		bits += math.Log2(float64(i))
+		if bits > MaxBits {
+			return nil, fmt.Errorf("%w: primorial at position %d is larger than %d bits", ErrLimit, pos, MaxBits)
+		}
+		result.Mul(result, big.NewInt(int64(i)))
+	}
+	return result, nil
+}
+
+func checkBits(v *big.Int) error {
+	if v.BitLen() > MaxBits {
+		return fmt.Errorf("%w: value larger than %d bits", ErrLimit, MaxBits)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package expression
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "decimal", input: "97", want: "97"},
+		{name: "mersenne", input: "2^127-1", want: "170141183460469231731687303715884105727"},
+		{name: "proth", input: "3*2^4+1", want: "49"},
+		{name: "power is right associative", input: "2^3^2", want: "512"},
+		{name: "negation binds looser than power", input: "-2^2", want: "-4"},
+		{name: "parentheses", input: "(1+2)*3", want: "9"},
+		{name: "factorial", input: "5!+1", want: "121"},
+		{name: "factorial of zero", input: "0!", want: "1"},
+		{name: "primorial", input: "10#", want: "210"},
+		{name: "primorial of a prime", input: "11#", want: "2310"},
+		{name: "hexadecimal with underscore", input: "0xFFFF_FFFB", want: "4294967291"},
+		{name: "binary", input: "0b1011", want: "11"},
+		{name: "octal", input: "0o17", want: "15"},
+		{name: "leading zero is decimal", input: "007", want: "7"},
+		{name: "decimal with underscores", input: "1_000_000", want: "1000000"},
+		{name: "whitespace", input: " 2 ^ 61 - 1\n", want: "2305843009213693951"},
+		{name: "zero to the zero", input: "0^0", want: "1"},
+		{name: "minus one to a huge odd power", input: "(-1)^1000001", want: "-1"},
+		{name: "one to a huge power", input: "1^1000000000000", want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.input)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Evaluate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateLargestValue(t *testing.T) {
+	got, err := Evaluate("2^99999")
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if got.BitLen() != MaxBits {
+		t.Errorf("BitLen() = %d, want %d", got.BitLen(), MaxBits)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "empty", input: "", wantErr: ErrSyntax},
+		{name: "blank", input: "   ", wantErr: ErrSyntax},
+		{name: "dangling operator", input: "1+", wantErr: ErrSyntax},
+		{name: "unclosed parenthesis", input: "(1", wantErr: ErrSyntax},
+		{name: "unopened parenthesis", input: "1)", wantErr: ErrSyntax},
+		{name: "negative exponent", input: "2^-1", wantErr: ErrSyntax},
+		{name: "factorial of a negative number", input: "(-3)!", wantErr: ErrSyntax},
+		{name: "primorial of a negative number", input: "(-3)#", wantErr: ErrSyntax},
+		{name: "double underscore", input: "1__0", wantErr: ErrSyntax},
+		{name: "trailing underscore", input: "1_", wantErr: ErrSyntax},
+		{name: "letters in a decimal", input: "12abc", wantErr: ErrSyntax},
+		{name: "invalid hexadecimal", input: "0xZZ", wantErr: ErrSyntax},
+		{name: "unknown operator", input: "7 % 2", wantErr: ErrSyntax},
+		{name: "too long", input: strings.Repeat("1", MaxLength+1), wantErr: ErrLimit},
+		{name: "too many tokens", input: "1" + strings.Repeat("+1", maxTokens/2), wantErr: ErrLimit},
+		{name: "nested too deep", input: strings.Repeat("(", maxDepth+1) + "1" + strings.Repeat(")", maxDepth+1), wantErr: ErrLimit},
+		{name: "negation nested too deep", input: strings.Repeat("-", maxDepth+1) + "1", wantErr: ErrLimit},
+		{name: "literal too large", input: "1" + strings.Repeat("0", 30200), wantErr: ErrLimit},
+		{name: "power too large", input: "2^100000", wantErr: ErrLimit},
+		{name: "power with a huge exponent", input: "3^(10^30)", wantErr: ErrLimit},
+		{name: "product too large", input: "2^60000*2^60000", wantErr: ErrLimit},
+		{name: "factorial too large", input: "10000!", wantErr: ErrLimit},
+		{name: "primorial too large", input: "70000#", wantErr: ErrLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Evaluate(tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Evaluate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
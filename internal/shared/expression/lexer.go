@@ -0,0 +1,105 @@
+package expression
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenPlus
+	tokenMinus
+	tokenStar
+	tokenCaret
+	tokenBang
+	tokenHash
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value *big.Int
+	pos   int
+}
+
+var operatorTokens = map[byte]tokenKind{
+	'+': tokenPlus,
+	'-': tokenMinus,
+	'*': tokenStar,
+	'^': tokenCaret,
+	'!': tokenBang,
+	'#': tokenHash,
+	'(': tokenLParen,
+	')': tokenRParen,
+}
+
+// tokenize splits the input into tokens, parsing number literals on the way.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c):
+			end := i
+			for end < len(input) && (isAlphanumeric(input[end]) || input[end] == '_') {
+				end++
+			}
+			value, err := parseLiteral(input[i:end])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v at position %d", ErrSyntax, err, i+1)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: input[i:end], value: value, pos: i + 1})
+			i = end
+		default:
+			kind, ok := operatorTokens[c]
+			if !ok {
+				return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrSyntax, c, i+1)
+			}
+			tokens = append(tokens, token{kind: kind, text: string(c), pos: i + 1})
+			i++
+		}
+		if len(tokens) > maxTokens {
+			return nil, fmt.Errorf("%w: more than %d tokens", ErrLimit, maxTokens)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input) + 1}), nil
+}
+
+// parseLiteral accepts decimal literals and 0x, 0b and 0o prefixed literals,
+// all with optional underscores between digits. Unlike Go, a leading 0 does
+// not make a literal octal.
+func parseLiteral(text string) (*big.Int, error) {
+	lower := strings.ToLower(text)
+	if len(lower) > 1 && lower[0] == '0' && (lower[1] == 'x' || lower[1] == 'b' || lower[1] == 'o') {
+		// Base 0 handles the prefix and validates underscore placement
+		if value, ok := new(big.Int).SetString(text, 0); ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("invalid literal %q", text)
+	}
+
+	if strings.HasSuffix(text, "_") || strings.Contains(text, "__") {
+		return nil, fmt.Errorf("invalid literal %q", text)
+	}
+	value, ok := new(big.Int).SetString(strings.ReplaceAll(text, "_", ""), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid literal %q", text)
+	}
+	return value, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlphanumeric(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
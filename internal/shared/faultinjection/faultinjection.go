@@ -0,0 +1,116 @@
+// Package faultinjection makes the services fail on purpose at the steps the
+// settings disable, so that failure scenarios can be rehearsed end to end.
+package faultinjection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+)
+
+type Step string
+
+const (
+	// The web server stores a prime check and its outbox message
+	StepRecordNumber Step = "record_number"
+	// The prime check worker decides a number
+	StepPrimeCheck Step = "prime_check"
+	// The email send worker sends a result
+	StepEmailSend Step = "email_send"
+	// A worker moves a message it keeps failing on to the dead letter queue
+	StepDLQSave Step = "dlq_save"
+)
+
+// ErrInjected is returned by a step that the settings make fail.
+var ErrInjected = errors.New("injected fault")
+
+// Settings tell for every step whether it succeeds.
+type Settings struct {
+	RecordNumberSuccess bool
+	PrimeCheckSuccess   bool
+	EmailSendSuccess    bool
+	DLQSaveSuccess      bool
+}
+
+// DefaultSettings lets every step succeed, as before any settings are stored.
+func DefaultSettings() Settings {
+	return Settings{
+		RecordNumberSuccess: true,
+		PrimeCheckSuccess:   true,
+		EmailSendSuccess:    true,
+		DLQSaveSuccess:      true,
+	}
+}
+
+func (s Settings) Succeeds(step Step) bool {
+	switch step {
+	case StepRecordNumber:
+		return s.RecordNumberSuccess
+	case StepPrimeCheck:
+		return s.PrimeCheckSuccess
+	case StepEmailSend:
+		return s.EmailSendSuccess
+	case StepDLQSave:
+		return s.DLQSaveSuccess
+	default:
+		return true
+	}
+}
+
+// Injector reads the settings the web server stores. They are read at every
+// step, so that a change reaches every service at once and a message that is
+// redelivered after the fault is lifted recovers.
+type Injector struct {
+	queries *generated_sql.Queries
+}
+
+func NewInjector(queries *generated_sql.Queries) *Injector {
+	return &Injector{
+		queries: queries,
+	}
+}
+
+// Inject returns ErrInjected when the settings make step fail, and records the
+// fault on the span in ctx. Settings that cannot be read inject nothing, so
+// that a real failure is never reported as an injected one.
+func (i *Injector) Inject(ctx context.Context, step Step) error {
+	settings, err := i.Settings(ctx)
+	if err != nil {
+		log.Printf("Failed to read fault injection settings: %v", err)
+		return nil
+	}
+	if settings.Succeeds(step) {
+		return nil
+	}
+
+	err = fmt.Errorf("%w: %s", ErrInjected, step)
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("fault injected", trace.WithAttributes(attribute.String("fault.step", string(step))))
+	span.RecordError(err)
+	return err
+}
+
+// Settings returns the stored settings, or the default ones when none are stored.
+func (i *Injector) Settings(ctx context.Context) (Settings, error) {
+	row, err := i.queries.GetSettings(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultSettings(), nil
+	}
+	if err != nil {
+		return Settings{}, err
+	}
+
+	return Settings{
+		RecordNumberSuccess: row.RecordNumberSuccess,
+		PrimeCheckSuccess:   row.PrimeCheckSuccess,
+		EmailSendSuccess:    row.EmailSendSuccess,
+		DLQSaveSuccess:      row.DlqSaveSuccess,
+	}, nil
+}
@@ -30,6 +30,9 @@ func NewDatabaseConnection(config DatabaseConfig) (*sql.DB, error) {
 		ParseTime: true,
 		Collation: "utf8mb4_unicode_ci",
 		Loc:       jst,
+		// Report the rows an UPDATE matched rather than changed, so that a
+		// conditional UPDATE tells whether its condition held
+		ClientFoundRows: true,
 	}
 
 	db, err := sql.Open("mysql", c.FormatDSN())
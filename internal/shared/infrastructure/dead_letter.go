@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+)
+
+// DeadLetterWriter stores a message that its handler kept failing on, so
+// that it stops being redelivered and can be inspected.
+type DeadLetterWriter interface {
+	Save(ctx context.Context, subject, messageID string, data []byte, deliveries int, cause error) error
+}
+
+type deadLetterRepository struct {
+	queries *generated_sql.Queries
+	faults  *faultinjection.Injector
+}
+
+func NewDeadLetterWriter(queries *generated_sql.Queries) DeadLetterWriter {
+	return &deadLetterRepository{
+		queries: queries,
+		faults:  faultinjection.NewInjector(queries),
+	}
+}
+
+func (r *deadLetterRepository) Save(ctx context.Context, subject, messageID string, data []byte, deliveries int, cause error) error {
+	// Continue the trace of the message when it can still be read
+	var msg message.Message
+	if err := json.Unmarshal(data, &msg); err == nil {
+		ctx = msg.ExtractTraceContext(ctx)
+	}
+
+	tracer := otel.Tracer("dead-letter-writer")
+	ctx, span := tracer.Start(ctx, "SaveDeadLetter")
+	defer span.End()
+
+	if err := r.faults.Inject(ctx, faultinjection.StepDLQSave); err != nil {
+		return err
+	}
+
+	// A message that is not JSON is kept as a JSON string
+	payload := json.RawMessage(data)
+	if !json.Valid(data) {
+		var err error
+		if payload, err = json.Marshal(string(data)); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	if err := r.queries.CreateDeadLetterMessage(ctx, generated_sql.CreateDeadLetterMessageParams{
+		Subject:       subject,
+		MessageID:     sql.NullString{String: messageID, Valid: messageID != ""},
+		Payload:       payload,
+		ErrorMessage:  cause.Error(),
+		DeliveryCount: int32(deliveries),
+	}); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
@@ -3,28 +3,74 @@ package infrastructure
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
 
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
 	"github.com/ponyo877/prime-checker/internal/shared/message"
 )
 
+const (
+	// Interval between in-progress acks for a message that is still being handled,
+	// well below the default JetStream AckWait of 30 seconds
+	inProgressInterval = 10 * time.Second
+	// Deliveries of a message whose handler keeps failing before it is moved to the dead letter queue
+	maxDeliveries = 5
+	// Delay before a failed message is redelivered, doubled at every further delivery up to redeliveryMaxDelay
+	redeliveryBaseDelay = time.Second
+	redeliveryMaxDelay  = time.Minute
+	// Longest a fetch waits for messages before the subscription checks for shutdown again
+	fetchMaxWait = time.Second
+	// Longest a failed message may take to be saved to the dead letter queue
+	deadLetterSaveTimeout = 5 * time.Second
+)
+
 type MessagingConfig struct {
 	Host string
 	Port string
 }
 
+// SubscriptionConfig bounds how many messages a subscription takes from
+// JetStream at once and handles concurrently, and how long its handlers may
+// run on after shutdown begins.
+type SubscriptionConfig struct {
+	// Messages requested per fetch, fewer while handlers are busy
+	FetchBatchSize int
+	// Messages handled at once, which is also the MaxAckPending of the consumer,
+	// so that JetStream never hands out more than the handlers take
+	MaxInFlight int
+	// Time in-flight handlers get to finish after shutdown begins, before their
+	// context is cancelled and the messages they fail on are redelivered
+	ShutdownTimeout time.Duration
+}
+
+// DefaultSubscriptionConfig handles a few messages at once, and lets in-flight
+// handlers finish within the 10 seconds Docker waits after SIGTERM.
+func DefaultSubscriptionConfig() SubscriptionConfig {
+	return SubscriptionConfig{
+		FetchBatchSize:  4,
+		MaxInFlight:     4,
+		ShutdownTimeout: 8 * time.Second,
+	}
+}
+
 type MessageBroker interface {
 	Publish(ctx context.Context, subject string, msg *message.Message) error
-	Subscribe(ctx context.Context, subject string, handler MessageHandler) error
+	Subscribe(ctx context.Context, subject string, config SubscriptionConfig, handler MessageHandler) error
+	Watch(ctx context.Context, subject string, afterSequence uint64, handler WatchHandler) error
+	LastSequence(ctx context.Context, subject string) (uint64, error)
 	Close() error
 }
 
-func NewMessageBroker(config MessagingConfig) (MessageBroker, error) {
-	natsBroker, err := newNATSBroker(config.Host, config.Port)
+// NewMessageBroker connects to NATS. Subscribers move a message their handler
+// keeps failing on to deadLetters, or redeliver it forever when deadLetters is nil.
+func NewMessageBroker(config MessagingConfig, deadLetters DeadLetterWriter) (MessageBroker, error) {
+	natsBroker, err := newNATSBroker(config.Host, config.Port, deadLetters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
 	}
@@ -33,13 +79,17 @@ func NewMessageBroker(config MessagingConfig) (MessageBroker, error) {
 }
 
 type NATSBroker struct {
-	conn *nats.Conn
-	js   nats.JetStreamContext
+	conn        *nats.Conn
+	js          nats.JetStreamContext
+	deadLetters DeadLetterWriter
 }
 
 type MessageHandler func(ctx context.Context, msg *message.Message) error
 
-func newNATSBroker(host, port string) (*NATSBroker, error) {
+// WatchHandler receives a watched message with its sequence in the stream.
+type WatchHandler func(ctx context.Context, sequence uint64, msg *message.Message) error
+
+func newNATSBroker(host, port string, deadLetters DeadLetterWriter) (*NATSBroker, error) {
 	url := fmt.Sprintf("nats://%s:%s", host, port)
 	conn, err := nats.Connect(url)
 	if err != nil {
@@ -54,8 +104,9 @@ func newNATSBroker(host, port string) (*NATSBroker, error) {
 	}
 
 	return &NATSBroker{
-		conn: conn,
-		js:   js,
+		conn:        conn,
+		js:          js,
+		deadLetters: deadLetters,
 	}, nil
 }
 
@@ -78,7 +129,16 @@ func (n *NATSBroker) Publish(ctx context.Context, subject string, msg *message.M
 	return nil
 }
 
-func (n *NATSBroker) Subscribe(ctx context.Context, subject string, handler MessageHandler) error {
+// Subscribe passes the messages of subject to handler, up to
+// config.MaxInFlight of them at once, until ctx is done. Shutdown is ordered:
+// no more messages are fetched, the handlers in flight get
+// config.ShutdownTimeout to finish, and every message is acknowledged or
+// redelivered by the time Subscribe returns.
+func (n *NATSBroker) Subscribe(ctx context.Context, subject string, config SubscriptionConfig, handler MessageHandler) error {
+	if config.FetchBatchSize < 1 || config.MaxInFlight < 1 {
+		return fmt.Errorf("invalid subscription config: fetch batch size %d and max in flight %d must be positive", config.FetchBatchSize, config.MaxInFlight)
+	}
+
 	// Ensure stream exists
 	if err := n.ensureStream(subject); err != nil {
 		return fmt.Errorf("failed to ensure stream: %w", err)
@@ -86,40 +146,175 @@ func (n *NATSBroker) Subscribe(ctx context.Context, subject string, handler Mess
 
 	// Create durable consumer
 	consumerName := fmt.Sprintf("%s_consumer", subject)
+	if err := n.ensureConsumer(subject, consumerName, config.MaxInFlight); err != nil {
+		return fmt.Errorf("failed to ensure consumer: %w", err)
+	}
 
-	sub, err := n.js.PullSubscribe(subject, consumerName)
+	sub, err := n.js.PullSubscribe(subject, consumerName, nats.Bind(fmt.Sprintf("%s_stream", subject), consumerName))
 	if err != nil {
 		return fmt.Errorf("failed to create pull subscription: %w", err)
 	}
+	defer sub.Unsubscribe()
+
+	// Handlers outlive ctx, so that shutdown lets them finish rather than interrupting them
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	// A slot per message in flight
+	slots := make(chan struct{}, config.MaxInFlight)
+	var inFlight sync.WaitGroup
+
+	for {
+		free := acquireSlots(ctx, slots, config.FetchBatchSize)
+		if free == 0 {
+			break
+		}
+
+		msgs, err := sub.Fetch(free, nats.MaxWait(fetchMaxWait))
+		// Release the slots of the messages that did not come
+		for range free - len(msgs) {
+			<-slots
+		}
+		if err != nil && !errors.Is(err, nats.ErrTimeout) {
+			log.Printf("Error fetching messages: %v", err)
+		}
+
+		for _, natsMsg := range msgs {
+			inFlight.Add(1)
+			go func() {
+				defer inFlight.Done()
+				defer func() { <-slots }()
+				n.handleMessage(handlerCtx, subject, natsMsg, handler)
+			}()
+		}
+	}
+
+	finishInFlight(&inFlight, config.ShutdownTimeout, cancelHandlers)
+
+	// Send the acks of the finished handlers before the connection closes
+	if err := n.conn.Flush(); err != nil {
+		log.Printf("Failed to flush acks: %v", err)
+	}
+	return ctx.Err()
+}
+
+// acquireSlots waits for a free slot and then takes as many more as are free,
+// up to batchSize in all, and returns how many it took. Once ctx is done it
+// takes none and returns 0.
+func acquireSlots(ctx context.Context, slots chan struct{}, batchSize int) int {
+	if ctx.Err() != nil {
+		return 0
+	}
+	select {
+	case <-ctx.Done():
+		return 0
+	case slots <- struct{}{}:
+	}
+
+	taken := 1
+	for taken < batchSize {
+		select {
+		case slots <- struct{}{}:
+			taken++
+		default:
+			return taken
+		}
+	}
+	return taken
+}
+
+// finishInFlight waits for the handlers in flight, cancelling their context
+// once timeout has passed so that they give up and their messages are redelivered.
+func finishInFlight(inFlight *sync.WaitGroup, timeout time.Duration, cancelHandlers context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-time.After(timeout):
+		log.Printf("Handlers still running %v after shutdown began, cancelling them", timeout)
+		cancelHandlers()
+		<-done
+	}
+}
+
+// handleMessage runs handler on a message, and acknowledges the message once
+// the handler succeeded or redelivers it once the handler failed.
+func (n *NATSBroker) handleMessage(ctx context.Context, subject string, natsMsg *nats.Msg, handler MessageHandler) {
+	stop := keepInProgress(natsMsg)
+	err := n.processMessage(ctx, natsMsg, handler)
+	stop()
+	if err != nil {
+		log.Printf("Error processing message: %v", err)
+		n.handleFailure(ctx, subject, natsMsg, err)
+	} else {
+		natsMsg.Ack()
+	}
+}
+
+// Watch passes every message of subject after afterSequence, and then every
+// new one, to handler until ctx is done or handler fails. Unlike Subscribe,
+// every watcher sees every message, and nothing is acknowledged, so watching
+// never takes a message away from its subscribers.
+func (n *NATSBroker) Watch(ctx context.Context, subject string, afterSequence uint64, handler WatchHandler) error {
+	// Ensure stream exists
+	if err := n.ensureStream(subject); err != nil {
+		return fmt.Errorf("failed to ensure stream: %w", err)
+	}
+
+	// An ordered consumer is ephemeral and recreated from the last sequence it delivered after a gap
+	natsMsgs := make(chan *nats.Msg, 64)
+	sub, err := n.js.ChanSubscribe(subject, natsMsgs, nats.OrderedConsumer(), nats.StartSequence(afterSequence+1))
+	if err != nil {
+		return fmt.Errorf("failed to create watch subscription: %w", err)
+	}
+	defer sub.Unsubscribe()
 
-	// Process messages
 	for {
 		select {
 		case <-ctx.Done():
-			sub.Unsubscribe()
 			return ctx.Err()
-		default:
-			msgs, err := sub.Fetch(1, nats.MaxWait(time.Second))
+		case natsMsg := <-natsMsgs:
+			meta, err := natsMsg.Metadata()
 			if err != nil {
-				if err == nats.ErrTimeout {
-					continue
-				}
-				log.Printf("Error fetching messages: %v", err)
+				log.Printf("Error reading watched message metadata: %v", err)
 				continue
 			}
 
-			for _, natsMsg := range msgs {
-				if err := n.processMessage(ctx, natsMsg, handler); err != nil {
-					log.Printf("Error processing message: %v", err)
-					natsMsg.Nak()
-				} else {
-					natsMsg.Ack()
-				}
+			var msg message.Message
+			if err := json.Unmarshal(natsMsg.Data, &msg); err != nil {
+				log.Printf("Error unmarshaling watched message %d: %v", meta.Sequence.Stream, err)
+				continue
+			}
+			msg.ID = fmt.Sprintf("%s-%d", meta.Stream, meta.Sequence.Stream)
+
+			if err := handler(ctx, meta.Sequence.Stream, &msg); err != nil {
+				return err
 			}
 		}
 	}
 }
 
+// LastSequence returns the sequence of the last message published on subject,
+// 0 when there is none, from which Watch can follow only newer messages.
+func (n *NATSBroker) LastSequence(ctx context.Context, subject string) (uint64, error) {
+	// Ensure stream exists
+	if err := n.ensureStream(subject); err != nil {
+		return 0, fmt.Errorf("failed to ensure stream: %w", err)
+	}
+
+	info, err := n.js.StreamInfo(fmt.Sprintf("%s_stream", subject), nats.Context(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stream info: %w", err)
+	}
+
+	return info.State.LastSeq, nil
+}
+
 func (n *NATSBroker) processMessage(ctx context.Context, natsMsg *nats.Msg, handler MessageHandler) error {
 	var msg message.Message
 	if err := json.Unmarshal(natsMsg.Data, &msg); err != nil {
@@ -134,6 +329,75 @@ func (n *NATSBroker) processMessage(ctx context.Context, natsMsg *nats.Msg, hand
 	return handler(ctx, &msg)
 }
 
+// handleFailure redelivers a message whose handler failed, after a delay that
+// grows with every delivery, until it has been delivered maxDeliveries times,
+// and then moves it to the dead letter queue. A message that failed on an
+// injected fault is never moved there, since the fault is only lifted by
+// hand, and neither is one the dead letter queue does not take. The save
+// outlives a cancelled ctx, since a handler often fails because shutdown
+// cancelled it, but is bounded by deadLetterSaveTimeout.
+func (n *NATSBroker) handleFailure(ctx context.Context, subject string, natsMsg *nats.Msg, cause error) {
+	meta, err := natsMsg.Metadata()
+	if err != nil {
+		natsMsg.NakWithDelay(redeliveryBaseDelay)
+		return
+	}
+	if n.deadLetters == nil || errors.Is(cause, faultinjection.ErrInjected) || meta.NumDelivered < maxDeliveries {
+		natsMsg.NakWithDelay(redeliveryDelay(meta.NumDelivered))
+		return
+	}
+
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterSaveTimeout)
+	defer cancel()
+
+	messageID := fmt.Sprintf("%s-%d", meta.Stream, meta.Sequence.Stream)
+	if err := n.deadLetters.Save(saveCtx, subject, messageID, natsMsg.Data, int(meta.NumDelivered), cause); err != nil {
+		log.Printf("Failed to save message %s to the dead letter queue: %v", messageID, err)
+		natsMsg.NakWithDelay(redeliveryDelay(meta.NumDelivered))
+		return
+	}
+
+	log.Printf("Moved message %s to the dead letter queue after %d deliveries", messageID, meta.NumDelivered)
+	natsMsg.Term()
+}
+
+// redeliveryDelay returns how long a message that failed on its deliveries-th
+// delivery waits before it is delivered again.
+func redeliveryDelay(deliveries uint64) time.Duration {
+	delay := redeliveryBaseDelay
+	for i := uint64(1); i < deliveries && delay < redeliveryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, redeliveryMaxDelay)
+}
+
+// keepInProgress resets the ack timer of msg every inProgressInterval so that
+// JetStream does not redeliver it while a long handler is still running. The
+// returned function stops the timer loop.
+func keepInProgress(msg *nats.Msg) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(inProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := msg.InProgress(); err != nil {
+					log.Printf("Failed to send in-progress ack: %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
 func (n *NATSBroker) ensureStream(subject string) error {
 	streamName := fmt.Sprintf("%s_stream", subject)
 
@@ -157,6 +421,34 @@ func (n *NATSBroker) ensureStream(subject string) error {
 	return nil
 }
 
+// ensureConsumer creates the durable consumer of a subscription, or updates
+// it, so that JetStream keeps at most maxAckPending of its messages
+// unacknowledged.
+func (n *NATSBroker) ensureConsumer(subject, consumerName string, maxAckPending int) error {
+	streamName := fmt.Sprintf("%s_stream", subject)
+
+	info, err := n.js.ConsumerInfo(streamName, consumerName)
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		_, err = n.js.AddConsumer(streamName, &nats.ConsumerConfig{
+			Durable:       consumerName,
+			FilterSubject: subject,
+			AckPolicy:     nats.AckExplicitPolicy,
+			MaxAckPending: maxAckPending,
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if info.Config.MaxAckPending != maxAckPending {
+		config := info.Config
+		config.MaxAckPending = maxAckPending
+		_, err = n.js.UpdateConsumer(streamName, &config)
+	}
+	return err
+}
+
 func (n *NATSBroker) Close() error {
 	if n.conn != nil {
 		n.conn.Close()
@@ -13,8 +13,14 @@ import (
 type MessageType string
 
 const (
-	MessageTypePrimeCheck MessageType = "prime_check"
-	MessageTypeEmailSend  MessageType = "email_send"
+	MessageTypePrimeCheck    MessageType = "prime_check"
+	MessageTypeEmailSend     MessageType = "email_send"
+	MessageTypeFactorization MessageType = "factorization"
+	MessageTypePrimeRange    MessageType = "prime_range"
+	// Status changes of prime checks, which the web server streams to clients
+	MessageTypePrimeCheckResult MessageType = "prime_check_result"
+	// Results of prime checks to post to the callback URL their user gave
+	MessageTypeWebhookDelivery MessageType = "webhook_delivery"
 )
 
 type Message struct {
@@ -26,11 +32,50 @@ type Message struct {
 }
 
 type PrimeCheckPayload struct {
+	RequestID      int32  `json:"request_id"`
+	UserID         int32  `json:"user_id"`
+	NumberText     string `json:"number_text"`
+	Operation      string `json:"operation,omitempty"`
+	Accuracy       string `json:"accuracy,omitempty"`
+	Algorithm      string `json:"algorithm,omitempty"`
+	TimeoutSeconds int32  `json:"timeout_seconds,omitempty"`
+	Certify        bool   `json:"certify,omitempty"`
+	CallbackURL    string `json:"callback_url,omitempty"`
+	Attempt        int32  `json:"attempt,omitempty"`
+}
+
+type FactorizationPayload struct {
 	RequestID  int32  `json:"request_id"`
 	UserID     int32  `json:"user_id"`
 	NumberText string `json:"number_text"`
 }
 
+type PrimeRangePayload struct {
+	RangeID   int32  `json:"range_id"`
+	UserID    int32  `json:"user_id"`
+	Start     uint64 `json:"start"`
+	End       uint64 `json:"end"`
+	CountOnly bool   `json:"count_only,omitempty"`
+}
+
+type PrimeCheckResultPayload struct {
+	RequestID int32     `json:"request_id"`
+	UserID    int32     `json:"user_id"`
+	Status    string    `json:"status"`
+	IsPrime   *bool     `json:"is_prime,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryPayload struct {
+	RequestID   int32     `json:"request_id"`
+	UserID      int32     `json:"user_id"`
+	CallbackURL string    `json:"callback_url"`
+	NumberText  string    `json:"number_text"`
+	Status      string    `json:"status"`
+	IsPrime     *bool     `json:"is_prime,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 type EmailSendPayload struct {
 	RequestID  int32  `json:"request_id"`
 	UserID     int32  `json:"user_id"`
@@ -93,6 +138,38 @@ func (m *Message) UnmarshalEmailSendPayload() (*EmailSendPayload, error) {
 	return &payload, nil
 }
 
+func (m *Message) UnmarshalFactorizationPayload() (*FactorizationPayload, error) {
+	var payload FactorizationPayload
+	if err := json.Unmarshal(m.Payload, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+func (m *Message) UnmarshalPrimeRangePayload() (*PrimeRangePayload, error) {
+	var payload PrimeRangePayload
+	if err := json.Unmarshal(m.Payload, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+func (m *Message) UnmarshalPrimeCheckResultPayload() (*PrimeCheckResultPayload, error) {
+	var payload PrimeCheckResultPayload
+	if err := json.Unmarshal(m.Payload, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+func (m *Message) UnmarshalWebhookDeliveryPayload() (*WebhookDeliveryPayload, error) {
+	var payload WebhookDeliveryPayload
+	if err := json.Unmarshal(m.Payload, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
 func (m *Message) ExtractTraceContext(ctx context.Context) context.Context {
 	if len(m.TraceContext) == 0 {
 		return ctx
@@ -0,0 +1,20 @@
+// Package numberhash keys stored numbers by a fixed-size hash, so that
+// arbitrarily long numbers can be looked up through an index.
+package numberhash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+)
+
+// Sum returns the hex SHA-256 of the canonical decimal form of numberText,
+// so that 007 and 7 share a hash. Text that is not a decimal number is
+// hashed as it is.
+func Sum(numberText string) string {
+	if n, ok := new(big.Int).SetString(numberText, 10); ok {
+		numberText = n.String()
+	}
+	sum := sha256.Sum256([]byte(numberText))
+	return hex.EncodeToString(sum[:])
+}
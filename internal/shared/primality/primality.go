@@ -0,0 +1,73 @@
+// Package primality is what the web server and the prime check worker have to
+// agree on about the primality tests: the bounds below which a test decides a
+// number, and the special forms of the tests that only decide numbers of their
+// form, and the time a test may take. The web server accepts a test asked for
+// by name only where the worker can run it.
+package primality
+
+import (
+	"math/big"
+	"time"
+)
+
+const (
+	// Upper bound (exclusive) of the small prime table used for trial division
+	SmallPrimeLimit = 1 << 16
+	// Largest number AKS is run on. The test is polynomial but far slower than
+	// every other test, so it is only offered for demonstrations.
+	AKSMaxBits = 20
+
+	// Longest time budget a prime check may ask for, and the budget of one that
+	// asks for none. A check out of budget is saved as timed out.
+	MaxTimeout = 5 * time.Minute
+)
+
+var (
+	// Numbers below this bound can be proven prime by trial division with the small prime table
+	TrialDivisionLimit = new(big.Int).SetUint64(SmallPrimeLimit * SmallPrimeLimit)
+
+	// Miller-Rabin with the first 13 primes as bases is deterministic below this
+	// bound (Sorenson and Webster, 2015)
+	DeterministicMillerRabinBound, _ = new(big.Int).SetString("3317044064679887385961981", 10)
+)
+
+// MersenneExponent returns p when n = 2^p - 1 with p >= 3.
+func MersenneExponent(n *big.Int) (int, bool) {
+	p, isPower := PowerOfTwoExponent(new(big.Int).Add(n, big.NewInt(1)))
+	return p, isPower && p >= 3
+}
+
+// IsMersenneNumber reports whether n = 2^p - 1 with p >= 3.
+func IsMersenneNumber(n *big.Int) bool {
+	_, ok := MersenneExponent(n)
+	return ok
+}
+
+// IsFermatNumber reports whether n = 2^e + 1 with e >= 2 itself a power of two.
+func IsFermatNumber(n *big.Int) bool {
+	e, isPower := PowerOfTwoExponent(new(big.Int).Sub(n, big.NewInt(1)))
+	return isPower && e >= 2 && e&(e-1) == 0
+}
+
+// IsProthNumber reports whether n = k*2^m + 1 with odd k < 2^m.
+func IsProthNumber(n *big.Int) bool {
+	if n.Cmp(big.NewInt(3)) < 0 {
+		return false
+	}
+	nm1 := new(big.Int).Sub(n, big.NewInt(1))
+	m := nm1.TrailingZeroBits()
+	if m == 0 {
+		return false
+	}
+	k := new(big.Int).Rsh(nm1, m)
+	return uint(k.BitLen()) <= m
+}
+
+// PowerOfTwoExponent returns e when v = 2^e.
+func PowerOfTwoExponent(v *big.Int) (int, bool) {
+	if v.Sign() <= 0 {
+		return 0, false
+	}
+	e := v.TrailingZeroBits()
+	return int(e), uint(v.BitLen()) == e+1
+}
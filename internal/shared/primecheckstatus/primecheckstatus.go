@@ -0,0 +1,167 @@
+// Package primecheckstatus is the life cycle of a prime check: the statuses
+// it goes through, the transitions allowed between them, and the history of
+// transitions every service appends to when it moves a prime check on.
+//
+// A prime check is queued when it is stored, dispatched once the outbox
+// publisher hands it to the broker, and computing once a prime check worker
+// takes it. It ends completed, failed, timed out or cancelled, and a retry
+// queues an unsuccessful one again:
+//
+//	queued → dispatched → computing → completed | failed | timed_out
+//	queued | dispatched | computing → cancelled
+//	failed | timed_out | cancelled → queued
+//
+// Services change the status with conditional UPDATEs, which only apply to the
+// status the transition starts from, so that concurrent changes cannot skip or
+// undo a transition.
+package primecheckstatus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+)
+
+type Status string
+
+const (
+	Queued     Status = "queued"
+	Dispatched Status = "dispatched"
+	Computing  Status = "computing"
+	Completed  Status = "completed"
+	Failed     Status = "failed"
+	TimedOut   Status = "timed_out"
+	Cancelled  Status = "cancelled"
+)
+
+// Statuses a prime check may move on to from each status
+var transitions = map[Status][]Status{
+	Queued:     {Dispatched, Cancelled},
+	Dispatched: {Computing, Cancelled},
+	Computing:  {Completed, Failed, TimedOut, Cancelled},
+	Failed:     {Queued},
+	TimedOut:   {Queued},
+	Cancelled:  {Queued},
+}
+
+// Actor is the service that moves a prime check on, recorded with every transition.
+type Actor string
+
+const (
+	ActorWebServer        Actor = "web-server"
+	ActorOutboxPublisher  Actor = "outbox-publisher"
+	ActorPrimeCheckWorker Actor = "prime-check-worker"
+)
+
+var (
+	// ErrInvalidTransition is returned for a transition the life cycle does not allow.
+	ErrInvalidTransition = errors.New("invalid prime check status transition")
+	// ErrStatusChanged is returned when the status changed before a conditional UPDATE applied.
+	ErrStatusChanged = errors.New("prime check status changed concurrently")
+)
+
+// IsValid reports whether s is one of the statuses above.
+func (s Status) IsValid() bool {
+	_, ok := transitions[s]
+	return ok || s == Completed
+}
+
+// IsFinal reports whether a prime check with the status is done, for good or
+// until it is retried.
+func (s Status) IsFinal() bool {
+	switch s {
+	case Completed, Failed, TimedOut, Cancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether the life cycle allows moving from s to next.
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransition returns ErrInvalidTransition unless from may move on to to.
+func CheckTransition(from, to Status) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: from %s to %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// Lock returns the status of a prime check and locks its row until the end
+// of the transaction queries run in, so that the status stays as it is read.
+func Lock(ctx context.Context, queries *generated_sql.Queries, id int32) (Status, error) {
+	status, err := queries.LockPrimeCheckStatus(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return Status(status.String), nil
+}
+
+// Transition moves a prime check from one status to another and records the
+// transition. Run it in a transaction, so that neither happens without the
+// other.
+func Transition(ctx context.Context, queries *generated_sql.Queries, id int32, from, to Status, actor Actor) error {
+	if err := CheckTransition(from, to); err != nil {
+		return err
+	}
+
+	result, err := queries.UpdatePrimeCheckStatus(ctx, generated_sql.UpdatePrimeCheckStatusParams{
+		Status:     sql.NullString{String: string(to), Valid: true},
+		ID:         id,
+		FromStatus: sql.NullString{String: string(from), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if err := CheckUpdated(result); err != nil {
+		return err
+	}
+
+	return Record(ctx, queries, id, from, to, actor)
+}
+
+// CheckUpdated returns ErrStatusChanged for a conditional UPDATE that matched
+// no row, because the prime check had already left the status it expected.
+func CheckUpdated(result sql.Result) error {
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrStatusChanged
+	}
+	return nil
+}
+
+// Record appends a transition to the history of a prime check, with the trace
+// of ctx. An empty from records the status a prime check was created with.
+func Record(ctx context.Context, queries *generated_sql.Queries, id int32, from, to Status, actor Actor) error {
+	return queries.CreatePrimeCheckEvent(ctx, generated_sql.CreatePrimeCheckEventParams{
+		PrimeCheckID: id,
+		FromStatus:   sql.NullString{String: string(from), Valid: from != ""},
+		ToStatus:     string(to),
+		Actor:        string(actor),
+		TraceID:      TraceID(ctx),
+	})
+}
+
+// TraceID returns the trace of ctx as recorded with a transition, NULL when
+// ctx carries none.
+func TraceID(ctx context.Context) sql.NullString {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		return sql.NullString{String: spanContext.TraceID().String(), Valid: true}
+	}
+	return sql.NullString{}
+}
@@ -0,0 +1,108 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+	"github.com/ponyo877/prime-checker/openapi"
+)
+
+type userContextKey struct{}
+
+// HandleBearerAuth authenticates every operation but registration by the auth
+// token of a user, and passes the user on to the handler.
+func (h *handler) HandleBearerAuth(ctx context.Context, operationName openapi.OperationName, t openapi.BearerAuth) (context.Context, error) {
+	user, err := h.usecase.AuthenticateUser(ctx, t.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("user_id", int(user.ID())))
+	return context.WithValue(ctx, userContextKey{}, user), nil
+}
+
+// userFromContext returns the user HandleBearerAuth authenticated.
+func userFromContext(ctx context.Context) *model.User {
+	user, _ := ctx.Value(userContextKey{}).(*model.User)
+	return user
+}
+
+func (h *handler) UsersRegister(ctx context.Context) (*openapi.UserToken, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "UsersRegister")
+	defer span.End()
+
+	user, token, webhookSecret, err := h.usecase.RegisterUser(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("user_id", int(user.ID())))
+
+	return &openapi.UserToken{
+		UserID:        user.ID(),
+		Token:         token,
+		WebhookSecret: convertStringToOptString(webhookSecret),
+	}, nil
+}
+
+func (h *handler) UsersRotateToken(ctx context.Context) (*openapi.UserToken, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "UsersRotateToken")
+	defer span.End()
+
+	userID := userFromContext(ctx).ID()
+	span.SetAttributes(attribute.Int("user_id", int(userID)))
+
+	token, err := h.usecase.RotateUserToken(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return &openapi.UserToken{
+		UserID: userID,
+		Token:  token,
+	}, nil
+}
+
+func (h *handler) UsersRotateWebhookSecret(ctx context.Context) (*openapi.WebhookSecret, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "UsersRotateWebhookSecret")
+	defer span.End()
+
+	userID := userFromContext(ctx).ID()
+	span.SetAttributes(attribute.Int("user_id", int(userID)))
+
+	webhookSecret, err := h.usecase.RotateWebhookSecret(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return &openapi.WebhookSecret{
+		WebhookSecret: webhookSecret,
+	}, nil
+}
+
+// HandleError writes the errors the generated server raises before a handler
+// runs, such as a missing auth token or an undecodable request, in the same
+// format as the errors of the handlers.
+func (h *handler) HandleError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	resp := h.NewError(ctx, err)
+	body, marshalErr := resp.Response.MarshalJSON()
+	if marshalErr != nil {
+		http.Error(w, err.Error(), resp.StatusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(body)
+}
@@ -2,12 +2,18 @@ package adapter
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/ogen-go/ogen/ogenerrors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/ponyo877/prime-checker/internal/web/model"
 	"github.com/ponyo877/prime-checker/internal/web/usecase"
 	"github.com/ponyo877/prime-checker/openapi"
 )
@@ -20,7 +26,7 @@ func NewHandler(uc *usecase.Usecase) *handler {
 	return &handler{usecase: uc}
 }
 
-func (h *handler) PrimeChecksCreate(ctx context.Context, req *openapi.PrimeCheckInput) (r *openapi.PrimeCheck, _ error) {
+func (h *handler) PrimeChecksCreate(ctx context.Context, req *openapi.PrimeCheckInput, params openapi.PrimeChecksCreateParams) (r *openapi.PrimeCheck, _ error) {
 	tracer := otel.Tracer("web-server")
 	ctx, span := tracer.Start(ctx, "PrimeChecksCreate")
 	defer span.End()
@@ -28,60 +34,121 @@ func (h *handler) PrimeChecksCreate(ctx context.Context, req *openapi.PrimeCheck
 	traceID := span.SpanContext().TraceID().String()
 	log.Printf("Processing request with Trace ID: %s", traceID)
 
+	operation := req.Operation.Or(openapi.PrimeCheckInputOperationIsPrime)
+	accuracy := req.Accuracy.Or(openapi.AccuracyStandard)
+	algorithm := req.Algorithm.Or("")
 	span.SetAttributes(
 		attribute.String("number", req.Number),
 		attribute.String("operation", "create_prime_check"),
+		attribute.String("prime_operation", string(operation)),
+		attribute.String("accuracy", string(accuracy)),
+		attribute.String("algorithm", string(algorithm)),
+		attribute.Bool("certify", req.Certify.Or(false)),
+		attribute.Bool("callback", req.CallbackURL.IsSet()),
+		attribute.Int("timeout_seconds", int(req.TimeoutSeconds.Or(0))),
+		attribute.Bool("idempotent", params.IdempotencyKey.IsSet()),
 	)
 
-	// TODO: Replace with actual user ID retrieval logic
-	userID := int32(1)
+	userID := userFromContext(ctx).ID()
 
-	test, err := h.usecase.CreatePrimeCheckWithMessage(ctx, userID, req.Number)
+	test, replayed, err := h.usecase.CreatePrimeCheckWithMessage(ctx, userID, params.IdempotencyKey.Or(""), req.Number, string(operation), string(accuracy), string(algorithm), req.CallbackURL.Or(""), convertOptInt32ToPtr(req.TimeoutSeconds), req.Certify.Or(false), req.BypassCache.Or(false))
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
 	}
 
-	span.SetAttributes(attribute.Int("request_id", int(test.ID())))
+	span.SetAttributes(
+		attribute.Int("request_id", int(test.ID())),
+		attribute.Bool("replayed", replayed),
+	)
 
-	// Set trace ID for the created prime check
-	test.SetTraceID(traceID)
-	test.SetStatus("processing")
+	// Set trace ID for the created prime check; a replayed one shows the trace
+	// the worker stored with its result, which is empty before the result of
+	// its latest attempt is in
+	if !replayed {
+		test.SetTraceID(traceID)
+	}
 
-	return &openapi.PrimeCheck{
-		ID:        test.ID(),
-		Number:    test.NumberText(),
-		CreatedAt: test.CreatedAt(),
-		TraceID:   convertStringPtrToOptString(test.TraceID()),
-		MessageID: convertStringPtrToOptString(test.MessageID()),
-		IsPrime:   convertBoolPtrToOptBool(test.IsPrime()),
-		Status:    convertStringPtrToOptString(test.Status()),
-	}, nil
+	check := convertPrimeCheck(test)
+	return &check, nil
 }
 
 func (h *handler) PrimeChecksGet(ctx context.Context, params openapi.PrimeChecksGetParams) (r *openapi.PrimeCheck, _ error) {
-	test, err := h.usecase.GetPrimeCheck(ctx, params.RequestID)
+	test, err := h.usecase.WaitPrimeCheck(ctx, userFromContext(ctx), params.RequestID, params.Wait.Or(0))
 	if err != nil {
 		return nil, err
 	}
 
-	return &openapi.PrimeCheck{
-		ID:        test.ID(),
-		Number:    test.NumberText(),
-		CreatedAt: test.CreatedAt(),
-		TraceID:   convertStringPtrToOptString(test.TraceID()),
-		MessageID: convertStringPtrToOptString(test.MessageID()),
-		IsPrime:   convertBoolPtrToOptBool(test.IsPrime()),
-		Status:    convertStringPtrToOptString(test.Status()),
-	}, nil
+	var factors []openapi.PrimeFactor
+	for _, factor := range test.Factors() {
+		factors = append(factors, openapi.PrimeFactor{
+			Prime:    factor.Prime,
+			Exponent: factor.Exponent,
+		})
+	}
+
+	check := convertPrimeCheck(test)
+	check.Factors = factors
+	check.Unfactored = convertStringPtrToOptString(test.Unfactored())
+	return &check, nil
+}
+
+func (h *handler) PrimeChecksCancel(ctx context.Context, params openapi.PrimeChecksCancelParams) (r *openapi.PrimeCheck, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksCancel")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("request_id", int(params.RequestID)))
+
+	test, err := h.usecase.CancelPrimeCheck(ctx, userFromContext(ctx), params.RequestID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	check := convertPrimeCheck(test)
+	return &check, nil
 }
 
-func (h *handler) PrimeChecksList(ctx context.Context) (r *openapi.PrimeCheckList, _ error) {
+func (h *handler) PrimeChecksRetry(ctx context.Context, params openapi.PrimeChecksRetryParams) (r *openapi.PrimeCheck, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksRetry")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("request_id", int(params.RequestID)))
+
+	test, err := h.usecase.RetryPrimeCheck(ctx, userFromContext(ctx), params.RequestID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	check := convertPrimeCheck(test)
+	return &check, nil
+}
+
+func (h *handler) PrimeChecksList(ctx context.Context, params openapi.PrimeChecksListParams) (r *openapi.PrimeCheckList, _ error) {
 	tracer := otel.Tracer("web-server")
 	ctx, span := tracer.Start(ctx, "PrimeChecksList")
 	defer span.End()
 
-	tests, err := h.usecase.ListPrimeChecks(ctx)
+	filter := model.PrimeCheckFilter{
+		Status:       convertOptStringToPtr(params.Status),
+		IsPrime:      convertOptBoolToPtr(params.IsPrime),
+		CreatedFrom:  convertOptDateTimeToPtr(params.CreatedFrom),
+		CreatedTo:    convertOptDateTimeToPtr(params.CreatedTo),
+		MinDigits:    convertOptInt32ToPtr(params.MinDigits),
+		MaxDigits:    convertOptInt32ToPtr(params.MaxDigits),
+		NumberPrefix: convertOptStringToPtr(params.NumberPrefix),
+	}
+	order := params.Order.Or(openapi.SortOrderDesc)
+
+	span.SetAttributes(
+		attribute.String("order", string(order)),
+		attribute.Bool("has_cursor", params.Cursor.IsSet()),
+	)
+
+	tests, nextCursor, err := h.usecase.ListPrimeChecks(ctx, userFromContext(ctx), filter, string(order), params.Cursor.Or(""), params.Limit.Or(0))
 	if err != nil {
 		span.RecordError(err)
 		return nil, err
@@ -91,40 +158,466 @@ func (h *handler) PrimeChecksList(ctx context.Context) (r *openapi.PrimeCheckLis
 
 	items := make([]openapi.PrimeCheck, len(tests))
 	for i, test := range tests {
-		items[i] = openapi.PrimeCheck{
-			ID:        test.ID(),
-			Number:    test.NumberText(),
-			CreatedAt: test.CreatedAt(),
-			TraceID:   convertStringPtrToOptString(test.TraceID()),
-			MessageID: convertStringPtrToOptString(test.MessageID()),
-			IsPrime:   convertBoolPtrToOptBool(test.IsPrime()),
-			Status:    convertStringPtrToOptString(test.Status()),
+		items[i] = convertPrimeCheck(test)
+	}
+
+	return &openapi.PrimeCheckList{
+		Items:      items,
+		NextCursor: convertStringToOptString(nextCursor),
+	}, nil
+}
+
+func (h *handler) PrimeChecksGetCertificate(ctx context.Context, params openapi.PrimeChecksGetCertificateParams) (r *openapi.PrimeCertificate, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksGetCertificate")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("request_id", int(params.RequestID)))
+
+	certificate, err := h.usecase.GetPrimeCertificate(ctx, userFromContext(ctx), params.RequestID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	steps := make([]openapi.CertificateStep, len(certificate.Steps()))
+	for i, step := range certificate.Steps() {
+		steps[i] = openapi.CertificateStep{
+			Method:    step.Method,
+			N:         step.N,
+			Factors:   step.Factors,
+			Witnesses: step.Witnesses,
+			A:         convertStringToOptString(step.A),
+			B:         convertStringToOptString(step.B),
+			M:         convertStringToOptString(step.M),
+			Q:         convertStringToOptString(step.Q),
+			X:         convertStringToOptString(step.X),
+			Y:         convertStringToOptString(step.Y),
 		}
 	}
 
+	return &openapi.PrimeCertificate{
+		RequestID: certificate.RequestID(),
+		Number:    certificate.Number(),
+		Method:    certificate.Method(),
+		Steps:     steps,
+		CreatedAt: certificate.CreatedAt(),
+	}, nil
+}
+
+func (h *handler) PrimeChecksCreateBatch(ctx context.Context, req *openapi.PrimeCheckBatchInput) (r *openapi.PrimeCheckBatch, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksCreateBatch")
+	defer span.End()
+
+	operation := req.Operation.Or(openapi.PrimeCheckBatchInputOperationIsPrime)
+	accuracy := req.Accuracy.Or(openapi.AccuracyStandard)
+	span.SetAttributes(
+		attribute.Int("batch_size", len(req.Numbers)),
+		attribute.String("operation", "create_prime_check_batch"),
+		attribute.String("prime_operation", string(operation)),
+		attribute.String("accuracy", string(accuracy)),
+		attribute.Bool("certify", req.Certify.Or(false)),
+	)
+
+	userID := userFromContext(ctx).ID()
+
+	batch, err := h.usecase.CreatePrimeCheckBatchWithMessages(ctx, userID, req.Numbers, string(operation), string(accuracy), req.Certify.Or(false), req.BypassCache.Or(false))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("batch_id", int(batch.ID())))
+
+	return convertPrimeCheckBatch(batch), nil
+}
+
+func (h *handler) PrimeChecksGetBatch(ctx context.Context, params openapi.PrimeChecksGetBatchParams) (r *openapi.PrimeCheckBatch, _ error) {
+	batch, err := h.usecase.GetPrimeCheckBatch(ctx, userFromContext(ctx), params.BatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertPrimeCheckBatch(batch), nil
+}
+
+func (h *handler) PrimeChecksListBatchResults(ctx context.Context, params openapi.PrimeChecksListBatchResultsParams) (r *openapi.PrimeCheckList, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksListBatchResults")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("batch_id", int(params.BatchID)),
+		attribute.Bool("has_cursor", params.Cursor.IsSet()),
+	)
+
+	tests, nextCursor, err := h.usecase.ListPrimeCheckBatchResults(ctx, userFromContext(ctx), params.BatchID, params.Cursor.Or(""), params.Limit.Or(0))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("results_count", len(tests)))
+
+	items := make([]openapi.PrimeCheck, len(tests))
+	for i, test := range tests {
+		items[i] = convertPrimeCheck(test)
+	}
+
 	return &openapi.PrimeCheckList{
+		Items:      items,
+		NextCursor: convertStringToOptString(nextCursor),
+	}, nil
+}
+
+func (h *handler) PrimeChecksUploadBatch(ctx context.Context, req openapi.PrimeChecksUploadBatchReq, params openapi.PrimeChecksUploadBatchParams) (r *openapi.PrimeCheckBatch, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksUploadBatch")
+	defer span.End()
+
+	var format string
+	var file io.Reader
+	switch req := req.(type) {
+	case *openapi.PrimeChecksUploadBatchReqTextCsv:
+		format, file = model.PrimeCheckFileFormatCSV, req.Data
+	case *openapi.PrimeChecksUploadBatchReqApplicationXNdjson:
+		format, file = model.PrimeCheckFileFormatNDJSON, req.Data
+	}
+
+	operation := params.Operation.Or(openapi.PrimeOperationIsPrime)
+	accuracy := params.Accuracy.Or(openapi.AccuracyStandard)
+	span.SetAttributes(
+		attribute.String("format", format),
+		attribute.String("operation", "upload_prime_check_batch"),
+		attribute.String("prime_operation", string(operation)),
+		attribute.String("accuracy", string(accuracy)),
+		attribute.Bool("certify", params.Certify.Or(false)),
+	)
+
+	userID := userFromContext(ctx).ID()
+
+	batch, err := h.usecase.CreatePrimeCheckBatchFromFile(ctx, userID, format, file, string(operation), string(accuracy), params.Certify.Or(false), params.BypassCache.Or(false))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("batch_id", int(batch.ID())),
+		attribute.Int64("batch_size", batch.Total()),
+	)
+
+	return convertPrimeCheckBatch(batch), nil
+}
+
+// PrimeChecksDownloadBatch streams the result file while it is being written,
+// so that the rows of a large batch are never held in memory together.
+func (h *handler) PrimeChecksDownloadBatch(ctx context.Context, params openapi.PrimeChecksDownloadBatchParams) (r openapi.PrimeChecksDownloadBatchRes, _ error) {
+	batch, err := h.usecase.GetPrimeCheckBatch(ctx, userFromContext(ctx), params.BatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The response encoder closes the reader when it is done, which stops the writer
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(h.usecase.WritePrimeCheckBatchFile(ctx, batch, pw))
+	}()
+
+	if batch.FileFormat() == model.PrimeCheckFileFormatCSV {
+		return &openapi.PrimeChecksDownloadBatchOKTextCsv{Data: pr}, nil
+	}
+	return &openapi.PrimeChecksDownloadBatchOKApplicationXNdjson{Data: pr}, nil
+}
+
+func (h *handler) PrimeChecksListWebhookDeliveries(ctx context.Context, params openapi.PrimeChecksListWebhookDeliveriesParams) (r *openapi.WebhookDeliveryList, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksListWebhookDeliveries")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("request_id", int(params.RequestID)))
+
+	deliveries, err := h.usecase.ListWebhookDeliveries(ctx, userFromContext(ctx), params.RequestID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	items := make([]openapi.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = convertWebhookDelivery(delivery)
+	}
+
+	return &openapi.WebhookDeliveryList{
+		Items: items,
+	}, nil
+}
+
+func (h *handler) PrimeChecksListEvents(ctx context.Context, params openapi.PrimeChecksListEventsParams) (r *openapi.PrimeCheckEventList, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksListEvents")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("request_id", int(params.RequestID)))
+
+	events, err := h.usecase.ListPrimeCheckEvents(ctx, userFromContext(ctx), params.RequestID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	items := make([]openapi.PrimeCheckEvent, len(events))
+	for i, event := range events {
+		items[i] = convertPrimeCheckEvent(event)
+	}
+
+	return &openapi.PrimeCheckEventList{
 		Items: items,
 	}, nil
 }
 
+func (h *handler) PrimeRangesCreate(ctx context.Context, req *openapi.PrimeRangeInput) (r *openapi.PrimeRange, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeRangesCreate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("start", req.Start),
+		attribute.String("end", req.End),
+		attribute.Bool("count_only", req.CountOnly.Or(false)),
+	)
+
+	userID := userFromContext(ctx).ID()
+
+	primeRange, err := h.usecase.CreatePrimeRangeWithMessage(ctx, userID, req.Start, req.End, req.CountOnly.Or(false))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("range_id", int(primeRange.ID())))
+
+	return convertPrimeRange(primeRange), nil
+}
+
+func (h *handler) PrimeRangesGet(ctx context.Context, params openapi.PrimeRangesGetParams) (r *openapi.PrimeRange, _ error) {
+	primeRange, err := h.usecase.GetPrimeRange(ctx, userFromContext(ctx), params.RangeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertPrimeRange(primeRange), nil
+}
+
+func (h *handler) PrimeRangesListPrimes(ctx context.Context, params openapi.PrimeRangesListPrimesParams) (r *openapi.PrimeRangePrimes, _ error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeRangesListPrimes")
+	defer span.End()
+
+	offset := params.Offset.Or(0)
+	span.SetAttributes(
+		attribute.Int("range_id", int(params.RangeID)),
+		attribute.Int64("offset", offset),
+	)
+
+	primes, err := h.usecase.ListPrimeRangePrimes(ctx, userFromContext(ctx), params.RangeID, offset, params.Limit.Or(0))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("results_count", len(primes)))
+
+	items := make([]int64, len(primes))
+	for i, p := range primes {
+		items[i] = int64(p)
+	}
+
+	// An empty page means the end of the primes stored so far
+	var nextOffset openapi.OptInt64
+	if len(items) > 0 {
+		nextOffset = openapi.NewOptInt64(offset + int64(len(items)))
+	}
+
+	return &openapi.PrimeRangePrimes{
+		RangeID:    params.RangeID,
+		Offset:     offset,
+		Items:      items,
+		NextOffset: nextOffset,
+	}, nil
+}
+
 func (h *handler) SettingsCreate(ctx context.Context, req *openapi.Setting) (r *openapi.Setting, _ error) {
-	return nil, nil
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "SettingsCreate")
+	defer span.End()
+
+	setting := model.NewSetting(req.RecordNumberSuccess, req.PrimeCheckSuccess, req.EmailSendSuccess, req.DlqSaveSuccess)
+	setting, err := h.usecase.UpdateSetting(ctx, userFromContext(ctx), setting)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Bool("record_number_success", setting.RecordNumberSuccess()),
+		attribute.Bool("prime_check_success", setting.PrimeCheckSuccess()),
+		attribute.Bool("email_send_success", setting.EmailSendSuccess()),
+		attribute.Bool("dlq_save_success", setting.DLQSaveSuccess()),
+	)
+
+	return convertSetting(setting), nil
 }
 
 func (h *handler) SettingsGet(ctx context.Context) (r *openapi.Setting, _ error) {
-	return nil, nil
+	setting, err := h.usecase.GetSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSetting(setting), nil
 }
 
+func convertSetting(setting *model.Setting) *openapi.Setting {
+	return &openapi.Setting{
+		RecordNumberSuccess: setting.RecordNumberSuccess(),
+		PrimeCheckSuccess:   setting.PrimeCheckSuccess(),
+		EmailSendSuccess:    setting.EmailSendSuccess(),
+		DlqSaveSuccess:      setting.DLQSaveSuccess(),
+	}
+}
+
+// NewError reports a domain error with the status of its kind and its code.
+// Any other error is an internal one.
 func (h *handler) NewError(ctx context.Context, err error) *openapi.ErrorStatusCode {
+	statusCode, errorCode := http.StatusInternalServerError, "internal"
+	var domainErr *model.Error
+	var ogenErr ogenerrors.Error
+	switch {
+	case errors.As(err, &domainErr):
+		statusCode, errorCode = convertErrorKindToStatusCode(domainErr.Kind()), domainErr.Code()
+	case errors.As(err, &ogenErr):
+		// Raised by the generated server before a handler runs
+		statusCode, errorCode = ogenErr.Code(), "invalid_request"
+		if statusCode == http.StatusUnauthorized {
+			errorCode = model.ErrUnauthenticated.Code()
+		}
+	}
+
 	return &openapi.ErrorStatusCode{
-		StatusCode: http.StatusInternalServerError,
+		StatusCode: statusCode,
 		Response: openapi.Error{
-			Code:    500,
-			Message: err.Error(),
+			Code:      int32(statusCode),
+			ErrorCode: errorCode,
+			Message:   err.Error(),
 		},
 	}
 }
 
+func convertErrorKindToStatusCode(kind model.ErrorKind) int {
+	switch kind {
+	case model.ErrorKindInvalidInput:
+		return http.StatusBadRequest
+	case model.ErrorKindUnauthenticated:
+		return http.StatusUnauthorized
+	case model.ErrorKindForbidden:
+		return http.StatusForbidden
+	case model.ErrorKindNotFound:
+		return http.StatusNotFound
+	case model.ErrorKindConflict:
+		return http.StatusConflict
+	case model.ErrorKindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func convertPrimeCheck(test *model.PrimeCheck) openapi.PrimeCheck {
+	return openapi.PrimeCheck{
+		ID:                  test.ID(),
+		BatchID:             convertInt32PtrToOptInt32(test.BatchID()),
+		Number:              test.NumberText(),
+		BitLength:           convertInt32PtrToOptInt32(test.BitLength()),
+		Expression:          convertStringPtrToOptString(test.Expression()),
+		Operation:           convertStringPtrToOptString(test.Operation()),
+		Accuracy:            convertStringPtrToOptString(test.Accuracy()),
+		CallbackURL:         convertStringPtrToOptString(test.CallbackURL()),
+		TimeoutSeconds:      convertDurationPtrToOptSeconds(test.Timeout()),
+		CreatedAt:           test.CreatedAt(),
+		TraceID:             convertStringPtrToOptString(test.TraceID()),
+		MessageID:           convertStringPtrToOptString(test.MessageID()),
+		IsPrime:             convertBoolPtrToOptBool(test.IsPrime()),
+		Algorithm:           convertStringPtrToOptString(test.Algorithm()),
+		AlgorithmVersion:    convertStringPtrToOptString(test.AlgorithmVersion()),
+		AlgorithmParams:     convertAlgorithmParams(test.AlgorithmParams()),
+		FoundPrime:          convertStringPtrToOptString(test.FoundPrime()),
+		PrimeGap:            convertInt64PtrToOptInt64(test.PrimeGap()),
+		Confidence:          convertStringPtrToOptString(test.Confidence()),
+		ErrorBound:          convertFloat64PtrToOptFloat64(test.ErrorBound()),
+		WorkerID:            convertStringPtrToOptString(test.WorkerID()),
+		StartedAt:           convertTimePtrToOptDateTime(test.StartedAt()),
+		FinishedAt:          convertTimePtrToOptDateTime(test.FinishedAt()),
+		CalculationUs:       convertDurationPtrToOptMicroseconds(test.CalculationTime()),
+		Status:              convertStringPtrToOptString(test.Status()),
+		CertificateStatus:   convertStringPtrToOptString(test.CertificateStatus()),
+		FactorizationStatus: convertStringPtrToOptString(test.FactorizationStatus()),
+		Cached:              openapi.NewOptBool(test.Cached()),
+	}
+}
+
+func convertPrimeCheckBatch(batch *model.PrimeCheckBatch) *openapi.PrimeCheckBatch {
+	return &openapi.PrimeCheckBatch{
+		ID:           batch.ID(),
+		Format:       convertStringPtrToOptString(batch.SourceFormat()),
+		UploadStatus: convertStringPtrToOptString(batch.UploadStatus()),
+		Total:        batch.Total(),
+		Pending:      batch.Pending(),
+		Completed:    batch.Completed(),
+		Failed:       batch.Failed(),
+		CreatedAt:    batch.CreatedAt(),
+	}
+}
+
+func convertPrimeRange(primeRange *model.PrimeRange) *openapi.PrimeRange {
+	return &openapi.PrimeRange{
+		ID:         primeRange.ID(),
+		Start:      strconv.FormatUint(primeRange.Start(), 10),
+		End:        strconv.FormatUint(primeRange.End(), 10),
+		CountOnly:  primeRange.CountOnly(),
+		PrimeCount: convertInt64PtrToOptInt64(primeRange.PrimeCount()),
+		Status:     convertStringPtrToOptString(primeRange.Status()),
+		CreatedAt:  primeRange.CreatedAt(),
+	}
+}
+
+func convertWebhookDelivery(delivery *model.WebhookDelivery) openapi.WebhookDelivery {
+	return openapi.WebhookDelivery{
+		ID:         delivery.ID(),
+		RequestID:  delivery.RequestID(),
+		URL:        delivery.URL(),
+		Attempt:    delivery.Attempt(),
+		StatusCode: convertInt32PtrToOptInt32(delivery.StatusCode()),
+		Error:      convertStringPtrToOptString(delivery.ErrorMessage()),
+		Success:    delivery.Success(),
+		DurationUs: delivery.Duration().Microseconds(),
+		CreatedAt:  delivery.CreatedAt(),
+	}
+}
+
+func convertPrimeCheckEvent(event *model.PrimeCheckEvent) openapi.PrimeCheckEvent {
+	return openapi.PrimeCheckEvent{
+		ID:         event.ID(),
+		RequestID:  event.RequestID(),
+		FromStatus: convertStringPtrToOptString(event.FromStatus()),
+		ToStatus:   event.ToStatus(),
+		Actor:      event.Actor(),
+		TraceID:    convertStringPtrToOptString(event.TraceID()),
+		CreatedAt:  event.CreatedAt(),
+	}
+}
+
 func convertStringPtrToOptString(ptr *string) openapi.OptString {
 	if ptr == nil {
 		return openapi.OptString{}
@@ -132,9 +625,97 @@ func convertStringPtrToOptString(ptr *string) openapi.OptString {
 	return openapi.NewOptString(*ptr)
 }
 
+func convertStringToOptString(s string) openapi.OptString {
+	if s == "" {
+		return openapi.OptString{}
+	}
+	return openapi.NewOptString(s)
+}
+
+func convertInt64PtrToOptInt64(ptr *int64) openapi.OptInt64 {
+	if ptr == nil {
+		return openapi.OptInt64{}
+	}
+	return openapi.NewOptInt64(*ptr)
+}
+
+func convertInt32PtrToOptInt32(ptr *int32) openapi.OptInt32 {
+	if ptr == nil {
+		return openapi.OptInt32{}
+	}
+	return openapi.NewOptInt32(*ptr)
+}
+
+func convertFloat64PtrToOptFloat64(ptr *float64) openapi.OptFloat64 {
+	if ptr == nil {
+		return openapi.OptFloat64{}
+	}
+	return openapi.NewOptFloat64(*ptr)
+}
+
+func convertAlgorithmParams(params map[string]string) openapi.OptPrimeCheckAlgorithmParams {
+	if params == nil {
+		return openapi.OptPrimeCheckAlgorithmParams{}
+	}
+	return openapi.NewOptPrimeCheckAlgorithmParams(params)
+}
+
+func convertTimePtrToOptDateTime(ptr *time.Time) openapi.OptDateTime {
+	if ptr == nil {
+		return openapi.OptDateTime{}
+	}
+	return openapi.NewOptDateTime(*ptr)
+}
+
+func convertDurationPtrToOptMicroseconds(ptr *time.Duration) openapi.OptInt64 {
+	if ptr == nil {
+		return openapi.OptInt64{}
+	}
+	return openapi.NewOptInt64(ptr.Microseconds())
+}
+
+func convertDurationPtrToOptSeconds(ptr *time.Duration) openapi.OptInt32 {
+	if ptr == nil {
+		return openapi.OptInt32{}
+	}
+	return openapi.NewOptInt32(int32(*ptr / time.Second))
+}
+
 func convertBoolPtrToOptBool(ptr *bool) openapi.OptBool {
 	if ptr == nil {
 		return openapi.OptBool{}
 	}
 	return openapi.NewOptBool(*ptr)
 }
+
+func convertOptStringToPtr(opt openapi.OptString) *string {
+	v, ok := opt.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func convertOptBoolToPtr(opt openapi.OptBool) *bool {
+	v, ok := opt.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func convertOptInt32ToPtr(opt openapi.OptInt32) *int32 {
+	v, ok := opt.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+func convertOptDateTimeToPtr(opt openapi.OptDateTime) *time.Time {
+	v, ok := opt.Get()
+	if !ok {
+		return nil
+	}
+	return &v
+}
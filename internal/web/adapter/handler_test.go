@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+	"github.com/ponyo877/prime-checker/internal/web/usecase"
+	"github.com/ponyo877/prime-checker/openapi"
+)
+
+// settingRepository records whether the settings were written. Every other
+// method of the embedded nil Repository panics if called.
+type settingRepository struct {
+	usecase.Repository
+	updated bool
+}
+
+func (r *settingRepository) UpdateSetting(ctx context.Context, setting *model.Setting) (*model.Setting, error) {
+	r.updated = true
+	return setting, nil
+}
+
+func TestSettingsCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "user", role: model.UserRoleUser, wantStatus: http.StatusForbidden, wantCode: "admin_required"},
+		{name: "admin", role: model.UserRoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &settingRepository{}
+			h := NewHandler(usecase.NewUseCase(repo, nil))
+			ctx := context.WithValue(context.Background(), userContextKey{}, model.NewUser(1, tt.role, time.Now()))
+
+			_, err := h.SettingsCreate(ctx, &openapi.Setting{})
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("SettingsCreate() error = %v", err)
+				}
+				if !repo.updated {
+					t.Error("settings were not updated")
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("SettingsCreate() error = nil")
+			}
+			if repo.updated {
+				t.Error("settings were updated")
+			}
+			resp := h.NewError(ctx, err)
+			if resp.StatusCode != tt.wantStatus || resp.Response.ErrorCode != tt.wantCode {
+				t.Errorf("NewError() = %d %s, want %d %s", resp.StatusCode, resp.Response.ErrorCode, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+	"github.com/ponyo877/prime-checker/openapi"
+)
+
+const (
+	// Interval between comments that keep an idle event stream open through proxies
+	eventStreamHeartbeatInterval = 15 * time.Second
+)
+
+// primeCheckStatusEvent is the data of a status event in an event stream.
+type primeCheckStatusEvent struct {
+	RequestID int32     `json:"request_id"`
+	Status    string    `json:"status"`
+	IsPrime   *bool     `json:"is_prime,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (h *handler) PrimeChecksStreamAll(ctx context.Context, params openapi.PrimeChecksStreamAllParams) (r openapi.PrimeChecksStreamAllOK, _ error) {
+	body, err := h.streamPrimeChecks(ctx, nil, params.LastEventID.Or(""))
+	if err != nil {
+		return r, err
+	}
+	return openapi.PrimeChecksStreamAllOK{Data: body}, nil
+}
+
+func (h *handler) PrimeChecksStream(ctx context.Context, params openapi.PrimeChecksStreamParams) (r openapi.PrimeChecksStreamOK, _ error) {
+	body, err := h.streamPrimeChecks(ctx, &params.RequestID, params.LastEventID.Or(""))
+	if err != nil {
+		return r, err
+	}
+	return openapi.PrimeChecksStreamOK{Data: body}, nil
+}
+
+// streamPrimeChecks opens the stream of the prime check requestID, or of all
+// prime checks of the user when it is nil, and returns its events as
+// server-sent events. They are written while the response is being sent, so
+// an error after the stream is open only ends it.
+func (h *handler) streamPrimeChecks(ctx context.Context, requestID *int32, lastEventID string) (io.Reader, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "PrimeChecksStream")
+	defer span.End()
+
+	if requestID != nil {
+		span.SetAttributes(attribute.Int("request_id", int(*requestID)))
+	}
+	span.SetAttributes(attribute.String("last_event_id", lastEventID))
+
+	user := userFromContext(ctx)
+	stream, err := h.usecase.OpenPrimeCheckStream(ctx, user, requestID, lastEventID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// The response encoder closes the reader when the client goes away, which stops the writers
+	pr, pw := io.Pipe()
+	go func() {
+		followCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// A comment right away lets the client see that the stream is open
+		if _, err := io.WriteString(pw, ": stream opened\n\n"); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		go writeEventStreamHeartbeats(followCtx, pw)
+
+		pw.CloseWithError(h.usecase.FollowPrimeCheckStream(followCtx, user, stream, func(event *model.PrimeCheckResultEvent) error {
+			return writePrimeCheckStatusEvent(pw, event)
+		}))
+	}()
+
+	return pr, nil
+}
+
+// writePrimeCheckStatusEvent writes a status event in one write, which a pipe
+// never interleaves with the writes of the heartbeats.
+func writePrimeCheckStatusEvent(w io.Writer, event *model.PrimeCheckResultEvent) error {
+	data, err := json.Marshal(primeCheckStatusEvent{
+		RequestID: event.RequestID(),
+		Status:    event.Status(),
+		IsPrime:   event.IsPrime(),
+		UpdatedAt: event.UpdatedAt(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	// The current status sent when a stream starts has no ID to resume from
+	if event.ID() != 0 {
+		fmt.Fprintf(&buf, "id: %d\n", event.ID())
+	}
+	fmt.Fprintf(&buf, "event: status\ndata: %s\n\n", data)
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func writeEventStreamHeartbeats(ctx context.Context, w io.Writer) {
+	ticker := time.NewTicker(eventStreamHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// FlushEventStreams sends every write of a server-sent event stream to the
+// client at once, rather than when the response buffer fills up.
+func FlushEventStreams(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&eventStreamFlusher{ResponseWriter: w}, r)
+	})
+}
+
+type eventStreamFlusher struct {
+	http.ResponseWriter
+}
+
+func (f *eventStreamFlusher) Write(p []byte) (int, error) {
+	n, err := f.ResponseWriter.Write(p)
+	if err == nil && f.Header().Get("Content-Type") == "text/event-stream" {
+		err = http.NewResponseController(f.ResponseWriter).Flush()
+	}
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the flusher of the wrapped writer.
+func (f *eventStreamFlusher) Unwrap() http.ResponseWriter {
+	return f.ResponseWriter
+}
@@ -0,0 +1,91 @@
+package model
+
+import "math/big"
+
+// Accuracy modes from weakest to strongest, for deciding which cached verdicts can answer a request
+var accuracyRanks = map[string]int{
+	AccuracyFast:          0,
+	AccuracyStandard:      1,
+	AccuracyParanoid:      2,
+	AccuracyDeterministic: 3,
+}
+
+// CachedPrimeResult is a verdict from the shared results cache, written by the
+// prime check worker for every number it has decided.
+type CachedPrimeResult struct {
+	numberText       string
+	accuracy         string
+	isPrime          bool
+	algorithm        *string
+	algorithmVersion *string
+	algorithmParams  map[string]string
+	confidence       *string
+	errorBound       *float64
+}
+
+func NewCachedPrimeResult(numberText, accuracy string, isPrime bool, algorithm, algorithmVersion *string, algorithmParams map[string]string, confidence *string, errorBound *float64) *CachedPrimeResult {
+	return &CachedPrimeResult{
+		numberText:       numberText,
+		accuracy:         accuracy,
+		isPrime:          isPrime,
+		algorithm:        algorithm,
+		algorithmVersion: algorithmVersion,
+		algorithmParams:  algorithmParams,
+		confidence:       confidence,
+		errorBound:       errorBound,
+	}
+}
+
+func (r *CachedPrimeResult) NumberText() string {
+	return r.numberText
+}
+
+func (r *CachedPrimeResult) Accuracy() string {
+	return r.accuracy
+}
+
+func (r *CachedPrimeResult) IsPrime() bool {
+	return r.isPrime
+}
+
+func (r *CachedPrimeResult) Algorithm() *string {
+	return r.algorithm
+}
+
+func (r *CachedPrimeResult) AlgorithmVersion() *string {
+	return r.algorithmVersion
+}
+
+func (r *CachedPrimeResult) AlgorithmParams() map[string]string {
+	return r.algorithmParams
+}
+
+func (r *CachedPrimeResult) Confidence() *string {
+	return r.confidence
+}
+
+func (r *CachedPrimeResult) ErrorBound() *float64 {
+	return r.errorBound
+}
+
+// IsComposite reports whether the verdict is composite, which calls for a
+// factorization. Not every number that is not prime is: 0, 1 and negative
+// numbers are neither.
+func (r *CachedPrimeResult) IsComposite() bool {
+	n, ok := new(big.Int).SetString(r.numberText, 10)
+	return ok && !r.isPrime && n.Cmp(big.NewInt(2)) >= 0
+}
+
+// Satisfies reports whether the verdict can answer a request at the given
+// accuracy: a proven verdict answers every request, a probable one only
+// requests at the accuracy it was reached with or a weaker one.
+func (r *CachedPrimeResult) Satisfies(accuracy string) bool {
+	if r.confidence != nil && *r.confidence == "proven" {
+		return true
+	}
+	cachedRank, ok := accuracyRanks[r.accuracy]
+	if !ok {
+		return false
+	}
+	return cachedRank >= accuracyRanks[accuracy]
+}
@@ -0,0 +1,79 @@
+package model
+
+// ErrorKind classifies an error by what the client can do about it, which
+// decides the HTTP status it is reported with.
+type ErrorKind string
+
+const (
+	ErrorKindInvalidInput    ErrorKind = "invalid_input"
+	ErrorKindUnauthenticated ErrorKind = "unauthenticated"
+	ErrorKindForbidden       ErrorKind = "forbidden"
+	ErrorKindNotFound        ErrorKind = "not_found"
+	ErrorKindConflict        ErrorKind = "conflict"
+	ErrorKindUnavailable     ErrorKind = "unavailable"
+)
+
+// Error is a domain error with a kind and a machine-readable code. The
+// variables below are the errors the usecases and repositories return, most
+// often wrapped with the details of the failing request.
+type Error struct {
+	kind    ErrorKind
+	code    string
+	message string
+}
+
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{
+		kind:    kind,
+		code:    code,
+		message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Kind() ErrorKind {
+	return e.kind
+}
+
+// Code identifies the error to clients, such as invalid_accuracy or prime_check_not_found.
+func (e *Error) Code() string {
+	return e.code
+}
+
+var (
+	// ErrInvalidNumber is returned for a number or expression that cannot be parsed.
+	ErrInvalidNumber = NewError(ErrorKindInvalidInput, "invalid_number", "invalid number")
+	// ErrNumberTooLarge is returned for an expression over the evaluation budget.
+	ErrNumberTooLarge = NewError(ErrorKindInvalidInput, "number_too_large", "number too large")
+
+	// ErrUnauthenticated is returned for a request without a valid auth token.
+	ErrUnauthenticated = NewError(ErrorKindUnauthenticated, "unauthenticated", "missing or invalid auth token")
+	// ErrAdminRequired is returned to a user without the admin role for an admin-only operation.
+	ErrAdminRequired = NewError(ErrorKindForbidden, "admin_required", "admin role required")
+
+	// ErrPrimeCheckNotFound is returned for an unknown prime check.
+	ErrPrimeCheckNotFound = NewError(ErrorKindNotFound, "prime_check_not_found", "prime check not found")
+	// ErrPrimeCertificateNotFound is returned for a prime check that has no certificate and will not get one.
+	ErrPrimeCertificateNotFound = NewError(ErrorKindNotFound, "prime_certificate_not_found", "prime certificate not found")
+	// ErrPrimeCheckBatchNotFound is returned for an unknown batch.
+	ErrPrimeCheckBatchNotFound = NewError(ErrorKindNotFound, "prime_check_batch_not_found", "prime check batch not found")
+	// ErrPrimeRangeNotFound is returned for an unknown range.
+	ErrPrimeRangeNotFound = NewError(ErrorKindNotFound, "prime_range_not_found", "prime range not found")
+
+	// ErrPrimeCertificatePending is returned for a certificate the worker has not produced yet.
+	ErrPrimeCertificatePending = NewError(ErrorKindConflict, "prime_certificate_pending", "prime certificate is not ready yet")
+	// ErrPrimeCheckNotCancellable is returned for cancelling a prime check that already finished.
+	ErrPrimeCheckNotCancellable = NewError(ErrorKindConflict, "prime_check_not_cancellable", "prime check already finished")
+	// ErrPrimeCheckNotRetryable is returned for retrying a prime check that is processing or completed.
+	ErrPrimeCheckNotRetryable = NewError(ErrorKindConflict, "prime_check_not_retryable", "prime check did not fail, time out or get cancelled")
+
+	// ErrDatabaseUnavailable is returned when the database cannot be reached.
+	ErrDatabaseUnavailable = NewError(ErrorKindUnavailable, "database_unavailable", "database unavailable")
+	// ErrMessageBrokerUnavailable is returned when the message broker cannot be reached.
+	ErrMessageBrokerUnavailable = NewError(ErrorKindUnavailable, "message_broker_unavailable", "message broker unavailable")
+	// ErrFaultInjected is returned by a step the settings make fail.
+	ErrFaultInjected = NewError(ErrorKindUnavailable, "fault_injected", "fault injected by settings")
+)
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Longest Idempotency-Key header the idempotency_keys table stores
+const maxIdempotencyKeyLength = 255
+
+var (
+	// ErrInvalidIdempotencyKey is returned for an Idempotency-Key header that is too long or not printable ASCII.
+	ErrInvalidIdempotencyKey = NewError(ErrorKindInvalidInput, "invalid_idempotency_key", "invalid idempotency key")
+	// ErrIdempotencyKeyReused is returned when an idempotency key comes back with a different request.
+	ErrIdempotencyKeyReused = NewError(ErrorKindConflict, "idempotency_key_reused", "idempotency key was already used for a different request")
+)
+
+// IdempotencyKey is the key a client sends with a request it may retry,
+// together with a fingerprint of that request. A retry with the same key and
+// fingerprint gets the result of the first request rather than a new one.
+type IdempotencyKey struct {
+	key         string
+	fingerprint string
+}
+
+// NewIdempotencyKey checks the key a client sent. It is unique per user only.
+func NewIdempotencyKey(key, fingerprint string) (*IdempotencyKey, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return nil, ErrInvalidIdempotencyKey
+		}
+	}
+
+	return &IdempotencyKey{
+		key:         key,
+		fingerprint: fingerprint,
+	}, nil
+}
+
+func (k *IdempotencyKey) Key() string {
+	return k.key
+}
+
+// Fingerprint is the hex SHA-256 hash of the request the key was sent with.
+func (k *IdempotencyKey) Fingerprint() string {
+	return k.fingerprint
+}
+
+// PrimeCheckRequestFingerprint returns the fingerprint of a prime check
+// submission, which covers every field of its body.
+func PrimeCheckRequestFingerprint(input, operation, accuracy, algorithm, callbackURL string, timeoutSeconds *int32, certify, bypassCache bool) string {
+	// Encoding the fields as JSON keeps their boundaries apart
+	body, _ := json.Marshal([]any{input, operation, accuracy, algorithm, callbackURL, timeoutSeconds, certify, bypassCache})
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
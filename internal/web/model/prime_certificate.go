@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+)
+
+// CertificateStep mirrors one step of the certificate JSON stored by the prime check worker.
+type CertificateStep struct {
+	Method    string   `json:"method"`
+	N         string   `json:"n"`
+	Factors   []string `json:"factors,omitempty"`
+	Witnesses []string `json:"witnesses,omitempty"`
+	A         string   `json:"a,omitempty"`
+	B         string   `json:"b,omitempty"`
+	M         string   `json:"m,omitempty"`
+	Q         string   `json:"q,omitempty"`
+	X         string   `json:"x,omitempty"`
+	Y         string   `json:"y,omitempty"`
+}
+
+type PrimeCertificate struct {
+	requestID int32
+	number    string
+	method    string
+	steps     []CertificateStep
+	createdAt time.Time
+}
+
+func NewPrimeCertificate(requestID int32, number, method string, steps []CertificateStep, createdAt time.Time) *PrimeCertificate {
+	return &PrimeCertificate{
+		requestID: requestID,
+		number:    number,
+		method:    method,
+		steps:     steps,
+		createdAt: createdAt,
+	}
+}
+
+func (p *PrimeCertificate) RequestID() int32 {
+	return p.requestID
+}
+
+func (p *PrimeCertificate) Number() string {
+	return p.number
+}
+
+func (p *PrimeCertificate) Method() string {
+	return p.method
+}
+
+func (p *PrimeCertificate) Steps() []CertificateStep {
+	return p.steps
+}
+
+func (p *PrimeCertificate) CreatedAt() time.Time {
+	return p.createdAt
+}
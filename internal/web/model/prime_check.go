@@ -4,43 +4,155 @@ import (
 	"time"
 )
 
+const (
+	PrimeOperationIsPrime   = "is_prime"
+	PrimeOperationNextPrime = "next_prime"
+	PrimeOperationPrevPrime = "prev_prime"
+)
+
+const (
+	AccuracyFast          = "fast"
+	AccuracyStandard      = "standard"
+	AccuracyParanoid      = "paranoid"
+	AccuracyDeterministic = "deterministic"
+)
+
+// Primality tests a check can ask for instead of the one the worker selects
+const (
+	AlgorithmTrialDivision            = "trial_division"
+	AlgorithmMillerRabin              = "miller_rabin"
+	AlgorithmDeterministicMillerRabin = "miller_rabin_deterministic"
+	AlgorithmBailliePSW               = "baillie_psw"
+	AlgorithmLucasLehmer              = "lucas_lehmer"
+	AlgorithmPepin                    = "pepin"
+	AlgorithmProth                    = "proth"
+	AlgorithmAKS                      = "aks"
+)
+
+// FactorizationStatusPending marks a composite the factorization worker has yet to factorize.
+const FactorizationStatusPending = "pending"
+
+var (
+	// ErrInvalidOperation is returned for an unknown operation or a prime search that cannot have an answer.
+	ErrInvalidOperation = NewError(ErrorKindInvalidInput, "invalid_operation", "invalid prime check operation")
+	// ErrInvalidAccuracy is returned for an unknown accuracy mode.
+	ErrInvalidAccuracy = NewError(ErrorKindInvalidInput, "invalid_accuracy", "invalid prime check accuracy")
+	// ErrInvalidAlgorithm is returned for an unknown primality algorithm.
+	ErrInvalidAlgorithm = NewError(ErrorKindInvalidInput, "invalid_algorithm", "invalid prime check algorithm")
+	// ErrUnsupportedAlgorithm is returned for an algorithm that cannot decide the number.
+	ErrUnsupportedAlgorithm = NewError(ErrorKindInvalidInput, "unsupported_algorithm", "primality algorithm cannot decide this number")
+	// ErrInvalidWait is returned for a negative or overlong wait for a result.
+	ErrInvalidWait = NewError(ErrorKindInvalidInput, "invalid_wait", "invalid wait for a prime check result")
+	// ErrInvalidTimeout is returned for a time budget that is not positive.
+	ErrInvalidTimeout = NewError(ErrorKindInvalidInput, "invalid_timeout", "invalid prime check timeout")
+	// ErrInvalidCallbackURL is returned for a callback URL that is not an absolute http or https URL.
+	ErrInvalidCallbackURL = NewError(ErrorKindInvalidInput, "invalid_callback_url", "invalid callback URL")
+)
+
 type PrimeCheck struct {
-	id         int32
-	userID     int32
-	numberText string
-	createdAt  time.Time
-	updatedAt  time.Time
-	traceID    *string
-	messageID  *string
-	isPrime    *bool
-	status     *string
+	id                  int32
+	userID              int32
+	batchID             *int32
+	numberText          string
+	bitLength           *int32
+	expression          *string
+	operation           *string
+	accuracy            *string
+	callbackURL         *string
+	timeout             *time.Duration
+	createdAt           time.Time
+	updatedAt           time.Time
+	traceID             *string
+	messageID           *string
+	isPrime             *bool
+	algorithm           *string
+	algorithmVersion    *string
+	algorithmParams     map[string]string
+	foundPrime          *string
+	primeGap            *int64
+	confidence          *string
+	errorBound          *float64
+	workerID            *string
+	startedAt           *time.Time
+	finishedAt          *time.Time
+	calculationTime     *time.Duration
+	status              *string
+	certificateStatus   *string
+	factorizationStatus *string
+	cached              bool
+	factors             []PrimeFactor
+	unfactored          *string
 }
 
 func NewPrimeCheck(id, userID int32, numberText string, createdAt, updatedAt time.Time) *PrimeCheck {
 	return &PrimeCheck{
-		id:         id,
-		userID:     userID,
-		numberText: numberText,
-		createdAt:  createdAt,
-		updatedAt:  updatedAt,
-		traceID:    nil,
-		messageID:  nil,
-		isPrime:    nil,
-		status:     nil,
+		id:                  id,
+		userID:              userID,
+		batchID:             nil,
+		numberText:          numberText,
+		bitLength:           nil,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+		expression:          nil,
+		operation:           nil,
+		accuracy:            nil,
+		callbackURL:         nil,
+		timeout:             nil,
+		traceID:             nil,
+		messageID:           nil,
+		isPrime:             nil,
+		algorithm:           nil,
+		algorithmVersion:    nil,
+		algorithmParams:     nil,
+		foundPrime:          nil,
+		primeGap:            nil,
+		confidence:          nil,
+		errorBound:          nil,
+		workerID:            nil,
+		startedAt:           nil,
+		finishedAt:          nil,
+		calculationTime:     nil,
+		status:              nil,
+		certificateStatus:   nil,
+		factorizationStatus: nil,
+		cached:              false,
+		factors:             nil,
+		unfactored:          nil,
 	}
 }
 
-func NewPrimeCheckWithExtras(id, userID int32, numberText string, createdAt, updatedAt time.Time, traceID, messageID *string, isPrime *bool, status *string) *PrimeCheck {
+func NewPrimeCheckWithExtras(id, userID int32, batchID *int32, numberText string, bitLength *int32, expression, operation, accuracy, callbackURL *string, timeout *time.Duration, createdAt, updatedAt time.Time, traceID, messageID *string, isPrime *bool, algorithm, algorithmVersion *string, algorithmParams map[string]string, foundPrime *string, primeGap *int64, confidence *string, errorBound *float64, workerID *string, startedAt, finishedAt *time.Time, calculationTime *time.Duration, status, certificateStatus, factorizationStatus *string, cached bool) *PrimeCheck {
 	return &PrimeCheck{
-		id:         id,
-		userID:     userID,
-		numberText: numberText,
-		createdAt:  createdAt,
-		updatedAt:  updatedAt,
-		traceID:    traceID,
-		messageID:  messageID,
-		isPrime:    isPrime,
-		status:     status,
+		id:                  id,
+		userID:              userID,
+		batchID:             batchID,
+		numberText:          numberText,
+		bitLength:           bitLength,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+		expression:          expression,
+		operation:           operation,
+		accuracy:            accuracy,
+		callbackURL:         callbackURL,
+		timeout:             timeout,
+		traceID:             traceID,
+		messageID:           messageID,
+		isPrime:             isPrime,
+		algorithm:           algorithm,
+		algorithmVersion:    algorithmVersion,
+		algorithmParams:     algorithmParams,
+		foundPrime:          foundPrime,
+		primeGap:            primeGap,
+		confidence:          confidence,
+		errorBound:          errorBound,
+		workerID:            workerID,
+		startedAt:           startedAt,
+		finishedAt:          finishedAt,
+		calculationTime:     calculationTime,
+		status:              status,
+		certificateStatus:   certificateStatus,
+		factorizationStatus: factorizationStatus,
+		cached:              cached,
 	}
 }
 
@@ -52,10 +164,43 @@ func (p *PrimeCheck) UserID() int32 {
 	return p.userID
 }
 
+// BatchID is set when the check was submitted as part of a batch.
+func (p *PrimeCheck) BatchID() *int32 {
+	return p.batchID
+}
+
 func (p *PrimeCheck) NumberText() string {
 	return p.numberText
 }
 
+// BitLength is the size of the number in bits.
+func (p *PrimeCheck) BitLength() *int32 {
+	return p.bitLength
+}
+
+func (p *PrimeCheck) Expression() *string {
+	return p.expression
+}
+
+func (p *PrimeCheck) Operation() *string {
+	return p.operation
+}
+
+// Accuracy is the accuracy mode the number was checked with.
+func (p *PrimeCheck) Accuracy() *string {
+	return p.accuracy
+}
+
+// CallbackURL is where the result is posted once the check is final, or nil for none.
+func (p *PrimeCheck) CallbackURL() *string {
+	return p.callbackURL
+}
+
+// Timeout is the time budget the check asked for, or nil for the longest one.
+func (p *PrimeCheck) Timeout() *time.Duration {
+	return p.timeout
+}
+
 func (p *PrimeCheck) CreatedAt() time.Time {
 	return p.createdAt
 }
@@ -76,10 +221,85 @@ func (p *PrimeCheck) IsPrime() *bool {
 	return p.isPrime
 }
 
+func (p *PrimeCheck) Algorithm() *string {
+	return p.algorithm
+}
+
+// AlgorithmVersion is the version of the test implementation that produced the verdict.
+func (p *PrimeCheck) AlgorithmVersion() *string {
+	return p.algorithmVersion
+}
+
+// AlgorithmParams are the parameters the test ran with, such as its Miller-Rabin rounds or bases.
+func (p *PrimeCheck) AlgorithmParams() map[string]string {
+	return p.algorithmParams
+}
+
+// FoundPrime is the prime found by a next_prime or prev_prime request.
+func (p *PrimeCheck) FoundPrime() *string {
+	return p.foundPrime
+}
+
+// PrimeGap is the distance between the requested number and FoundPrime.
+func (p *PrimeCheck) PrimeGap() *int64 {
+	return p.primeGap
+}
+
+// Confidence is proven or probable, for the verdict actually reached.
+func (p *PrimeCheck) Confidence() *string {
+	return p.confidence
+}
+
+// ErrorBound bounds the probability that a probable prime is composite, 0 when proven.
+func (p *PrimeCheck) ErrorBound() *float64 {
+	return p.errorBound
+}
+
+// WorkerID identifies the worker process that calculated the check.
+func (p *PrimeCheck) WorkerID() *string {
+	return p.workerID
+}
+
+// StartedAt is when the worker started calculating, so that the time before
+// it is the wait in the queue.
+func (p *PrimeCheck) StartedAt() *time.Time {
+	return p.startedAt
+}
+
+func (p *PrimeCheck) FinishedAt() *time.Time {
+	return p.finishedAt
+}
+
+// CalculationTime is the time the worker spent calculating.
+func (p *PrimeCheck) CalculationTime() *time.Duration {
+	return p.calculationTime
+}
+
 func (p *PrimeCheck) Status() *string {
 	return p.status
 }
 
+func (p *PrimeCheck) CertificateStatus() *string {
+	return p.certificateStatus
+}
+
+func (p *PrimeCheck) FactorizationStatus() *string {
+	return p.factorizationStatus
+}
+
+// Cached is true when the result was taken from the results cache instead of being calculated.
+func (p *PrimeCheck) Cached() bool {
+	return p.cached
+}
+
+func (p *PrimeCheck) Factors() []PrimeFactor {
+	return p.factors
+}
+
+func (p *PrimeCheck) Unfactored() *string {
+	return p.unfactored
+}
+
 func (p *PrimeCheck) SetTraceID(traceID string) {
 	p.traceID = &traceID
 }
@@ -92,6 +312,11 @@ func (p *PrimeCheck) SetIsPrime(isPrime bool) {
 	p.isPrime = &isPrime
 }
 
-func (p *PrimeCheck) SetStatus(status string) {
-	p.status = &status
+func (p *PrimeCheck) SetCertificateStatus(certificateStatus string) {
+	p.certificateStatus = &certificateStatus
+}
+
+func (p *PrimeCheck) SetFactorization(factors []PrimeFactor, unfactored *string) {
+	p.factors = factors
+	p.unfactored = unfactored
 }
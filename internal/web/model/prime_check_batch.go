@@ -0,0 +1,141 @@
+package model
+
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+)
+
+const (
+	PrimeCheckFileFormatCSV    = "csv"
+	PrimeCheckFileFormatNDJSON = "ndjson"
+)
+
+// Upload statuses of a batch created from a file, whose prime checks are
+// committed a chunk at a time. A JSON batch is committed whole and has none.
+const (
+	PrimeCheckBatchUploadReceiving = "receiving"
+	PrimeCheckBatchUploadReceived  = "received"
+	PrimeCheckBatchUploadFailed    = "failed"
+)
+
+// ErrInvalidBatch is returned for an empty or oversized batch, or an unreadable batch file.
+var ErrInvalidBatch = NewError(ErrorKindInvalidInput, "invalid_batch", "invalid prime check batch")
+
+// PrimeCheckBatch groups the prime checks submitted in one batch request and
+// tracks how many of them are still pending, completed or failed.
+type PrimeCheckBatch struct {
+	id           int32
+	userID       int32
+	sourceFormat *string
+	uploadStatus *string
+	total        int64
+	pending      int64
+	completed    int64
+	failed       int64
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func NewPrimeCheckBatch(id, userID int32, sourceFormat, uploadStatus *string, total, pending, completed, failed int64, createdAt, updatedAt time.Time) *PrimeCheckBatch {
+	return &PrimeCheckBatch{
+		id:           id,
+		userID:       userID,
+		sourceFormat: sourceFormat,
+		uploadStatus: uploadStatus,
+		total:        total,
+		pending:      pending,
+		completed:    completed,
+		failed:       failed,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (b *PrimeCheckBatch) ID() int32 {
+	return b.id
+}
+
+func (b *PrimeCheckBatch) UserID() int32 {
+	return b.userID
+}
+
+// SourceFormat is the format of the uploaded file, or nil for a JSON batch.
+func (b *PrimeCheckBatch) SourceFormat() *string {
+	return b.sourceFormat
+}
+
+// UploadStatus tells whether the prime checks of an uploaded file are still
+// being received, or nil for a JSON batch. Total counts those received so far.
+func (b *PrimeCheckBatch) UploadStatus() *string {
+	return b.uploadStatus
+}
+
+func (b *PrimeCheckBatch) Total() int64 {
+	return b.total
+}
+
+func (b *PrimeCheckBatch) Pending() int64 {
+	return b.pending
+}
+
+func (b *PrimeCheckBatch) Completed() int64 {
+	return b.completed
+}
+
+// Failed counts the checks that failed, ran out of their time budget or were cancelled.
+func (b *PrimeCheckBatch) Failed() int64 {
+	return b.failed
+}
+
+func (b *PrimeCheckBatch) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+func (b *PrimeCheckBatch) UpdatedAt() time.Time {
+	return b.updatedAt
+}
+
+// FileFormat is the format results of the batch are downloaded in: the format
+// of the uploaded file, or NDJSON for a JSON batch.
+func (b *PrimeCheckBatch) FileFormat() string {
+	if b.sourceFormat == nil {
+		return PrimeCheckFileFormatNDJSON
+	}
+	return *b.sourceFormat
+}
+
+// PrimeCheckBatchCursor is the position after the last prime check of a page
+// of batch results. The results are ordered by ID alone, since every prime
+// check of a batch exists once the batch does.
+type PrimeCheckBatchCursor struct {
+	id int32
+}
+
+func NewPrimeCheckBatchCursor(id int32) *PrimeCheckBatchCursor {
+	return &PrimeCheckBatchCursor{
+		id: id,
+	}
+}
+
+// ParsePrimeCheckBatchCursor reads a cursor that Encode wrote.
+func ParsePrimeCheckBatchCursor(token string) (*PrimeCheckBatchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return NewPrimeCheckBatchCursor(int32(id)), nil
+}
+
+func (c *PrimeCheckBatchCursor) ID() int32 {
+	return c.id
+}
+
+// Encode returns the cursor as an opaque token for the client to pass back.
+func (c *PrimeCheckBatchCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString(strconv.AppendInt(nil, int64(c.id), 10))
+}
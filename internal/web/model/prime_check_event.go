@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+)
+
+// PrimeCheckEvent is one transition in the history of a prime check: the
+// service that moved it from one status to another, and the trace it did so
+// in. The event that created a prime check has no previous status.
+type PrimeCheckEvent struct {
+	id         int32
+	requestID  int32
+	fromStatus *string
+	toStatus   string
+	actor      string
+	traceID    *string
+	createdAt  time.Time
+}
+
+func NewPrimeCheckEvent(id, requestID int32, fromStatus *string, toStatus, actor string, traceID *string, createdAt time.Time) *PrimeCheckEvent {
+	return &PrimeCheckEvent{
+		id:         id,
+		requestID:  requestID,
+		fromStatus: fromStatus,
+		toStatus:   toStatus,
+		actor:      actor,
+		traceID:    traceID,
+		createdAt:  createdAt,
+	}
+}
+
+func (e *PrimeCheckEvent) ID() int32 {
+	return e.id
+}
+
+func (e *PrimeCheckEvent) RequestID() int32 {
+	return e.requestID
+}
+
+func (e *PrimeCheckEvent) FromStatus() *string {
+	return e.fromStatus
+}
+
+func (e *PrimeCheckEvent) ToStatus() string {
+	return e.toStatus
+}
+
+// Actor names the service that made the transition, such as web-server.
+func (e *PrimeCheckEvent) Actor() string {
+	return e.actor
+}
+
+func (e *PrimeCheckEvent) TraceID() *string {
+	return e.traceID
+}
+
+func (e *PrimeCheckEvent) CreatedAt() time.Time {
+	return e.createdAt
+}
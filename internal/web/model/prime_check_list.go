@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+var (
+	// ErrInvalidListFilter is returned for an unknown sort order, an oversized page or a filter that cannot match.
+	ErrInvalidListFilter = NewError(ErrorKindInvalidInput, "invalid_list_filter", "invalid prime check list filter")
+	// ErrInvalidCursor is returned for a cursor that no list returned.
+	ErrInvalidCursor = NewError(ErrorKindInvalidInput, "invalid_cursor", "invalid prime check list cursor")
+)
+
+// PrimeCheckFilter selects the prime checks a list returns. Every nil field
+// matches all prime checks, and UserID scopes the list to one user.
+type PrimeCheckFilter struct {
+	UserID       *int32
+	Status       *string
+	IsPrime      *bool
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MinDigits    *int32
+	MaxDigits    *int32
+	NumberPrefix *string
+}
+
+// PrimeCheckCursor is the position after the last prime check of a page. The
+// list is ordered by creation time and then ID, so the pair always resumes
+// right after that prime check, even when others are created meanwhile.
+type PrimeCheckCursor struct {
+	createdAt time.Time
+	id        int32
+}
+
+func NewPrimeCheckCursor(createdAt time.Time, id int32) *PrimeCheckCursor {
+	return &PrimeCheckCursor{
+		createdAt: createdAt,
+		id:        id,
+	}
+}
+
+// ParsePrimeCheckCursor reads a cursor that Encode wrote.
+func ParsePrimeCheckCursor(token string) (*PrimeCheckCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAtText, idText, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := strconv.ParseInt(createdAtText, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(idText, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return NewPrimeCheckCursor(time.Unix(0, createdAt).UTC(), int32(id)), nil
+}
+
+func (c *PrimeCheckCursor) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *PrimeCheckCursor) ID() int32 {
+	return c.id
+}
+
+// Encode returns the cursor as an opaque token for the client to pass back.
+func (c *PrimeCheckCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", c.createdAt.UnixNano(), c.id))
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+)
+
+// ErrInvalidEventID is returned for a Last-Event-ID that no event stream sent.
+var ErrInvalidEventID = NewError(ErrorKindInvalidInput, "invalid_event_id", "invalid last event ID")
+
+// PrimeCheckResultEvent tells that a prime check reached a status. Events the
+// workers publish have an ID that orders them and from which a stream resumes;
+// the current status of a prime check, sent when a stream starts, has ID 0.
+type PrimeCheckResultEvent struct {
+	id        uint64
+	requestID int32
+	userID    int32
+	status    string
+	isPrime   *bool
+	updatedAt time.Time
+}
+
+func NewPrimeCheckResultEvent(id uint64, requestID, userID int32, status string, isPrime *bool, updatedAt time.Time) *PrimeCheckResultEvent {
+	return &PrimeCheckResultEvent{
+		id:        id,
+		requestID: requestID,
+		userID:    userID,
+		status:    status,
+		isPrime:   isPrime,
+		updatedAt: updatedAt,
+	}
+}
+
+func (e *PrimeCheckResultEvent) ID() uint64 {
+	return e.id
+}
+
+func (e *PrimeCheckResultEvent) RequestID() int32 {
+	return e.requestID
+}
+
+func (e *PrimeCheckResultEvent) UserID() int32 {
+	return e.userID
+}
+
+func (e *PrimeCheckResultEvent) Status() string {
+	return e.status
+}
+
+func (e *PrimeCheckResultEvent) IsPrime() *bool {
+	return e.isPrime
+}
+
+func (e *PrimeCheckResultEvent) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
+// IsFinal reports whether the prime check is done, unless it is retried.
+func (e *PrimeCheckResultEvent) IsFinal() bool {
+	return primecheckstatus.Status(e.status).IsFinal()
+}
+
+// PrimeCheckStream is where a stream of result events starts: after an event
+// ID, and for a single prime check with its current status.
+type PrimeCheckStream struct {
+	requestID    *int32
+	afterEventID uint64
+	current      *PrimeCheckResultEvent
+}
+
+func NewPrimeCheckStream(requestID *int32, afterEventID uint64, current *PrimeCheckResultEvent) *PrimeCheckStream {
+	return &PrimeCheckStream{
+		requestID:    requestID,
+		afterEventID: afterEventID,
+		current:      current,
+	}
+}
+
+// RequestID is the prime check the stream follows, or nil for all of them.
+func (s *PrimeCheckStream) RequestID() *int32 {
+	return s.requestID
+}
+
+func (s *PrimeCheckStream) AfterEventID() uint64 {
+	return s.afterEventID
+}
+
+func (s *PrimeCheckStream) Current() *PrimeCheckResultEvent {
+	return s.current
+}
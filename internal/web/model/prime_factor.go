@@ -0,0 +1,7 @@
+package model
+
+// PrimeFactor mirrors one entry of the factor JSON stored by the factorization worker.
+type PrimeFactor struct {
+	Prime    string `json:"prime"`
+	Exponent int32  `json:"exponent"`
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+)
+
+// ErrInvalidRange is returned for range requests whose bounds are out of order or too large.
+var ErrInvalidRange = NewError(ErrorKindInvalidInput, "invalid_range", "invalid prime range")
+
+type PrimeRange struct {
+	id         int32
+	userID     int32
+	start      uint64
+	end        uint64
+	countOnly  bool
+	primeCount *int64
+	status     *string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func NewPrimeRange(id, userID int32, start, end uint64, countOnly bool, primeCount *int64, status *string, createdAt, updatedAt time.Time) *PrimeRange {
+	return &PrimeRange{
+		id:         id,
+		userID:     userID,
+		start:      start,
+		end:        end,
+		countOnly:  countOnly,
+		primeCount: primeCount,
+		status:     status,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (p *PrimeRange) ID() int32 {
+	return p.id
+}
+
+func (p *PrimeRange) UserID() int32 {
+	return p.userID
+}
+
+func (p *PrimeRange) Start() uint64 {
+	return p.start
+}
+
+func (p *PrimeRange) End() uint64 {
+	return p.end
+}
+
+func (p *PrimeRange) CountOnly() bool {
+	return p.countOnly
+}
+
+// PrimeCount is set once the worker has finished, timed out or failed; it counts the primes found so far.
+func (p *PrimeRange) PrimeCount() *int64 {
+	return p.primeCount
+}
+
+func (p *PrimeRange) Status() *string {
+	return p.status
+}
+
+func (p *PrimeRange) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *PrimeRange) UpdatedAt() time.Time {
+	return p.updatedAt
+}
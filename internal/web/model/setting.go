@@ -0,0 +1,39 @@
+package model
+
+// Setting tells for every step of handling a prime check whether it succeeds
+// or fails on purpose, for rehearsing failure scenarios.
+type Setting struct {
+	recordNumberSuccess bool
+	primeCheckSuccess   bool
+	emailSendSuccess    bool
+	dlqSaveSuccess      bool
+}
+
+func NewSetting(recordNumberSuccess, primeCheckSuccess, emailSendSuccess, dlqSaveSuccess bool) *Setting {
+	return &Setting{
+		recordNumberSuccess: recordNumberSuccess,
+		primeCheckSuccess:   primeCheckSuccess,
+		emailSendSuccess:    emailSendSuccess,
+		dlqSaveSuccess:      dlqSaveSuccess,
+	}
+}
+
+// RecordNumberSuccess is false when the web server fails to store new prime checks.
+func (s *Setting) RecordNumberSuccess() bool {
+	return s.recordNumberSuccess
+}
+
+// PrimeCheckSuccess is false when the prime check worker fails every message.
+func (s *Setting) PrimeCheckSuccess() bool {
+	return s.primeCheckSuccess
+}
+
+// EmailSendSuccess is false when the email send worker fails every message.
+func (s *Setting) EmailSendSuccess() bool {
+	return s.emailSendSuccess
+}
+
+// DLQSaveSuccess is false when the workers fail to move messages to the dead letter queue.
+func (s *Setting) DLQSaveSuccess() bool {
+	return s.dlqSaveSuccess
+}
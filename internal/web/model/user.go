@@ -0,0 +1,82 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Random bytes in an auth token, which make it too strong to guess for its
+// plain SHA-256 hash to be stored in place of a password hash
+const authTokenBytes = 32
+
+// Random bytes in a webhook secret, which is stored as is since the webhook
+// worker signs with it
+const webhookSecretBytes = 32
+
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
+type User struct {
+	id        int32
+	role      string
+	createdAt time.Time
+}
+
+func NewUser(id int32, role string, createdAt time.Time) *User {
+	return &User{
+		id:        id,
+		role:      role,
+		createdAt: createdAt,
+	}
+}
+
+func (u *User) ID() int32 {
+	return u.id
+}
+
+func (u *User) Role() string {
+	return u.role
+}
+
+func (u *User) IsAdmin() bool {
+	return u.role == UserRoleAdmin
+}
+
+// CanAccess reports whether the user may see what ownerID submitted: only its
+// own requests, or those of every user for an admin.
+func (u *User) CanAccess(ownerID int32) bool {
+	return u.IsAdmin() || u.id == ownerID
+}
+
+func (u *User) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+// NewAuthToken returns a random bearer token. Only its hash is stored, so the
+// token can be shown to the user only once.
+func NewAuthToken() (string, error) {
+	b := make([]byte, authTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// NewWebhookSecret returns a random key to sign the webhooks of a user with.
+func NewWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashAuthToken returns the hex SHA-256 hash under which a token is stored.
+func HashAuthToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+)
+
+// WebhookDelivery is one attempt to post the result of a prime check to its
+// callback URL. An attempt that got no response has no status code but an
+// error message.
+type WebhookDelivery struct {
+	id           int32
+	requestID    int32
+	url          string
+	attempt      int32
+	statusCode   *int32
+	errorMessage *string
+	success      bool
+	duration     time.Duration
+	createdAt    time.Time
+}
+
+func NewWebhookDelivery(id, requestID int32, url string, attempt int32, statusCode *int32, errorMessage *string, success bool, duration time.Duration, createdAt time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:           id,
+		requestID:    requestID,
+		url:          url,
+		attempt:      attempt,
+		statusCode:   statusCode,
+		errorMessage: errorMessage,
+		success:      success,
+		duration:     duration,
+		createdAt:    createdAt,
+	}
+}
+
+func (d *WebhookDelivery) ID() int32 {
+	return d.id
+}
+
+func (d *WebhookDelivery) RequestID() int32 {
+	return d.requestID
+}
+
+func (d *WebhookDelivery) URL() string {
+	return d.url
+}
+
+// Attempt counts the attempts of a delivery from 1.
+func (d *WebhookDelivery) Attempt() int32 {
+	return d.attempt
+}
+
+func (d *WebhookDelivery) StatusCode() *int32 {
+	return d.statusCode
+}
+
+func (d *WebhookDelivery) ErrorMessage() *string {
+	return d.errorMessage
+}
+
+func (d *WebhookDelivery) Success() bool {
+	return d.success
+}
+
+func (d *WebhookDelivery) Duration() time.Duration {
+	return d.duration
+}
+
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+func (r *Repository) GetPrimeCheckBatch(ctx context.Context, id int32) (*model.PrimeCheckBatch, error) {
+	batch, err := r.queries.GetPrimeCheckBatch(ctx, id)
+	if err != nil {
+		return nil, convertError(err, model.ErrPrimeCheckBatchNotFound)
+	}
+
+	counts, err := r.queries.CountPrimeChecksByBatchStatus(ctx, sql.NullInt32{Int32: id, Valid: true})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	var total, pending, completed, failed int64
+	for _, count := range counts {
+		total += count.Count
+		switch status := primecheckstatus.Status(count.Status.String); {
+		case status == primecheckstatus.Completed:
+			completed += count.Count
+		case status.IsFinal():
+			failed += count.Count
+		default:
+			pending += count.Count
+		}
+	}
+
+	return model.NewPrimeCheckBatch(
+		batch.ID,
+		batch.UserID,
+		convertNullStringToPtr(batch.SourceFormat),
+		convertNullStringToPtr(batch.UploadStatus),
+		total,
+		pending,
+		completed,
+		failed,
+		batch.CreatedAt,
+		batch.UpdatedAt,
+	), nil
+}
+
+// CreatePrimeCheckBatchWithMessages creates the batch, one prime check per
+// number and their outbox messages in a single transaction, so that either
+// the whole batch is queued or nothing is. The rows are written in bulk, a
+// chunk of numbers per statement.
+func (r *Repository) CreatePrimeCheckBatchWithMessages(ctx context.Context, userID int32, numberTexts, expressions []string, operation, accuracy string, certify, useCache bool) (*model.PrimeCheckBatch, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	result, err := txQueries.CreatePrimeCheckBatch(ctx, generated_sql.CreatePrimeCheckBatchParams{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	numbers := make([]batchNumber, len(numberTexts))
+	for i, numberText := range numberTexts {
+		numbers[i] = batchNumber{numberText: numberText, expression: expressions[i]}
+	}
+	if err := createPrimeChecksInTx(ctx, tx, userID, sql.NullInt32{Int32: int32(id), Valid: true}, numbers, operation, accuracy, certify, useCache); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	if err := r.injectRecordNumberFault(ctx); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return r.GetPrimeCheckBatch(ctx, int32(id))
+}
+
+// CreatePrimeCheckBatchFromFile creates a batch for an uploaded file and lets
+// produce add its prime checks one at a time. The batch is committed first as
+// receiving, and the prime checks are buffered only until a chunk is full,
+// which is then written in bulk and committed in a transaction of its own, so
+// that no transaction stays open while the file is read. The batch is received
+// once produce is done, or failed when produce or a chunk fails, in which case
+// the prime checks committed before stay queued.
+func (r *Repository) CreatePrimeCheckBatchFromFile(ctx context.Context, userID int32, sourceFormat, operation, accuracy string, certify, useCache bool, produce func(add func(numberText, expression string) error) error) (*model.PrimeCheckBatch, error) {
+	result, err := r.queries.CreatePrimeCheckBatch(ctx, generated_sql.CreatePrimeCheckBatchParams{
+		UserID:       userID,
+		SourceFormat: sql.NullString{String: sourceFormat, Valid: true},
+		UploadStatus: sql.NullString{String: model.PrimeCheckBatchUploadReceiving, Valid: true},
+	})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	batchID := sql.NullInt32{Int32: int32(id), Valid: true}
+	numbers := make([]batchNumber, 0, primeCheckBulkInsertSize)
+	flush := func() error {
+		if len(numbers) == 0 {
+			return nil
+		}
+		err := r.createPrimeCheckChunk(ctx, userID, batchID, numbers, operation, accuracy, certify, useCache)
+		numbers = numbers[:0]
+		return err
+	}
+
+	err = produce(func(numberText, expression string) error {
+		numbers = append(numbers, batchNumber{numberText: numberText, expression: expression})
+		if len(numbers) < primeCheckBulkInsertSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err == nil {
+		err = r.injectRecordNumberFault(ctx)
+	}
+
+	// The upload status is recorded even when the request has been cancelled
+	uploadStatus := model.PrimeCheckBatchUploadReceived
+	if err != nil {
+		uploadStatus = model.PrimeCheckBatchUploadFailed
+	}
+	if updateErr := r.queries.UpdatePrimeCheckBatchUploadStatus(context.WithoutCancel(ctx), generated_sql.UpdatePrimeCheckBatchUploadStatusParams{
+		UploadStatus: sql.NullString{String: uploadStatus, Valid: true},
+		ID:           int32(id),
+	}); updateErr != nil && err == nil {
+		err = updateErr
+	}
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return r.GetPrimeCheckBatch(ctx, int32(id))
+}
+
+// createPrimeCheckChunk commits a chunk of the prime checks of a batch.
+func (r *Repository) createPrimeCheckChunk(ctx context.Context, userID int32, batchID sql.NullInt32, numbers []batchNumber, operation, accuracy string, certify, useCache bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return convertError(err, nil)
+	}
+	defer tx.Rollback()
+
+	if err := createPrimeChecksInTx(ctx, tx, userID, batchID, numbers, operation, accuracy, certify, useCache); err != nil {
+		return convertError(err, nil)
+	}
+	return convertError(tx.Commit(), nil)
+}
+
+// ListPrimeCheckBatchResultsAfter returns up to limit prime checks of the batch
+// with an id above afterID, for reading a large batch page by page.
+func (r *Repository) ListPrimeCheckBatchResultsAfter(ctx context.Context, batchID, afterID, limit int32) ([]*model.PrimeCheck, error) {
+	rows, err := r.queries.ListPrimeChecksByBatchAfter(ctx, generated_sql.ListPrimeChecksByBatchAfterParams{
+		BatchID: sql.NullInt32{Int32: batchID, Valid: true},
+		ID:      afterID,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	result := []*model.PrimeCheck{}
+	for _, row := range rows {
+		result = append(result, convertPrimeCheck(row))
+	}
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/shared/certificatestatus"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/shared/numberhash"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// Prime checks written per multi-row INSERT, which keeps every statement well
+// below the placeholder and packet limits of MySQL
+const primeCheckBulkInsertSize = 500
+
+var primeCheckBulkColumns = []string{
+	"user_id", "batch_id", "number_text", "number_hash", "bit_length", "expression", "operation", "accuracy",
+	"is_prime", "algorithm", "algorithm_version", "algorithm_params", "confidence", "error_bound",
+	"factorization_status", "status", "certificate_status", "cached",
+}
+
+// batchNumber is a number of a batch waiting to be inserted.
+type batchNumber struct {
+	numberText string
+	expression string
+}
+
+// createPrimeChecksInTx inserts the prime checks of a batch together with
+// their first transitions and outbox messages, which factorize the cached
+// composites as createPrimeCheckInTx does. Unlike createPrimeCheckInTx it
+// writes each table with one multi-row INSERT per chunk of
+// primeCheckBulkInsertSize numbers, and looks the whole chunk up in the results
+// cache with a single query, so a batch costs a handful of round trips per
+// chunk rather than several per number.
+func createPrimeChecksInTx(ctx context.Context, tx generated_sql.DBTX, userID int32, batchID sql.NullInt32, numbers []batchNumber, operation, accuracy string, certify, useCache bool) error {
+	for start := 0; start < len(numbers); start += primeCheckBulkInsertSize {
+		end := min(start+primeCheckBulkInsertSize, len(numbers))
+		if err := createPrimeCheckChunkInTx(ctx, tx, userID, batchID, numbers[start:end], operation, accuracy, certify, useCache); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createPrimeCheckChunkInTx(ctx context.Context, tx generated_sql.DBTX, userID int32, batchID sql.NullInt32, numbers []batchNumber, operation, accuracy string, certify, useCache bool) error {
+	txQueries := generated_sql.New(tx)
+
+	hashes := make([]string, len(numbers))
+	for i, number := range numbers {
+		hashes[i] = numberhash.Sum(number.numberText)
+	}
+
+	cachedRows := map[string][]generated_sql.PrimeResultCache{}
+	if useCache {
+		rows, err := txQueries.ListCachedPrimeResultsByHashes(ctx, hashes)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			cachedRows[row.NumberHash] = append(cachedRows[row.NumberHash], row)
+		}
+	}
+
+	// The worker moves a requested certificate on from pending once the number is checked
+	certificateStatus := sql.NullString{}
+	if certify {
+		certificateStatus = sql.NullString{String: string(certificatestatus.Pending), Valid: true}
+	}
+
+	statuses := make([]primecheckstatus.Status, len(numbers))
+	factorize := make([]bool, len(numbers))
+	checkRows := make([][]any, len(numbers))
+	for i, number := range numbers {
+		row := []any{
+			userID,
+			batchID,
+			number.numberText,
+			hashes[i],
+			convertNumberTextToBitLength(number.numberText),
+			number.expression,
+			operation,
+			accuracy,
+		}
+		if cached := selectCachedPrimeResult(cachedRows[hashes[i]], accuracy); cached != nil {
+			statuses[i] = primecheckstatus.Completed
+			// A cached composite is factorized like a calculated one
+			factorizationStatus := sql.NullString{}
+			if cached.IsComposite() {
+				factorize[i] = true
+				factorizationStatus = sql.NullString{String: model.FactorizationStatusPending, Valid: true}
+			}
+			row = append(row,
+				cached.IsPrime(),
+				convertStringPtrToNullString(cached.Algorithm()),
+				convertStringPtrToNullString(cached.AlgorithmVersion()),
+				convertStringMapToJSON(cached.AlgorithmParams()),
+				convertStringPtrToNullString(cached.Confidence()),
+				convertFloat64PtrToNullFloat64(cached.ErrorBound()),
+				factorizationStatus,
+				string(statuses[i]),
+				sql.NullString{},
+				true,
+			)
+		} else {
+			statuses[i] = primecheckstatus.Queued
+			row = append(row, nil, nil, nil, nil, nil, nil, nil, string(statuses[i]), certificateStatus, false)
+		}
+		checkRows[i] = row
+	}
+
+	result, err := insertRows(ctx, tx, "prime_checks", primeCheckBulkColumns, checkRows)
+	if err != nil {
+		return err
+	}
+	firstID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	// Only this transaction adds prime checks to the batch, so its rows from the
+	// first id on are the ones just inserted, in the order of their values
+	ids, err := txQueries.ListPrimeCheckIDsByBatch(ctx, generated_sql.ListPrimeCheckIDsByBatchParams{
+		BatchID: batchID,
+		ID:      int32(firstID),
+		Limit:   int32(len(numbers)),
+	})
+	if err != nil {
+		return err
+	}
+	if len(ids) != len(numbers) {
+		return fmt.Errorf("inserted %d prime checks but found %d", len(numbers), len(ids))
+	}
+
+	traceID := primecheckstatus.TraceID(ctx)
+	eventRows := make([][]any, len(numbers))
+	outboxRows := [][]any{}
+	for i, number := range numbers {
+		eventRows[i] = []any{ids[i], nil, string(statuses[i]), string(primecheckstatus.ActorWebServer), traceID}
+
+		var msgType message.MessageType
+		var payload any
+		switch {
+		case statuses[i] == primecheckstatus.Queued:
+			msgType = message.MessageTypePrimeCheck
+			payload = &message.PrimeCheckPayload{
+				RequestID:  ids[i],
+				UserID:     userID,
+				NumberText: number.numberText,
+				Operation:  operation,
+				Accuracy:   accuracy,
+				Certify:    certify,
+			}
+		case factorize[i]:
+			msgType = message.MessageTypeFactorization
+			payload = &message.FactorizationPayload{
+				RequestID:  ids[i],
+				UserID:     userID,
+				NumberText: number.numberText,
+			}
+		default:
+			continue
+		}
+
+		msgBytes, err := marshalMessage(ctx, msgType, payload)
+		if err != nil {
+			return err
+		}
+		outboxRows = append(outboxRows, []any{string(msgType), msgBytes})
+	}
+
+	if _, err := insertRows(ctx, tx, "prime_check_events", []string{"prime_check_id", "from_status", "to_status", "actor", "trace_id"}, eventRows); err != nil {
+		return err
+	}
+	if len(outboxRows) > 0 {
+		if _, err := insertRows(ctx, tx, "outbox", []string{"event_type", "payload"}, outboxRows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertRows writes rows into table with a single multi-row INSERT.
+func insertRows(ctx context.Context, db generated_sql.DBTX, table string, columns []string, rows [][]any) (sql.Result, error) {
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES " +
+		strings.TrimSuffix(strings.Repeat(placeholders+", ", len(rows)), ", ")
+
+	args := make([]any, 0, len(rows)*len(columns))
+	for _, row := range rows {
+		args = append(args, row...)
+	}
+	return db.ExecContext(ctx, query, args...)
+}
+
+// marshalMessage encodes a message for the outbox with the trace of ctx.
+func marshalMessage(ctx context.Context, msgType message.MessageType, payload any) (json.RawMessage, error) {
+	msg, err := message.NewMessageWithTraceContext(ctx, msgType, payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(msg)
+}
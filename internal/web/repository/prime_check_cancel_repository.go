@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/shared/certificatestatus"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// CancelPrimeCheck marks a queued, dispatched or computing prime check
+// cancelled, and announces the cancellation to the result stream and the
+// callback URL in the same transaction. Cancelling a cancelled prime check
+// again changes nothing, and any other status fails with
+// ErrPrimeCheckNotCancellable.
+func (r *Repository) CancelPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	from, err := primecheckstatus.Lock(ctx, txQueries, id)
+	if err != nil {
+		return nil, convertError(err, model.ErrPrimeCheckNotFound)
+	}
+	if from != primecheckstatus.Cancelled {
+		err := primecheckstatus.Transition(ctx, txQueries, id, from, primecheckstatus.Cancelled, primecheckstatus.ActorWebServer)
+		if errors.Is(err, primecheckstatus.ErrInvalidTransition) {
+			return nil, fmt.Errorf("%w: prime check %d is %s", model.ErrPrimeCheckNotCancellable, id, from)
+		}
+		if err != nil {
+			return nil, convertError(err, nil)
+		}
+	}
+
+	row, err := txQueries.GetPrimeCheck(ctx, id)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	if from == primecheckstatus.Cancelled {
+		return convertPrimeCheck(row), nil
+	}
+
+	if err := createResultMessageInTx(ctx, txQueries, &message.PrimeCheckResultPayload{
+		RequestID: row.ID,
+		UserID:    row.UserID,
+		Status:    string(primecheckstatus.Cancelled),
+		UpdatedAt: row.UpdatedAt,
+	}); err != nil {
+		return nil, convertError(err, nil)
+	}
+	if row.CallbackUrl.Valid {
+		if err := createWebhookMessageInTx(ctx, txQueries, &message.WebhookDeliveryPayload{
+			RequestID:   row.ID,
+			UserID:      row.UserID,
+			CallbackURL: row.CallbackUrl.String,
+			NumberText:  row.NumberText,
+			Status:      string(primecheckstatus.Cancelled),
+			UpdatedAt:   row.UpdatedAt,
+		}); err != nil {
+			return nil, convertError(err, nil)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, convertError(err, nil)
+	}
+	return convertPrimeCheck(row), nil
+}
+
+// RetryPrimeCheck clears the result of a failed, timed out or cancelled prime
+// check and queues it for the prime check worker again, with the operation,
+// accuracy, algorithm, time budget and callback URL it was created with. Any other status
+// fails with ErrPrimeCheckNotRetryable. The retry is a new attempt, so that the
+// worker drops a message of an earlier one that is still on its way.
+func (r *Repository) RetryPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	from, err := primecheckstatus.Lock(ctx, txQueries, id)
+	if err != nil {
+		return nil, convertError(err, model.ErrPrimeCheckNotFound)
+	}
+	if err := primecheckstatus.CheckTransition(from, primecheckstatus.Queued); err != nil {
+		return nil, fmt.Errorf("%w: prime check %d is %s", model.ErrPrimeCheckNotRetryable, id, from)
+	}
+
+	row, err := txQueries.GetPrimeCheck(ctx, id)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	// A requested certificate is produced anew for the new result
+	certify := row.CertificateStatus.Valid
+	certificateStatus := sql.NullString{}
+	if certify {
+		certificateStatus = sql.NullString{String: string(certificatestatus.Pending), Valid: true}
+	}
+
+	result, err := txQueries.RetryPrimeCheck(ctx, generated_sql.RetryPrimeCheckParams{
+		CertificateStatus: certificateStatus,
+		ID:                id,
+		FromStatus:        sql.NullString{String: string(from), Valid: true},
+	})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	if err := primecheckstatus.CheckUpdated(result); err != nil {
+		return nil, convertError(err, nil)
+	}
+	if err := primecheckstatus.Record(ctx, txQueries, id, from, primecheckstatus.Queued, primecheckstatus.ActorWebServer); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	if err := createPrimeCheckMessageInTx(ctx, txQueries, &message.PrimeCheckPayload{
+		RequestID:      row.ID,
+		UserID:         row.UserID,
+		NumberText:     row.NumberText,
+		Operation:      row.Operation.String,
+		Accuracy:       row.Accuracy.String,
+		Algorithm:      row.RequestedAlgorithm.String,
+		TimeoutSeconds: row.TimeoutSeconds.Int32,
+		Certify:        certify,
+		CallbackURL:    row.CallbackUrl.String,
+		Attempt:        row.Attempt + 1,
+	}); err != nil {
+		return nil, convertError(err, nil)
+	}
+	if err := createResultMessageInTx(ctx, txQueries, &message.PrimeCheckResultPayload{
+		RequestID: row.ID,
+		UserID:    row.UserID,
+		Status:    string(primecheckstatus.Queued),
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	check, err := r.queries.GetPrimeCheck(ctx, id)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	return convertPrimeCheck(check), nil
+}
+
+// createResultMessageInTx queues a result event, which the result stream and
+// the waiting requests follow.
+func createResultMessageInTx(ctx context.Context, txQueries *generated_sql.Queries, payload *message.PrimeCheckResultPayload) error {
+	msg, err := message.NewMessageWithTraceContext(ctx, message.MessageTypePrimeCheckResult, payload)
+	if err != nil {
+		return err
+	}
+
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = txQueries.CreateOutboxMessage(ctx, generated_sql.CreateOutboxMessageParams{
+		EventType: string(message.MessageTypePrimeCheckResult),
+		Payload:   msgBytes,
+	})
+	return err
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+func (r *Repository) ListPrimeCheckEvents(ctx context.Context, requestID int32) ([]*model.PrimeCheckEvent, error) {
+	rows, err := r.queries.ListPrimeCheckEvents(ctx, requestID)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	events := make([]*model.PrimeCheckEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, model.NewPrimeCheckEvent(
+			row.ID,
+			row.PrimeCheckID,
+			convertNullStringToPtr(row.FromStatus),
+			row.ToStatus,
+			row.Actor,
+			convertNullStringToPtr(row.TraceID),
+			row.CreatedAt,
+		))
+	}
+	return events, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ponyo877/prime-checker/internal/shared/infrastructure"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+	"github.com/ponyo877/prime-checker/internal/web/usecase"
+)
+
+// Subject the prime check worker publishes result events on through the outbox
+const primeCheckResultSubject = "primecheckresult"
+
+// PrimeCheckResultEventRepository reads the result events from the message
+// broker, where their stream sequence serves as the event ID.
+type PrimeCheckResultEventRepository struct {
+	broker infrastructure.MessageBroker
+}
+
+func NewPrimeCheckResultEventRepository(broker infrastructure.MessageBroker) usecase.PrimeCheckResultEvents {
+	return &PrimeCheckResultEventRepository{
+		broker: broker,
+	}
+}
+
+func (r *PrimeCheckResultEventRepository) LastPrimeCheckResultEventID(ctx context.Context) (uint64, error) {
+	id, err := r.broker.LastSequence(ctx, primeCheckResultSubject)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", model.ErrMessageBrokerUnavailable, err)
+	}
+	return id, nil
+}
+
+func (r *PrimeCheckResultEventRepository) WatchPrimeCheckResultEvents(ctx context.Context, afterID uint64, handle func(event *model.PrimeCheckResultEvent) error) error {
+	return r.broker.Watch(ctx, primeCheckResultSubject, afterID, func(ctx context.Context, sequence uint64, msg *message.Message) error {
+		payload, err := msg.UnmarshalPrimeCheckResultPayload()
+		if err != nil {
+			log.Printf("Skipping unreadable prime check result event %d: %v", sequence, err)
+			return nil
+		}
+
+		return handle(model.NewPrimeCheckResultEvent(sequence, payload.RequestID, payload.UserID, payload.Status, payload.IsPrime, payload.UpdatedAt))
+	})
+}
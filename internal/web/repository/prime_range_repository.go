@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+func convertNullInt64ToPtr(ni sql.NullInt64) *int64 {
+	if !ni.Valid {
+		return nil
+	}
+	return &ni.Int64
+}
+
+func (r *Repository) GetPrimeRange(ctx context.Context, id int32) (*model.PrimeRange, error) {
+	row, err := r.queries.GetPrimeRange(ctx, id)
+	if err != nil {
+		return nil, convertError(err, model.ErrPrimeRangeNotFound)
+	}
+
+	return convertPrimeRange(row), nil
+}
+
+func (r *Repository) CreatePrimeRangeWithMessage(ctx context.Context, userID int32, start, end uint64, countOnly bool) (*model.PrimeRange, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	defer tx.Rollback()
+
+	txQueries := r.queries.WithTx(tx)
+
+	result, err := txQueries.CreatePrimeRange(ctx, generated_sql.CreatePrimeRangeParams{
+		UserID:     userID,
+		RangeStart: int64(start),
+		RangeEnd:   int64(end),
+		CountOnly:  countOnly,
+	})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	// Create message for prime range worker with trace context
+	payload := &message.PrimeRangePayload{
+		RangeID:   int32(id),
+		UserID:    userID,
+		Start:     start,
+		End:       end,
+		CountOnly: countOnly,
+	}
+
+	msg, err := message.NewMessageWithTraceContext(ctx, message.MessageTypePrimeRange, payload)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	// Save message to outbox
+	if _, err := txQueries.CreateOutboxMessage(ctx, generated_sql.CreateOutboxMessageParams{
+		EventType: string(message.MessageTypePrimeRange),
+		Payload:   msgBytes,
+	}); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return r.GetPrimeRange(ctx, int32(id))
+}
+
+// ListPrimeRangePrimes reads the chunks overlapping positions [offset, offset+limit)
+// and cuts the requested page out of them.
+func (r *Repository) ListPrimeRangePrimes(ctx context.Context, rangeID int32, offset, limit int64) ([]uint64, error) {
+	chunks, err := r.queries.ListPrimeRangeChunks(ctx, generated_sql.ListPrimeRangeChunksParams{
+		PrimeRangeID:  rangeID,
+		EndPosition:   offset,
+		FirstPosition: offset + limit,
+	})
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	primes := []uint64{}
+	for _, chunk := range chunks {
+		var chunkPrimes []uint64
+		if err := json.Unmarshal(chunk.Primes, &chunkPrimes); err != nil {
+			return nil, convertError(err, nil)
+		}
+
+		from := max(offset-chunk.FirstPosition, 0)
+		to := min(offset+limit-chunk.FirstPosition, int64(len(chunkPrimes)))
+		primes = append(primes, chunkPrimes[from:to]...)
+	}
+	return primes, nil
+}
+
+func convertPrimeRange(row generated_sql.PrimeRange) *model.PrimeRange {
+	return model.NewPrimeRange(
+		row.ID,
+		row.UserID,
+		uint64(row.RangeStart),
+		uint64(row.RangeEnd),
+		row.CountOnly,
+		convertNullInt64ToPtr(row.PrimeCount),
+		convertNullStringToPtr(row.Status),
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
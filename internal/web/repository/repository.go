@@ -3,14 +3,53 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 
 	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/shared/certificatestatus"
+	"github.com/ponyo877/prime-checker/internal/shared/faultinjection"
 	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/shared/numberhash"
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
 	"github.com/ponyo877/prime-checker/internal/web/model"
 	"github.com/ponyo877/prime-checker/internal/web/usecase"
 )
 
+// MySQL error number of an insert that violates a unique key
+const mysqlErrDuplicateEntry = 1062
+
+// convertError maps a database error to the domain error the API reports: a
+// missing row to notFound, when given, and a lost connection to
+// ErrDatabaseUnavailable. Other errors are returned unchanged. Every exported
+// method returns its errors through it; the helpers they call do not.
+func convertError(err error, notFound error) error {
+	if err == nil {
+		return nil
+	}
+	if notFound != nil && errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %w", model.ErrDatabaseUnavailable, err)
+	}
+	return err
+}
+
+// isDuplicateEntry reports whether err is a violation of a unique key.
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
 func convertNullStringToPtr(ns sql.NullString) *string {
 	if !ns.Valid {
 		return nil
@@ -25,127 +64,567 @@ func convertNullBoolToPtr(nb sql.NullBool) *bool {
 	return &nb.Bool
 }
 
+func convertNullFloat64ToPtr(nf sql.NullFloat64) *float64 {
+	if !nf.Valid {
+		return nil
+	}
+	return &nf.Float64
+}
+
+// convertNumberTextToBitLength returns the size of a decimal number in bits.
+func convertNumberTextToBitLength(numberText string) sql.NullInt32 {
+	n, ok := new(big.Int).SetString(numberText, 10)
+	if !ok {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(n.BitLen()), Valid: true}
+}
+
+func convertNullTimeToPtr(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
+
+func convertMicrosecondsToDurationPtr(ni sql.NullInt64) *time.Duration {
+	if !ni.Valid {
+		return nil
+	}
+	d := time.Duration(ni.Int64) * time.Microsecond
+	return &d
+}
+
+func convertSecondsToDurationPtr(ni sql.NullInt32) *time.Duration {
+	if !ni.Valid {
+		return nil
+	}
+	d := time.Duration(ni.Int32) * time.Second
+	return &d
+}
+
+// convertJSONToStringMap decodes a JSON object of strings, nil for NULL or
+// anything else, which the workers never write.
+func convertJSONToStringMap(raw json.RawMessage) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// convertStringMapToJSON encodes a map as a JSON object, NULL when it is empty.
+func convertStringMapToJSON(m map[string]string) json.RawMessage {
+	if len(m) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func convertStringPtrToNullString(ptr *string) sql.NullString {
+	if ptr == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *ptr, Valid: true}
+}
+
+func convertFloat64PtrToNullFloat64(ptr *float64) sql.NullFloat64 {
+	if ptr == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *ptr, Valid: true}
+}
+
+func convertNullInt32ToPtr(ni sql.NullInt32) *int32 {
+	if !ni.Valid {
+		return nil
+	}
+	return &ni.Int32
+}
+
+func convertInt32PtrToNullInt32(ptr *int32) sql.NullInt32 {
+	if ptr == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *ptr, Valid: true}
+}
+
+func convertBoolPtrToNullBool(ptr *bool) sql.NullBool {
+	if ptr == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *ptr, Valid: true}
+}
+
+func convertTimePtrToNullTime(ptr *time.Time) sql.NullTime {
+	if ptr == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *ptr, Valid: true}
+}
+
 type Repository struct {
 	db      *sql.DB
 	queries *generated_sql.Queries
+	faults  *faultinjection.Injector
 }
 
 func NewRepository(db *sql.DB) usecase.Repository {
+	queries := generated_sql.New(db)
 	return &Repository{
 		db:      db,
-		queries: generated_sql.New(db),
+		queries: queries,
+		faults:  faultinjection.NewInjector(queries),
 	}
 }
 
 func (r *Repository) GetPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error) {
 	test, err := r.queries.GetPrimeCheck(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, convertError(err, model.ErrPrimeCheckNotFound)
 	}
 
-	return model.NewPrimeCheckWithExtras(
-		test.ID, 
-		test.UserID, 
-		test.NumberText, 
-		test.CreatedAt, 
-		test.UpdatedAt,
-		convertNullStringToPtr(test.TraceID),
-		convertNullStringToPtr(test.MessageID),
-		convertNullBoolToPtr(test.IsPrime),
-		convertNullStringToPtr(test.Status),
-	), nil
+	check := convertPrimeCheck(test)
+
+	// The factorization row only exists once the factorization worker has finished
+	factorization, err := r.queries.GetPrimeFactorization(ctx, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, convertError(err, nil)
+	}
+	if err == nil {
+		var factors []model.PrimeFactor
+		if err := json.Unmarshal(factorization.Factors, &factors); err != nil {
+			return nil, convertError(err, nil)
+		}
+		check.SetFactorization(factors, convertNullStringToPtr(factorization.Unfactored))
+	}
+
+	return check, nil
 }
 
-func (r *Repository) ListPrimeChecks(ctx context.Context) ([]*model.PrimeCheck, error) {
-	tests, err := r.queries.ListPrimeChecks(ctx)
+// ListPrimeChecks returns up to limit prime checks that match filter, ordered
+// by creation time and then ID, starting after the cursor when one is given.
+func (r *Repository) ListPrimeChecks(ctx context.Context, filter model.PrimeCheckFilter, order string, after *model.PrimeCheckCursor, limit int32) ([]*model.PrimeCheck, error) {
+	params := generated_sql.ListPrimeChecksParams{
+		UserID:      convertInt32PtrToNullInt32(filter.UserID),
+		Status:      convertStringPtrToNullString(filter.Status),
+		IsPrime:     convertBoolPtrToNullBool(filter.IsPrime),
+		CreatedFrom: convertTimePtrToNullTime(filter.CreatedFrom),
+		CreatedTo:   convertTimePtrToNullTime(filter.CreatedTo),
+		MinDigits:   convertInt32PtrToNullInt32(filter.MinDigits),
+		MaxDigits:   convertInt32PtrToNullInt32(filter.MaxDigits),
+		Limit:       limit,
+	}
+	// The prefix only holds digits, which LIKE matches literally
+	if filter.NumberPrefix != nil {
+		params.NumberPattern = sql.NullString{String: *filter.NumberPrefix + "%", Valid: true}
+	}
+	if after != nil {
+		params.CursorCreatedAt = sql.NullTime{Time: after.CreatedAt(), Valid: true}
+		params.CursorID = sql.NullInt32{Int32: after.ID(), Valid: true}
+	}
+
+	var tests []generated_sql.PrimeCheck
+	var err error
+	if order == model.SortOrderAsc {
+		tests, err = r.queries.ListPrimeChecksAsc(ctx, generated_sql.ListPrimeChecksAscParams(params))
+	} else {
+		tests, err = r.queries.ListPrimeChecks(ctx, params)
+	}
 	if err != nil {
-		return nil, err
+		return nil, convertError(err, nil)
 	}
 
 	result := []*model.PrimeCheck{}
 	for _, test := range tests {
-		result = append(result, model.NewPrimeCheckWithExtras(
-			test.ID, 
-			test.UserID, 
-			test.NumberText, 
-			test.CreatedAt, 
-			test.UpdatedAt,
-			convertNullStringToPtr(test.TraceID),
-			convertNullStringToPtr(test.MessageID),
-			convertNullBoolToPtr(test.IsPrime),
-			convertNullStringToPtr(test.Status),
-		))
+		result = append(result, convertPrimeCheck(test))
 	}
 	return result, nil
 }
 
-func (r *Repository) CreatePrimeCheckWithMessage(ctx context.Context, userID int32, numberText string) (*model.PrimeCheck, error) {
+// CreatePrimeCheckWithMessage stores the idempotency key, if any, in the
+// transaction that creates the prime check, so that a retry finds either both
+// or neither. It reports whether the prime check was replayed for a key used
+// before rather than created.
+func (r *Repository) CreatePrimeCheckWithMessage(ctx context.Context, userID int32, idempotencyKey *model.IdempotencyKey, numberText, expression, operation, accuracy, algorithm, callbackURL string, timeout time.Duration, certify, useCache bool) (*model.PrimeCheck, bool, error) {
+	if idempotencyKey != nil {
+		replayed, err := r.replayPrimeCheck(ctx, userID, idempotencyKey)
+		if err != nil || replayed != nil {
+			return replayed, replayed != nil, err
+		}
+	}
+
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, convertError(err, nil)
 	}
 	defer tx.Rollback()
 
 	txQueries := r.queries.WithTx(tx)
 
+	id, err := createPrimeCheckInTx(ctx, txQueries, userID, sql.NullInt32{}, numberText, expression, operation, accuracy, algorithm, callbackURL, timeout, certify, useCache)
+	if err != nil {
+		return nil, false, convertError(err, nil)
+	}
+
+	if idempotencyKey != nil {
+		if err := txQueries.CreateIdempotencyKey(ctx, generated_sql.CreateIdempotencyKeyParams{
+			UserID:             userID,
+			IdempotencyKey:     idempotencyKey.Key(),
+			RequestFingerprint: idempotencyKey.Fingerprint(),
+			PrimeCheckID:       id,
+		}); err != nil {
+			if !isDuplicateEntry(err) {
+				return nil, false, convertError(err, nil)
+			}
+			// A concurrent request with the same key committed first: drop this check and answer with that one
+			tx.Rollback()
+			replayed, err := r.replayPrimeCheck(ctx, userID, idempotencyKey)
+			if err == nil && replayed == nil {
+				err = fmt.Errorf("idempotency key %q vanished after a duplicate entry", idempotencyKey.Key())
+			}
+			return replayed, true, err
+		}
+	}
+
+	if err := r.injectRecordNumberFault(ctx); err != nil {
+		return nil, false, convertError(err, nil)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, false, convertError(err, nil)
+	}
+
+	// Return the created prime check
+	check, err := r.queries.GetPrimeCheck(ctx, id)
+	if err != nil {
+		return nil, false, convertError(err, nil)
+	}
+
+	return convertPrimeCheck(check), false, nil
+}
+
+// replayPrimeCheck returns the prime check created earlier with the
+// idempotency key, or nil when the key is new. A key created with a different
+// request fails with ErrIdempotencyKeyReused. The prime check is read as it is
+// now, so a replay shows its current status and result rather than a copy of
+// the response to the first request, and its trace ID only once the worker
+// has stored the result of the latest attempt, since a retry clears it.
+func (r *Repository) replayPrimeCheck(ctx context.Context, userID int32, idempotencyKey *model.IdempotencyKey) (*model.PrimeCheck, error) {
+	row, err := r.queries.GetIdempotencyKey(ctx, generated_sql.GetIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: idempotencyKey.Key(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	if row.RequestFingerprint != idempotencyKey.Fingerprint() {
+		return nil, fmt.Errorf("%w: %q", model.ErrIdempotencyKeyReused, idempotencyKey.Key())
+	}
+
+	check, err := r.queries.GetPrimeCheck(ctx, row.PrimeCheckID)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+	return convertPrimeCheck(check), nil
+}
+
+// injectRecordNumberFault fails storing prime checks when the settings say so,
+// which rolls back the transaction they were inserted in.
+func (r *Repository) injectRecordNumberFault(ctx context.Context) error {
+	if err := r.faults.Inject(ctx, faultinjection.StepRecordNumber); err != nil {
+		return fmt.Errorf("%w: %w", model.ErrFaultInjected, err)
+	}
+	return nil
+}
+
+// createPrimeCheckInTx inserts a prime check together with its outbox message
+// so that callers can create one or many checks in a single transaction. With
+// useCache a verdict from the results cache completes the check right away,
+// and no message is queued for it but the ones delivering the result to the
+// callback URL and factorizing a composite. An empty algorithm lets the worker select the primality test,
+// an empty callback URL asks for no webhook, and a zero timeout gives the check
+// the longest time budget.
+func createPrimeCheckInTx(ctx context.Context, txQueries *generated_sql.Queries, userID int32, batchID sql.NullInt32, numberText, expression, operation, accuracy, algorithm, callbackURL string, timeout time.Duration, certify, useCache bool) (int32, error) {
+	numberHash := numberhash.Sum(numberText)
+	bitLength := convertNumberTextToBitLength(numberText)
+	callback := sql.NullString{String: callbackURL, Valid: callbackURL != ""}
+
+	if useCache {
+		cached, err := findCachedPrimeResult(ctx, txQueries, numberHash, accuracy)
+		if err != nil {
+			return 0, err
+		}
+		if cached != nil {
+			// A cached composite is factorized like a calculated one
+			factorizationStatus := sql.NullString{}
+			if cached.IsComposite() {
+				factorizationStatus = sql.NullString{String: model.FactorizationStatusPending, Valid: true}
+			}
+
+			result, err := txQueries.CreateCachedPrimeCheck(ctx, generated_sql.CreateCachedPrimeCheckParams{
+				UserID:              userID,
+				BatchID:             batchID,
+				NumberText:          numberText,
+				NumberHash:          sql.NullString{String: numberHash, Valid: true},
+				BitLength:           bitLength,
+				Expression:          sql.NullString{String: expression, Valid: true},
+				Operation:           sql.NullString{String: operation, Valid: true},
+				Accuracy:            sql.NullString{String: accuracy, Valid: true},
+				CallbackUrl:         callback,
+				IsPrime:             sql.NullBool{Bool: cached.IsPrime(), Valid: true},
+				Algorithm:           convertStringPtrToNullString(cached.Algorithm()),
+				AlgorithmVersion:    convertStringPtrToNullString(cached.AlgorithmVersion()),
+				AlgorithmParams:     convertStringMapToJSON(cached.AlgorithmParams()),
+				Confidence:          convertStringPtrToNullString(cached.Confidence()),
+				ErrorBound:          convertFloat64PtrToNullFloat64(cached.ErrorBound()),
+				FactorizationStatus: factorizationStatus,
+			})
+			if err != nil {
+				return 0, err
+			}
+
+			id, err := result.LastInsertId()
+			if err != nil {
+				return 0, err
+			}
+			if err := primecheckstatus.Record(ctx, txQueries, int32(id), "", primecheckstatus.Completed, primecheckstatus.ActorWebServer); err != nil {
+				return 0, err
+			}
+
+			if factorizationStatus.Valid {
+				if err := createFactorizationMessageInTx(ctx, txQueries, &message.FactorizationPayload{
+					RequestID:  int32(id),
+					UserID:     userID,
+					NumberText: numberText,
+				}); err != nil {
+					return 0, err
+				}
+			}
+
+			if callbackURL != "" {
+				isPrime := cached.IsPrime()
+				if err := createWebhookMessageInTx(ctx, txQueries, &message.WebhookDeliveryPayload{
+					RequestID:   int32(id),
+					UserID:      userID,
+					CallbackURL: callbackURL,
+					NumberText:  numberText,
+					Status:      string(primecheckstatus.Completed),
+					IsPrime:     &isPrime,
+					UpdatedAt:   time.Now(),
+				}); err != nil {
+					return 0, err
+				}
+			}
+			return int32(id), nil
+		}
+	}
+
+	// The worker moves a requested certificate on from pending once the number is checked
+	certificateStatus := sql.NullString{}
+	if certify {
+		certificateStatus = sql.NullString{String: string(certificatestatus.Pending), Valid: true}
+	}
+
 	result, err := txQueries.CreatePrimeCheck(ctx, generated_sql.CreatePrimeCheckParams{
-		UserID:     userID,
-		NumberText: numberText,
+		UserID:             userID,
+		BatchID:            batchID,
+		NumberText:         numberText,
+		NumberHash:         sql.NullString{String: numberHash, Valid: true},
+		BitLength:          bitLength,
+		Expression:         sql.NullString{String: expression, Valid: true},
+		Operation:          sql.NullString{String: operation, Valid: true},
+		Accuracy:           sql.NullString{String: accuracy, Valid: true},
+		CallbackUrl:        callback,
+		RequestedAlgorithm: sql.NullString{String: algorithm, Valid: algorithm != ""},
+		TimeoutSeconds:     sql.NullInt32{Int32: int32(timeout / time.Second), Valid: timeout > 0},
+		CertificateStatus:  certificateStatus,
 	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	if err := primecheckstatus.Record(ctx, txQueries, int32(id), "", primecheckstatus.Queued, primecheckstatus.ActorWebServer); err != nil {
+		return 0, err
 	}
 
 	// Create message for prime check worker with trace context
-	payload := &message.PrimeCheckPayload{
-		RequestID:  int32(id),
-		UserID:     userID,
-		NumberText: numberText,
+	if err := createPrimeCheckMessageInTx(ctx, txQueries, &message.PrimeCheckPayload{
+		RequestID:      int32(id),
+		UserID:         userID,
+		NumberText:     numberText,
+		Operation:      operation,
+		Accuracy:       accuracy,
+		Algorithm:      algorithm,
+		TimeoutSeconds: int32(timeout / time.Second),
+		Certify:        certify,
+		CallbackURL:    callbackURL,
+	}); err != nil {
+		return 0, err
 	}
 
-	msg, err := message.NewMessageWithTraceContext(ctx, message.MessageTypePrimeCheck, payload)
-	if err != nil {
-		return nil, err
-	}
+	return int32(id), nil
+}
 
-	msgBytes, err := json.Marshal(msg)
+// createPrimeCheckMessageInTx queues a prime check for the prime check worker.
+func createPrimeCheckMessageInTx(ctx context.Context, txQueries *generated_sql.Queries, payload *message.PrimeCheckPayload) error {
+	msgBytes, err := marshalMessage(ctx, message.MessageTypePrimeCheck, payload)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Save message to outbox
-	if _, err := txQueries.CreateOutboxMessage(ctx, generated_sql.CreateOutboxMessageParams{
+	_, err = txQueries.CreateOutboxMessage(ctx, generated_sql.CreateOutboxMessageParams{
 		EventType: string(message.MessageTypePrimeCheck),
 		Payload:   msgBytes,
-	}); err != nil {
-		return nil, err
+	})
+	return err
+}
+
+// createFactorizationMessageInTx queues a composite for the factorization worker.
+func createFactorizationMessageInTx(ctx context.Context, txQueries *generated_sql.Queries, payload *message.FactorizationPayload) error {
+	msgBytes, err := marshalMessage(ctx, message.MessageTypeFactorization, payload)
+	if err != nil {
+		return err
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return nil, err
+	_, err = txQueries.CreateOutboxMessage(ctx, generated_sql.CreateOutboxMessageParams{
+		EventType: string(message.MessageTypeFactorization),
+		Payload:   msgBytes,
+	})
+	return err
+}
+
+// createWebhookMessageInTx queues the delivery of a result to its callback URL.
+func createWebhookMessageInTx(ctx context.Context, txQueries *generated_sql.Queries, payload *message.WebhookDeliveryPayload) error {
+	msgBytes, err := marshalMessage(ctx, message.MessageTypeWebhookDelivery, payload)
+	if err != nil {
+		return err
 	}
 
-	// Return the created prime check
-	check, err := r.queries.GetPrimeCheck(ctx, int32(id))
+	_, err = txQueries.CreateOutboxMessage(ctx, generated_sql.CreateOutboxMessageParams{
+		EventType: string(message.MessageTypeWebhookDelivery),
+		Payload:   msgBytes,
+	})
+	return err
+}
+
+// findCachedPrimeResult returns a cached verdict for the number that is good
+// enough for the requested accuracy, or nil when there is none.
+func findCachedPrimeResult(ctx context.Context, queries *generated_sql.Queries, numberHash, accuracy string) (*model.CachedPrimeResult, error) {
+	rows, err := queries.ListCachedPrimeResults(ctx, numberHash)
 	if err != nil {
 		return nil, err
 	}
+	return selectCachedPrimeResult(rows, accuracy), nil
+}
 
-	return model.NewPrimeCheckWithExtras(
-		check.ID, 
-		check.UserID, 
-		check.NumberText, 
-		check.CreatedAt, 
-		check.UpdatedAt,
-		convertNullStringToPtr(check.TraceID),
-		convertNullStringToPtr(check.MessageID),
-		convertNullBoolToPtr(check.IsPrime),
-		convertNullStringToPtr(check.Status),
+// selectCachedPrimeResult returns the first of the cached verdicts for a
+// number that is good enough for the requested accuracy, or nil.
+func selectCachedPrimeResult(rows []generated_sql.PrimeResultCache, accuracy string) *model.CachedPrimeResult {
+	for _, row := range rows {
+		cached := model.NewCachedPrimeResult(
+			row.NumberText,
+			row.Accuracy,
+			row.IsPrime,
+			convertNullStringToPtr(row.Algorithm),
+			convertNullStringToPtr(row.AlgorithmVersion),
+			convertJSONToStringMap(row.AlgorithmParams),
+			convertNullStringToPtr(row.Confidence),
+			convertNullFloat64ToPtr(row.ErrorBound),
+		)
+		if cached.Satisfies(accuracy) {
+			return cached
+		}
+	}
+	return nil
+}
+
+func (r *Repository) GetPrimeCertificate(ctx context.Context, requestID int32) (*model.PrimeCertificate, error) {
+	row, err := r.queries.GetPrimeCertificate(ctx, requestID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.convertMissingCertificate(ctx, requestID)
+	}
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	var certificate struct {
+		Number string                  `json:"number"`
+		Steps  []model.CertificateStep `json:"steps"`
+	}
+	if err := json.Unmarshal(row.Certificate, &certificate); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return model.NewPrimeCertificate(
+		row.PrimeCheckID,
+		certificate.Number,
+		row.Method,
+		certificate.Steps,
+		row.CreatedAt,
 	), nil
 }
+
+// convertMissingCertificate explains why a prime check has no certificate: the
+// check does not exist, the worker is still certifying it, or it never will.
+func (r *Repository) convertMissingCertificate(ctx context.Context, requestID int32) error {
+	test, err := r.queries.GetPrimeCheck(ctx, requestID)
+	if err != nil {
+		return convertError(err, model.ErrPrimeCheckNotFound)
+	}
+	if certificatestatus.Status(test.CertificateStatus.String) == certificatestatus.Pending {
+		return model.ErrPrimeCertificatePending
+	}
+	return model.ErrPrimeCertificateNotFound
+}
+
+func convertPrimeCheck(row generated_sql.PrimeCheck) *model.PrimeCheck {
+	return model.NewPrimeCheckWithExtras(
+		row.ID,
+		row.UserID,
+		convertNullInt32ToPtr(row.BatchID),
+		row.NumberText,
+		convertNullInt32ToPtr(row.BitLength),
+		convertNullStringToPtr(row.Expression),
+		convertNullStringToPtr(row.Operation),
+		convertNullStringToPtr(row.Accuracy),
+		convertNullStringToPtr(row.CallbackUrl),
+		convertSecondsToDurationPtr(row.TimeoutSeconds),
+		row.CreatedAt,
+		row.UpdatedAt,
+		convertNullStringToPtr(row.TraceID),
+		convertNullStringToPtr(row.MessageID),
+		convertNullBoolToPtr(row.IsPrime),
+		convertNullStringToPtr(row.Algorithm),
+		convertNullStringToPtr(row.AlgorithmVersion),
+		convertJSONToStringMap(row.AlgorithmParams),
+		convertNullStringToPtr(row.FoundPrime),
+		convertNullInt64ToPtr(row.PrimeGap),
+		convertNullStringToPtr(row.Confidence),
+		convertNullFloat64ToPtr(row.ErrorBound),
+		convertNullStringToPtr(row.WorkerID),
+		convertNullTimeToPtr(row.StartedAt),
+		convertNullTimeToPtr(row.FinishedAt),
+		convertMicrosecondsToDurationPtr(row.CalculationUs),
+		convertNullStringToPtr(row.Status),
+		convertNullStringToPtr(row.CertificateStatus),
+		convertNullStringToPtr(row.FactorizationStatus),
+		row.Cached,
+	)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// GetSetting returns the stored settings, in which every step succeeds until they are first updated.
+func (r *Repository) GetSetting(ctx context.Context) (*model.Setting, error) {
+	settings, err := r.faults.Settings(ctx)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return model.NewSetting(
+		settings.RecordNumberSuccess,
+		settings.PrimeCheckSuccess,
+		settings.EmailSendSuccess,
+		settings.DLQSaveSuccess,
+	), nil
+}
+
+func (r *Repository) UpdateSetting(ctx context.Context, setting *model.Setting) (*model.Setting, error) {
+	if err := r.queries.UpsertSettings(ctx, generated_sql.UpsertSettingsParams{
+		RecordNumberSuccess: setting.RecordNumberSuccess(),
+		PrimeCheckSuccess:   setting.PrimeCheckSuccess(),
+		EmailSendSuccess:    setting.EmailSendSuccess(),
+		DlqSaveSuccess:      setting.DLQSaveSuccess(),
+	}); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return r.GetSetting(ctx)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+func (r *Repository) CreateUser(ctx context.Context, authTokenHash, webhookSecret string) (*model.User, error) {
+	if _, err := r.queries.CreateUser(ctx, generated_sql.CreateUserParams{
+		AuthTokenHash: authTokenHash,
+		WebhookSecret: sql.NullString{String: webhookSecret, Valid: true},
+	}); err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	// The token hash is unique, so it finds the user just created
+	user, err := r.queries.GetUserByAuthTokenHash(ctx, authTokenHash)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	return convertUser(user), nil
+}
+
+// GetUserByAuthTokenHash returns ErrUnauthenticated when no user has the token.
+func (r *Repository) GetUserByAuthTokenHash(ctx context.Context, authTokenHash string) (*model.User, error) {
+	user, err := r.queries.GetUserByAuthTokenHash(ctx, authTokenHash)
+	if err != nil {
+		return nil, convertError(err, model.ErrUnauthenticated)
+	}
+
+	return convertUser(user), nil
+}
+
+// UpdateUserAuthTokenHash replaces the token of the user, so that the previous one stops working.
+func (r *Repository) UpdateUserAuthTokenHash(ctx context.Context, userID int32, authTokenHash string) error {
+	if err := r.queries.UpdateUserAuthTokenHash(ctx, generated_sql.UpdateUserAuthTokenHashParams{
+		AuthTokenHash: authTokenHash,
+		ID:            userID,
+	}); err != nil {
+		return convertError(err, nil)
+	}
+	return nil
+}
+
+// UpdateUserWebhookSecret replaces the webhook secret of the user.
+func (r *Repository) UpdateUserWebhookSecret(ctx context.Context, userID int32, webhookSecret string) error {
+	if err := r.queries.UpdateUserWebhookSecret(ctx, generated_sql.UpdateUserWebhookSecretParams{
+		WebhookSecret: sql.NullString{String: webhookSecret, Valid: true},
+		ID:            userID,
+	}); err != nil {
+		return convertError(err, nil)
+	}
+	return nil
+}
+
+func convertUser(row generated_sql.User) *model.User {
+	return model.NewUser(row.ID, row.Role, row.CreatedAt)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+func (r *Repository) ListWebhookDeliveries(ctx context.Context, requestID int32) ([]*model.WebhookDelivery, error) {
+	rows, err := r.queries.ListWebhookDeliveriesByPrimeCheck(ctx, requestID)
+	if err != nil {
+		return nil, convertError(err, nil)
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, model.NewWebhookDelivery(
+			row.ID,
+			row.PrimeCheckID,
+			row.Url,
+			row.Attempt,
+			convertNullInt32ToPtr(row.StatusCode),
+			convertNullStringToPtr(row.ErrorMessage),
+			row.Success,
+			time.Duration(row.DurationUs)*time.Microsecond,
+			row.CreatedAt,
+		))
+	}
+	return deliveries, nil
+}
@@ -2,12 +2,36 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/ponyo877/prime-checker/internal/web/model"
 )
 
 type Repository interface {
 	GetPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error)
-	ListPrimeChecks(ctx context.Context) ([]*model.PrimeCheck, error)
-	CreatePrimeCheckWithMessage(ctx context.Context, userID int32, numberText string) (*model.PrimeCheck, error)
+	ListPrimeChecks(ctx context.Context, filter model.PrimeCheckFilter, order string, after *model.PrimeCheckCursor, limit int32) ([]*model.PrimeCheck, error)
+	CreatePrimeCheckWithMessage(ctx context.Context, userID int32, idempotencyKey *model.IdempotencyKey, numberText, expression, operation, accuracy, algorithm, callbackURL string, timeout time.Duration, certify, useCache bool) (*model.PrimeCheck, bool, error)
+	CancelPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error)
+	RetryPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error)
+	GetPrimeCertificate(ctx context.Context, requestID int32) (*model.PrimeCertificate, error)
+	GetPrimeCheckBatch(ctx context.Context, id int32) (*model.PrimeCheckBatch, error)
+	CreatePrimeCheckBatchWithMessages(ctx context.Context, userID int32, numberTexts, expressions []string, operation, accuracy string, certify, useCache bool) (*model.PrimeCheckBatch, error)
+	CreatePrimeCheckBatchFromFile(ctx context.Context, userID int32, sourceFormat, operation, accuracy string, certify, useCache bool, produce func(add func(numberText, expression string) error) error) (*model.PrimeCheckBatch, error)
+	ListPrimeCheckBatchResultsAfter(ctx context.Context, batchID, afterID, limit int32) ([]*model.PrimeCheck, error)
+	GetPrimeRange(ctx context.Context, id int32) (*model.PrimeRange, error)
+	CreatePrimeRangeWithMessage(ctx context.Context, userID int32, start, end uint64, countOnly bool) (*model.PrimeRange, error)
+	ListPrimeRangePrimes(ctx context.Context, rangeID int32, offset, limit int64) ([]uint64, error)
+	CreateUser(ctx context.Context, authTokenHash, webhookSecret string) (*model.User, error)
+	GetUserByAuthTokenHash(ctx context.Context, authTokenHash string) (*model.User, error)
+	UpdateUserAuthTokenHash(ctx context.Context, userID int32, authTokenHash string) error
+	UpdateUserWebhookSecret(ctx context.Context, userID int32, webhookSecret string) error
+	ListWebhookDeliveries(ctx context.Context, requestID int32) ([]*model.WebhookDelivery, error)
+	ListPrimeCheckEvents(ctx context.Context, requestID int32) ([]*model.PrimeCheckEvent, error)
+	GetSetting(ctx context.Context) (*model.Setting, error)
+	UpdateSetting(ctx context.Context, setting *model.Setting) (*model.Setting, error)
+}
+
+type PrimeCheckResultEvents interface {
+	LastPrimeCheckResultEventID(ctx context.Context) (uint64, error)
+	WatchPrimeCheckResultEvents(ctx context.Context, afterID uint64, handle func(event *model.PrimeCheckResultEvent) error) error
 }
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+const (
+	// Largest number of prime checks accepted in one batch, which bounds the size of its transaction
+	maxPrimeCheckBatchSize = 10000
+)
+
+// GetPrimeCheckBatch returns a batch of the user, or of any user for an
+// admin, and reports the batches of other users as not found.
+func (u *Usecase) GetPrimeCheckBatch(ctx context.Context, user *model.User, id int32) (*model.PrimeCheckBatch, error) {
+	batch, err := u.repo.GetPrimeCheckBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanAccess(batch.UserID()) {
+		return nil, model.ErrPrimeCheckBatchNotFound
+	}
+	return batch, nil
+}
+
+// ListPrimeCheckBatchResults returns a page of the prime checks of the batch
+// in the order they were submitted, together with the cursor of the next page
+// or an empty one on the last page. A limit of 0 selects the default page
+// size, and an unknown batch is ErrPrimeCheckBatchNotFound rather than an
+// empty list.
+func (u *Usecase) ListPrimeCheckBatchResults(ctx context.Context, user *model.User, batchID int32, cursor string, limit int32) ([]*model.PrimeCheck, string, error) {
+	if limit < 0 || limit > maxPrimeCheckPageSize {
+		return nil, "", fmt.Errorf("%w: limit must be non-negative and at most %d", model.ErrInvalidListFilter, maxPrimeCheckPageSize)
+	}
+	if limit == 0 {
+		limit = defaultPrimeCheckPageSize
+	}
+
+	afterID := int32(0)
+	if cursor != "" {
+		after, err := model.ParsePrimeCheckBatchCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		afterID = after.ID()
+	}
+
+	if _, err := u.GetPrimeCheckBatch(ctx, user, batchID); err != nil {
+		return nil, "", err
+	}
+
+	// The row after the page tells whether there is a next one
+	checks, err := u.repo.ListPrimeCheckBatchResultsAfter(ctx, batchID, afterID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(checks) <= int(limit) {
+		return checks, "", nil
+	}
+
+	checks = checks[:limit]
+	return checks, model.NewPrimeCheckBatchCursor(checks[len(checks)-1].ID()).Encode(), nil
+}
+
+// CreatePrimeCheckBatchWithMessages validates every input up front, so that a
+// single bad number rejects the whole batch before anything is queued.
+func (u *Usecase) CreatePrimeCheckBatchWithMessages(ctx context.Context, userID int32, inputs []string, operation, accuracy string, certify, bypassCache bool) (*model.PrimeCheckBatch, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "CreatePrimeCheckBatchWithMessages")
+	defer span.End()
+
+	if len(inputs) == 0 || len(inputs) > maxPrimeCheckBatchSize {
+		err := fmt.Errorf("%w: a batch must contain between 1 and %d numbers", model.ErrInvalidBatch, maxPrimeCheckBatchSize)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if operation == "" {
+		operation = model.PrimeOperationIsPrime
+	}
+	accuracy, err := validateAccuracy(accuracy)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	numberTexts := make([]string, len(inputs))
+	for i, input := range inputs {
+		number, err := evaluateNumber(input)
+		if err == nil {
+			err = validateOperation(number, operation)
+		}
+		if err != nil {
+			err = fmt.Errorf("number %d: %w", i, err)
+			span.RecordError(err)
+			return nil, err
+		}
+		numberTexts[i] = number.String()
+	}
+
+	result, err := u.repo.CreatePrimeCheckBatchWithMessages(ctx, userID, numberTexts, inputs, operation, accuracy, certify, useResultCache(operation, certify, bypassCache))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// CancelPrimeCheck cancels a processing prime check. The prime check worker
+// stops calculating it, or skips it when it is still queued, and discards its
+// result. A finished prime check fails with ErrPrimeCheckNotCancellable.
+func (u *Usecase) CancelPrimeCheck(ctx context.Context, user *model.User, id int32) (*model.PrimeCheck, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "CancelPrimeCheck")
+	defer span.End()
+
+	// Also reports a foreign prime check as not found
+	if _, err := u.GetPrimeCheck(ctx, user, id); err != nil {
+		return nil, err
+	}
+	return u.repo.CancelPrimeCheck(ctx, id)
+}
+
+// RetryPrimeCheck queues a failed, timed out or cancelled prime check again
+// under its ID. Any other prime check fails with ErrPrimeCheckNotRetryable.
+func (u *Usecase) RetryPrimeCheck(ctx context.Context, user *model.User, id int32) (*model.PrimeCheck, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "RetryPrimeCheck")
+	defer span.End()
+
+	if _, err := u.GetPrimeCheck(ctx, user, id); err != nil {
+		return nil, err
+	}
+	return u.repo.RetryPrimeCheck(ctx, id)
+}
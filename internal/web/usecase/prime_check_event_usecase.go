@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// ListPrimeCheckEvents returns the status transitions of a prime check,
+// oldest first.
+func (u *Usecase) ListPrimeCheckEvents(ctx context.Context, user *model.User, requestID int32) ([]*model.PrimeCheckEvent, error) {
+	// Also reports a foreign prime check as not found
+	if _, err := u.GetPrimeCheck(ctx, user, requestID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListPrimeCheckEvents(ctx, requestID)
+}
@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/internal/shared/expression"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+const (
+	// Largest number of prime checks read from one uploaded file
+	maxPrimeCheckFileSize = 1_000_000
+	// Largest uploaded file in bytes, read up to this point and rejected past it
+	maxPrimeCheckFileBytes = 64 << 20
+	// Longest NDJSON line accepted, leaving room for JSON escaping of the longest expression
+	maxPrimeCheckFileLineLength = 2 * expression.MaxLength
+	// Prime checks read per query while writing a result file
+	primeCheckFilePageSize = 1000
+)
+
+// primeCheckFileReader returns the next number of an uploaded file with its
+// line number, or io.EOF after the last one.
+type primeCheckFileReader func() (input string, line int, err error)
+
+// primeCheckSpoolRecord is a validated number of an uploaded file, kept on disk
+// until the whole file has been read.
+type primeCheckSpoolRecord struct {
+	Number string `json:"number"`
+	Input  string `json:"input"`
+}
+
+// primeCheckFileRecord is one line of a result file.
+type primeCheckFileRecord struct {
+	Input      string    `json:"input"`
+	Number     string    `json:"number"`
+	IsPrime    *bool     `json:"is_prime,omitempty"`
+	FoundPrime *string   `json:"found_prime,omitempty"`
+	Status     *string   `json:"status,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	DurationMs *int64    `json:"duration_ms,omitempty"`
+}
+
+// CreatePrimeCheckBatchFromFile streams a CSV or NDJSON file of numbers into a
+// new batch. Every number is validated as it is read and spooled to a
+// temporary file, so that a bad line rejects the whole file before anything
+// is queued, and only then are the numbers handed to the repository, which
+// commits them a chunk at a time while the batch tracks the upload.
+func (u *Usecase) CreatePrimeCheckBatchFromFile(ctx context.Context, userID int32, format string, file io.Reader, operation, accuracy string, certify, bypassCache bool) (*model.PrimeCheckBatch, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "CreatePrimeCheckBatchFromFile")
+	defer span.End()
+
+	limited := newLimitedFileReader(file, maxPrimeCheckFileBytes)
+	read, err := newPrimeCheckFileReader(format, limited)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if operation == "" {
+		operation = model.PrimeOperationIsPrime
+	}
+	accuracy, err = validateAccuracy(accuracy)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	spool, err := os.CreateTemp("", "prime-check-upload-*.ndjson")
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	if err := spoolPrimeCheckFile(read, spool, operation); err != nil {
+		// The line cut off at the limit may have failed before the limit was reported
+		if limited.Exceeded() {
+			err = fmt.Errorf("%w: a file must be at most %d bytes", model.ErrInvalidBatch, maxPrimeCheckFileBytes)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(spool))
+	result, err := u.repo.CreatePrimeCheckBatchFromFile(ctx, userID, format, operation, accuracy, certify, useResultCache(operation, certify, bypassCache), func(add func(numberText, expression string) error) error {
+		for {
+			var record primeCheckSpoolRecord
+			if err := decoder.Decode(&record); errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			if err := add(record.Number, record.Input); err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// spoolPrimeCheckFile validates every number read and writes it to spool,
+// failing on the first bad line.
+func spoolPrimeCheckFile(read primeCheckFileReader, spool io.Writer, operation string) error {
+	writer := bufio.NewWriter(spool)
+	encoder := json.NewEncoder(writer)
+
+	count := 0
+	for {
+		input, line, err := read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: line %d: %v", model.ErrInvalidBatch, line, err)
+		}
+
+		count++
+		if count > maxPrimeCheckFileSize {
+			return fmt.Errorf("%w: a file must contain at most %d numbers", model.ErrInvalidBatch, maxPrimeCheckFileSize)
+		}
+
+		number, err := evaluateNumber(input)
+		if err == nil {
+			err = validateOperation(number, operation)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if err := encoder.Encode(primeCheckSpoolRecord{Number: number.String(), Input: input}); err != nil {
+			return err
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w: the file contains no numbers", model.ErrInvalidBatch)
+	}
+	return writer.Flush()
+}
+
+// WritePrimeCheckBatchFile writes one line per prime check of the batch to w,
+// in the format the batch was uploaded in, reading the batch page by page.
+func (u *Usecase) WritePrimeCheckBatchFile(ctx context.Context, batch *model.PrimeCheckBatch, w io.Writer) error {
+	var write func(record primeCheckFileRecord) error
+	var flush func() error
+	switch batch.FileFormat() {
+	case model.PrimeCheckFileFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"input", "number", "is_prime", "found_prime", "status", "created_at", "updated_at", "duration_ms"}); err != nil {
+			return err
+		}
+		write = func(record primeCheckFileRecord) error {
+			return writer.Write([]string{
+				record.Input,
+				record.Number,
+				formatOptional(record.IsPrime, strconv.FormatBool),
+				formatOptional(record.FoundPrime, func(s string) string { return s }),
+				formatOptional(record.Status, func(s string) string { return s }),
+				record.CreatedAt.Format(time.RFC3339),
+				record.UpdatedAt.Format(time.RFC3339),
+				formatOptional(record.DurationMs, func(ms int64) string { return strconv.FormatInt(ms, 10) }),
+			})
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		encoder := json.NewEncoder(w)
+		write = func(record primeCheckFileRecord) error {
+			return encoder.Encode(record)
+		}
+		flush = func() error { return nil }
+	}
+
+	afterID := int32(0)
+	for {
+		checks, err := u.repo.ListPrimeCheckBatchResultsAfter(ctx, batch.ID(), afterID, primeCheckFilePageSize)
+		if err != nil {
+			return err
+		}
+		if len(checks) == 0 {
+			return flush()
+		}
+
+		for _, check := range checks {
+			if err := write(newPrimeCheckFileRecord(check)); err != nil {
+				return err
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		afterID = checks[len(checks)-1].ID()
+	}
+}
+
+func newPrimeCheckFileReader(format string, file io.Reader) (primeCheckFileReader, error) {
+	switch format {
+	case model.PrimeCheckFileFormatCSV:
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		reader.ReuseRecord = true
+		first := true
+		return func() (string, int, error) {
+			for {
+				record, err := reader.Read()
+				if err != nil {
+					var parseErr *csv.ParseError
+					if errors.As(err, &parseErr) {
+						return "", parseErr.Line, parseErr.Err
+					}
+					return "", 0, err
+				}
+				line, _ := reader.FieldPos(0)
+
+				// The number is in the first column, under an optional "number" header
+				input := strings.TrimSpace(record[0])
+				if first {
+					first = false
+					if strings.EqualFold(input, "number") {
+						continue
+					}
+				}
+				return input, line, nil
+			}
+		}, nil
+	case model.PrimeCheckFileFormatNDJSON:
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxPrimeCheckFileLineLength)
+		line := 0
+		return func() (string, int, error) {
+			for scanner.Scan() {
+				line++
+				if strings.TrimSpace(scanner.Text()) == "" {
+					continue
+				}
+
+				var record struct {
+					Number *string `json:"number"`
+				}
+				if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+					return "", line, err
+				}
+				if record.Number == nil {
+					return "", line, errors.New(`missing "number"`)
+				}
+				return *record.Number, line, nil
+			}
+			if err := scanner.Err(); err != nil {
+				return "", line + 1, err
+			}
+			return "", line, io.EOF
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported file format %q", model.ErrInvalidBatch, format)
+	}
+}
+
+// limitedFileReader fails once more than limit bytes are read. io.LimitReader
+// alone would end the file at the limit and let a truncated file pass as a
+// valid one, so it is given one byte more to tell a file of exactly limit
+// bytes from a longer one.
+type limitedFileReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func newLimitedFileReader(file io.Reader, limit int64) *limitedFileReader {
+	return &limitedFileReader{reader: io.LimitReader(file, limit+1), limit: limit}
+}
+
+func (r *limitedFileReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.Exceeded() {
+		return n, fmt.Errorf("file larger than %d bytes", r.limit)
+	}
+	return n, err
+}
+
+// Exceeded reports whether the file turned out larger than the limit.
+func (r *limitedFileReader) Exceeded() bool {
+	return r.read > r.limit
+}
+
+func newPrimeCheckFileRecord(check *model.PrimeCheck) primeCheckFileRecord {
+	input := check.NumberText()
+	if check.Expression() != nil {
+		input = *check.Expression()
+	}
+
+	// The time the worker spent calculating, without the time the check was
+	// queued, and unknown until a worker has finished it
+	var durationMs *int64
+	if calculationTime := check.CalculationTime(); calculationTime != nil {
+		ms := calculationTime.Milliseconds()
+		durationMs = &ms
+	}
+
+	return primeCheckFileRecord{
+		Input:      input,
+		Number:     check.NumberText(),
+		IsPrime:    check.IsPrime(),
+		FoundPrime: check.FoundPrime(),
+		Status:     check.Status(),
+		CreatedAt:  check.CreatedAt(),
+		UpdatedAt:  check.UpdatedAt(),
+		DurationMs: durationMs,
+	}
+}
+
+func formatOptional[T any](value *T, format func(T) string) string {
+	if value == nil {
+		return ""
+	}
+	return format(*value)
+}
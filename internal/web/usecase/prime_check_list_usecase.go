@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+const (
+	// Page size used when the client does not ask for one, and the largest page served
+	defaultPrimeCheckPageSize = 50
+	maxPrimeCheckPageSize     = 500
+	// Longest number prefix a list can be filtered by, which the prefix index covers
+	maxPrimeCheckNumberPrefixLength = 64
+)
+
+// ListPrimeChecks returns a page of the prime checks that match filter, only
+// of the user unless it is an admin, together with the cursor of the next page
+// or an empty one on the last page. The newest prime checks come first unless
+// order is asc, and a limit of 0 selects the default page size.
+func (u *Usecase) ListPrimeChecks(ctx context.Context, user *model.User, filter model.PrimeCheckFilter, order, cursor string, limit int32) ([]*model.PrimeCheck, string, error) {
+	if err := validatePrimeCheckFilter(filter, order, limit); err != nil {
+		return nil, "", err
+	}
+	if order == "" {
+		order = model.SortOrderDesc
+	}
+	if limit == 0 {
+		limit = defaultPrimeCheckPageSize
+	}
+
+	var after *model.PrimeCheckCursor
+	if cursor != "" {
+		var err error
+		if after, err = model.ParsePrimeCheckCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	filter.UserID = nil
+	if !user.IsAdmin() {
+		userID := user.ID()
+		filter.UserID = &userID
+	}
+
+	// The row after the page tells whether there is a next one
+	checks, err := u.repo.ListPrimeChecks(ctx, filter, order, after, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(checks) <= int(limit) {
+		return checks, "", nil
+	}
+
+	checks = checks[:limit]
+	last := checks[len(checks)-1]
+	return checks, model.NewPrimeCheckCursor(last.CreatedAt(), last.ID()).Encode(), nil
+}
+
+func validatePrimeCheckFilter(filter model.PrimeCheckFilter, order string, limit int32) error {
+	switch order {
+	case "", model.SortOrderAsc, model.SortOrderDesc:
+	default:
+		return fmt.Errorf("%w: order must be %s or %s", model.ErrInvalidListFilter, model.SortOrderAsc, model.SortOrderDesc)
+	}
+
+	if limit < 0 || limit > maxPrimeCheckPageSize {
+		return fmt.Errorf("%w: limit must be non-negative and at most %d", model.ErrInvalidListFilter, maxPrimeCheckPageSize)
+	}
+
+	if filter.Status != nil && !primecheckstatus.Status(*filter.Status).IsValid() {
+		return fmt.Errorf("%w: unknown status %q", model.ErrInvalidListFilter, *filter.Status)
+	}
+
+	if (filter.MinDigits != nil && *filter.MinDigits < 1) || (filter.MaxDigits != nil && *filter.MaxDigits < 1) {
+		return fmt.Errorf("%w: digit lengths must be positive", model.ErrInvalidListFilter)
+	}
+	if filter.MinDigits != nil && filter.MaxDigits != nil && *filter.MinDigits > *filter.MaxDigits {
+		return fmt.Errorf("%w: min_digits must not exceed max_digits", model.ErrInvalidListFilter)
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return fmt.Errorf("%w: created_from must be before created_to", model.ErrInvalidListFilter)
+	}
+
+	if filter.NumberPrefix != nil {
+		prefix := *filter.NumberPrefix
+		if prefix == "" || len(prefix) > maxPrimeCheckNumberPrefixLength {
+			return fmt.Errorf("%w: number_prefix must have 1 to %d digits", model.ErrInvalidListFilter, maxPrimeCheckNumberPrefixLength)
+		}
+		for _, r := range prefix {
+			if r < '0' || r > '9' {
+				return fmt.Errorf("%w: number_prefix must only contain decimal digits", model.ErrInvalidListFilter)
+			}
+		}
+	}
+
+	return nil
+}
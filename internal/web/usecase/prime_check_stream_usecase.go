@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// errStreamEnded stops watching once the prime check a stream follows is final.
+var errStreamEnded = errors.New("prime check stream ended")
+
+// OpenPrimeCheckStream checks that the user may follow the prime check
+// requestID, or all of its own prime checks when requestID is nil, and returns
+// where the stream starts: after lastEventID when a client resumes, and after
+// the latest event otherwise. The stream of a single prime check also carries
+// its current status, read after the starting point so that no event is missed.
+func (u *Usecase) OpenPrimeCheckStream(ctx context.Context, user *model.User, requestID *int32, lastEventID string) (*model.PrimeCheckStream, error) {
+	var afterEventID uint64
+	if lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", model.ErrInvalidEventID, lastEventID)
+		}
+		afterEventID = id
+	} else {
+		id, err := u.events.LastPrimeCheckResultEventID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		afterEventID = id
+	}
+
+	if requestID == nil {
+		return model.NewPrimeCheckStream(nil, afterEventID, nil), nil
+	}
+
+	check, err := u.GetPrimeCheck(ctx, user, *requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := string(primecheckstatus.Queued)
+	if check.Status() != nil {
+		status = *check.Status()
+	}
+	// Only a completed check has a verdict
+	var isPrime *bool
+	if status == string(primecheckstatus.Completed) {
+		isPrime = check.IsPrime()
+	}
+	current := model.NewPrimeCheckResultEvent(0, check.ID(), check.UserID(), status, isPrime, check.UpdatedAt())
+
+	return model.NewPrimeCheckStream(requestID, afterEventID, current), nil
+}
+
+// FollowPrimeCheckStream passes the current status of the stream, if any, and
+// then every later result event the user may see to handle. It returns when ctx
+// is done or handle fails, and once a single prime check it follows is final.
+func (u *Usecase) FollowPrimeCheckStream(ctx context.Context, user *model.User, stream *model.PrimeCheckStream, handle func(event *model.PrimeCheckResultEvent) error) error {
+	if current := stream.Current(); current != nil {
+		if err := handle(current); err != nil {
+			return err
+		}
+		if current.IsFinal() {
+			return nil
+		}
+	}
+
+	err := u.events.WatchPrimeCheckResultEvents(ctx, stream.AfterEventID(), func(event *model.PrimeCheckResultEvent) error {
+		if !user.CanAccess(event.UserID()) {
+			return nil
+		}
+
+		requestID := stream.RequestID()
+		if requestID == nil {
+			return handle(event)
+		}
+		if event.RequestID() != *requestID {
+			return nil
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+		if event.IsFinal() {
+			return errStreamEnded
+		}
+		return nil
+	})
+	if errors.Is(err, errStreamEnded) {
+		return nil
+	}
+	return err
+}
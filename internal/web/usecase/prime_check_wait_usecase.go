@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/shared/primecheckstatus"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+const (
+	// Longest a request may wait for a prime check to leave processing, in seconds
+	maxPrimeCheckWaitSeconds = 60
+	// Pause before following the result events again after the broker failed
+	primeCheckNotifierRetryInterval = 5 * time.Second
+)
+
+// primeCheckNotifier wakes the requests waiting for prime checks when a result
+// event of one of them arrives, so that waiting never polls the database. A
+// single watch of the result events serves all waiters of the web server.
+type primeCheckNotifier struct {
+	mu      sync.Mutex
+	waiters map[int32]map[chan struct{}]struct{}
+}
+
+func newPrimeCheckNotifier() *primeCheckNotifier {
+	return &primeCheckNotifier{
+		waiters: map[int32]map[chan struct{}]struct{}{},
+	}
+}
+
+// wait returns a channel that is closed by the next result event of the prime
+// check requestID, and a function that stops waiting for it.
+func (n *primeCheckNotifier) wait(requestID int32) (<-chan struct{}, func()) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	done := make(chan struct{})
+	if n.waiters[requestID] == nil {
+		n.waiters[requestID] = map[chan struct{}]struct{}{}
+	}
+	n.waiters[requestID][done] = struct{}{}
+
+	return done, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.waiters[requestID], done)
+		if len(n.waiters[requestID]) == 0 {
+			delete(n.waiters, requestID)
+		}
+	}
+}
+
+func (n *primeCheckNotifier) notify(requestID int32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for done := range n.waiters[requestID] {
+		close(done)
+	}
+	delete(n.waiters, requestID)
+}
+
+// RunPrimeCheckNotifier follows the result events and wakes the requests
+// waiting for them until ctx is done. After a broker failure it follows them
+// again from the last event it saw; meanwhile waits end at their timeout.
+func (u *Usecase) RunPrimeCheckNotifier(ctx context.Context) {
+	var afterID uint64
+	started := false
+	for {
+		var err error
+		if !started {
+			afterID, err = u.events.LastPrimeCheckResultEventID(ctx)
+			started = err == nil
+		}
+		if started {
+			err = u.events.WatchPrimeCheckResultEvents(ctx, afterID, func(event *model.PrimeCheckResultEvent) error {
+				afterID = event.ID()
+				// Waits are for a result, which a check on its way to computing has not
+				if event.IsFinal() {
+					u.notifier.notify(event.RequestID())
+				}
+				return nil
+			})
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Failed to follow prime check result events, retrying in %v: %v", primeCheckNotifierRetryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(primeCheckNotifierRetryInterval):
+		}
+	}
+}
+
+// WaitPrimeCheck returns the prime check like GetPrimeCheck, but while it is
+// processing first waits up to waitSeconds for it to change. A wait of 0 does
+// not wait at all.
+func (u *Usecase) WaitPrimeCheck(ctx context.Context, user *model.User, id, waitSeconds int32) (*model.PrimeCheck, error) {
+	if waitSeconds < 0 || waitSeconds > maxPrimeCheckWaitSeconds {
+		return nil, fmt.Errorf("%w: wait must be between 0 and %d seconds", model.ErrInvalidWait, maxPrimeCheckWaitSeconds)
+	}
+
+	// Wait before reading, so that a change right after the read still wakes this request
+	changed, stop := u.notifier.wait(id)
+	defer stop()
+
+	check, err := u.GetPrimeCheck(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	if waitSeconds == 0 || (check.Status() != nil && primecheckstatus.Status(*check.Status()).IsFinal()) {
+		return check, nil
+	}
+
+	timer := time.NewTimer(time.Duration(waitSeconds) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return check, nil
+	case <-changed:
+		return u.GetPrimeCheck(ctx, user, id)
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+const (
+	// Largest range end accepted, which bounds the base prime table of the sieve
+	maxPrimeRangeEnd = 1_000_000_000_000_000
+	// Widest range whose primes are stored for listing
+	maxPrimeRangeListWidth = 100_000_000
+	// Widest range whose primes are only counted
+	maxPrimeRangeCountWidth = 10_000_000_000
+	// Page size used when the client does not ask for one, and the largest page served
+	defaultPrimeRangePageSize = 1000
+	maxPrimeRangePageSize     = 10000
+)
+
+// GetPrimeRange returns a range of the user, or of any user for an admin, and
+// reports the ranges of other users as not found.
+func (u *Usecase) GetPrimeRange(ctx context.Context, user *model.User, id int32) (*model.PrimeRange, error) {
+	primeRange, err := u.repo.GetPrimeRange(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanAccess(primeRange.UserID()) {
+		return nil, model.ErrPrimeRangeNotFound
+	}
+	return primeRange, nil
+}
+
+// CreatePrimeRangeWithMessage validates the bounds, which may be written as
+// expressions like the prime check input, and queues the range for the sieve worker.
+func (u *Usecase) CreatePrimeRangeWithMessage(ctx context.Context, userID int32, startInput, endInput string, countOnly bool) (*model.PrimeRange, error) {
+	tracer := otel.Tracer("web-server")
+	ctx, span := tracer.Start(ctx, "CreatePrimeRangeWithMessage")
+	defer span.End()
+
+	start, end, err := parsePrimeRange(startInput, endInput, countOnly)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	result, err := u.repo.CreatePrimeRangeWithMessage(ctx, userID, start, end, countOnly)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListPrimeRangePrimes returns up to limit primes of the range starting at the
+// zero-based position offset. A limit of 0 selects the default page size.
+func (u *Usecase) ListPrimeRangePrimes(ctx context.Context, user *model.User, rangeID int32, offset int64, limit int32) ([]uint64, error) {
+	if offset < 0 || limit < 0 || limit > maxPrimeRangePageSize {
+		return nil, fmt.Errorf("%w: offset must be non-negative and limit at most %d", model.ErrInvalidRange, maxPrimeRangePageSize)
+	}
+	if limit == 0 {
+		limit = defaultPrimeRangePageSize
+	}
+
+	if _, err := u.GetPrimeRange(ctx, user, rangeID); err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListPrimeRangePrimes(ctx, rangeID, offset, int64(limit))
+}
+
+func parsePrimeRange(startInput, endInput string, countOnly bool) (uint64, uint64, error) {
+	start, err := evaluateNumber(startInput)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := evaluateNumber(endInput)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if start.Sign() < 0 || start.Cmp(end) > 0 {
+		return 0, 0, fmt.Errorf("%w: start must be non-negative and not above end", model.ErrInvalidRange)
+	}
+	if end.Cmp(new(big.Int).SetUint64(maxPrimeRangeEnd)) > 0 {
+		return 0, 0, fmt.Errorf("%w: end must not exceed %d", model.ErrInvalidRange, uint64(maxPrimeRangeEnd))
+	}
+
+	maxWidth := uint64(maxPrimeRangeListWidth)
+	if countOnly {
+		maxWidth = maxPrimeRangeCountWidth
+	}
+	if end.Uint64()-start.Uint64() >= maxWidth {
+		return 0, 0, fmt.Errorf("%w: range must span fewer than %d numbers", model.ErrInvalidRange, maxWidth)
+	}
+
+	return start.Uint64(), end.Uint64(), nil
+}
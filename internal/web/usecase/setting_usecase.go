@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+func (u *Usecase) GetSetting(ctx context.Context) (*model.Setting, error) {
+	return u.repo.GetSetting(ctx)
+}
+
+// UpdateSetting replaces the settings. The services read them at every step,
+// so the change applies to messages already queued as well, which is why only
+// an admin may change them.
+func (u *Usecase) UpdateSetting(ctx context.Context, user *model.User, setting *model.Setting) (*model.Setting, error) {
+	if !user.IsAdmin() {
+		return nil, model.ErrAdminRequired
+	}
+	return u.repo.UpdateSetting(ctx, setting)
+}
@@ -2,40 +2,216 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
 
 	"go.opentelemetry.io/otel"
 
+	"github.com/ponyo877/prime-checker/internal/shared/expression"
+	"github.com/ponyo877/prime-checker/internal/shared/primality"
 	"github.com/ponyo877/prime-checker/internal/web/model"
 )
 
 type Usecase struct {
-	repo Repository
+	repo     Repository
+	events   PrimeCheckResultEvents
+	notifier *primeCheckNotifier
 }
 
-func NewUseCase(repo Repository) *Usecase {
+func NewUseCase(repo Repository, events PrimeCheckResultEvents) *Usecase {
 	return &Usecase{
-		repo: repo,
+		repo:     repo,
+		events:   events,
+		notifier: newPrimeCheckNotifier(),
 	}
 }
 
-func (u *Usecase) GetPrimeCheck(ctx context.Context, id int32) (*model.PrimeCheck, error) {
-	return u.repo.GetPrimeCheck(ctx, id)
+// GetPrimeCheck returns a prime check of the user, or of any user for an
+// admin. The prime checks of other users are reported as not found, so that
+// their existence does not leak.
+func (u *Usecase) GetPrimeCheck(ctx context.Context, user *model.User, id int32) (*model.PrimeCheck, error) {
+	check, err := u.repo.GetPrimeCheck(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanAccess(check.UserID()) {
+		return nil, model.ErrPrimeCheckNotFound
+	}
+	return check, nil
 }
 
-func (u *Usecase) ListPrimeChecks(ctx context.Context) ([]*model.PrimeCheck, error) {
-	return u.repo.ListPrimeChecks(ctx)
+func (u *Usecase) GetPrimeCertificate(ctx context.Context, user *model.User, requestID int32) (*model.PrimeCertificate, error) {
+	if _, err := u.GetPrimeCheck(ctx, user, requestID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetPrimeCertificate(ctx, requestID)
 }
 
-func (u *Usecase) CreatePrimeCheckWithMessage(ctx context.Context, userID int32, numberText string) (*model.PrimeCheck, error) {
+// CreatePrimeCheckWithMessage queues a prime check. With an idempotency key,
+// a retry of the same request returns the prime check the first one created
+// and reports it replayed, and a different request with the key fails with
+// ErrIdempotencyKeyReused.
+func (u *Usecase) CreatePrimeCheckWithMessage(ctx context.Context, userID int32, idempotencyKey, input, operation, accuracy, algorithm, callbackURL string, timeoutSeconds *int32, certify, bypassCache bool) (*model.PrimeCheck, bool, error) {
 	tracer := otel.Tracer("web-server")
 	ctx, span := tracer.Start(ctx, "CreatePrimeCheckWithMessage")
 	defer span.End()
 
-	result, err := u.repo.CreatePrimeCheckWithMessage(ctx, userID, numberText)
+	// The fingerprint covers the request as sent, before defaults are filled in
+	var key *model.IdempotencyKey
+	if idempotencyKey != "" {
+		var err error
+		key, err = model.NewIdempotencyKey(idempotencyKey, model.PrimeCheckRequestFingerprint(input, operation, accuracy, algorithm, callbackURL, timeoutSeconds, certify, bypassCache))
+		if err != nil {
+			span.RecordError(err)
+			return nil, false, err
+		}
+	}
+
+	// Evaluate here so that over-budget or malformed input never reaches the outbox
+	number, err := evaluateNumber(input)
 	if err != nil {
 		span.RecordError(err)
-		return nil, err
+		return nil, false, err
+	}
+
+	if operation == "" {
+		operation = model.PrimeOperationIsPrime
+	}
+	if err := validateOperation(number, operation); err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+
+	accuracy, err = validateAccuracy(accuracy)
+	if err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+
+	if err := validateAlgorithm(number, operation, algorithm); err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+
+	if err := validateCallbackURL(ctx, callbackURL); err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+
+	timeout, err := validateTimeout(timeoutSeconds)
+	if err != nil {
+		span.RecordError(err)
+		return nil, false, err
 	}
 
-	return result, nil
+	// A check that names its test asks for that test to run, so it never comes from the cache
+	useCache := algorithm == "" && useResultCache(operation, certify, bypassCache)
+	result, replayed, err := u.repo.CreatePrimeCheckWithMessage(ctx, userID, key, number.String(), input, operation, accuracy, algorithm, callbackURL, timeout, certify, useCache)
+	if err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+
+	return result, replayed, nil
+}
+
+func validateOperation(number *big.Int, operation string) error {
+	switch operation {
+	case model.PrimeOperationIsPrime, model.PrimeOperationNextPrime:
+		return nil
+	case model.PrimeOperationPrevPrime:
+		if number.Cmp(big.NewInt(2)) < 0 {
+			return fmt.Errorf("%w: there is no prime below %s", model.ErrInvalidOperation, number)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", model.ErrInvalidOperation, operation)
+	}
+}
+
+// useResultCache reports whether a cached verdict may answer the request. Only
+// plain checks qualify: a certificate or a prime search needs the worker.
+func useResultCache(operation string, certify, bypassCache bool) bool {
+	return !bypassCache && !certify && operation == model.PrimeOperationIsPrime
+}
+
+// evaluateNumber evaluates the number or expression of a request, reporting
+// malformed and oversized input as the domain errors the API maps to 400.
+func evaluateNumber(input string) (*big.Int, error) {
+	number, err := expression.Evaluate(input)
+	switch {
+	case errors.Is(err, expression.ErrLimit):
+		return nil, fmt.Errorf("%w: %w", model.ErrNumberTooLarge, err)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidNumber, err)
+	}
+	return number, nil
+}
+
+// validateAlgorithm accepts no algorithm, which lets the worker select the
+// test, or one of the tests the worker can be asked for by name, provided the
+// test can decide the number. The special form tests only decide numbers of
+// their form, so they cannot search for a prime.
+func validateAlgorithm(number *big.Int, operation, algorithm string) error {
+	var supported bool
+	switch algorithm {
+	case "", model.AlgorithmMillerRabin, model.AlgorithmBailliePSW:
+		supported = true
+	case model.AlgorithmTrialDivision:
+		supported = searchBound(number, operation).Cmp(primality.TrialDivisionLimit) < 0
+	case model.AlgorithmDeterministicMillerRabin:
+		supported = searchBound(number, operation).Cmp(primality.DeterministicMillerRabinBound) < 0
+	case model.AlgorithmAKS:
+		supported = number.Sign() >= 0 && searchBound(number, operation).BitLen() <= primality.AKSMaxBits
+	case model.AlgorithmLucasLehmer:
+		supported = operation == model.PrimeOperationIsPrime && primality.IsMersenneNumber(number)
+	case model.AlgorithmPepin:
+		supported = operation == model.PrimeOperationIsPrime && primality.IsFermatNumber(number)
+	case model.AlgorithmProth:
+		supported = operation == model.PrimeOperationIsPrime && primality.IsProthNumber(number)
+	default:
+		return fmt.Errorf("%w: %q", model.ErrInvalidAlgorithm, algorithm)
+	}
+
+	if !supported {
+		return fmt.Errorf("%w: %s for %s on a %d-bit number", model.ErrUnsupportedAlgorithm, algorithm, operation, number.BitLen())
+	}
+	return nil
+}
+
+// searchBound is the largest number the worker may test: the number itself,
+// or for next_prime a margin above it that prime gaps of this size stay under.
+func searchBound(number *big.Int, operation string) *big.Int {
+	if operation != model.PrimeOperationNextPrime {
+		return number
+	}
+	margin := big.NewInt(int64(number.BitLen())*int64(number.BitLen()) + 2)
+	return new(big.Int).Add(number, margin)
+}
+
+// validateTimeout returns the time budget to store for the seconds a request
+// asked for, capped at the longest budget the worker allows, or 0 to give the
+// check the longest budget when it asked for none.
+func validateTimeout(timeoutSeconds *int32) (time.Duration, error) {
+	if timeoutSeconds == nil {
+		return 0, nil
+	}
+	if *timeoutSeconds <= 0 {
+		return 0, fmt.Errorf("%w: %d seconds", model.ErrInvalidTimeout, *timeoutSeconds)
+	}
+	return min(time.Duration(*timeoutSeconds)*time.Second, primality.MaxTimeout), nil
+}
+
+// validateAccuracy returns the accuracy mode to store, standard when none is given.
+func validateAccuracy(accuracy string) (string, error) {
+	switch accuracy {
+	case "":
+		return model.AccuracyStandard, nil
+	case model.AccuracyFast, model.AccuracyStandard, model.AccuracyParanoid, model.AccuracyDeterministic:
+		return accuracy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", model.ErrInvalidAccuracy, accuracy)
+	}
 }
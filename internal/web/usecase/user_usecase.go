@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// RegisterUser creates a user and returns it with its auth token, which is
+// not stored and so cannot be shown again, and the secret its webhooks are
+// signed with.
+func (u *Usecase) RegisterUser(ctx context.Context) (*model.User, string, string, error) {
+	token, err := model.NewAuthToken()
+	if err != nil {
+		return nil, "", "", err
+	}
+	webhookSecret, err := model.NewWebhookSecret()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	user, err := u.repo.CreateUser(ctx, model.HashAuthToken(token), webhookSecret)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return user, token, webhookSecret, nil
+}
+
+// AuthenticateUser returns the user with the token, or ErrUnauthenticated.
+func (u *Usecase) AuthenticateUser(ctx context.Context, token string) (*model.User, error) {
+	if token == "" {
+		return nil, model.ErrUnauthenticated
+	}
+	return u.repo.GetUserByAuthTokenHash(ctx, model.HashAuthToken(token))
+}
+
+// RotateUserToken gives the user a new auth token and revokes the previous one.
+func (u *Usecase) RotateUserToken(ctx context.Context, userID int32) (string, error) {
+	token, err := model.NewAuthToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := u.repo.UpdateUserAuthTokenHash(ctx, userID, model.HashAuthToken(token)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RotateWebhookSecret gives the user a new webhook secret, with which the
+// webhooks sent from now on are signed.
+func (u *Usecase) RotateWebhookSecret(ctx context.Context, userID int32) (string, error) {
+	webhookSecret, err := model.NewWebhookSecret()
+	if err != nil {
+		return "", err
+	}
+
+	if err := u.repo.UpdateUserWebhookSecret(ctx, userID, webhookSecret); err != nil {
+		return "", err
+	}
+
+	return webhookSecret, nil
+}
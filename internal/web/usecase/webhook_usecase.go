@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ponyo877/prime-checker/internal/shared/callbackaddress"
+	"github.com/ponyo877/prime-checker/internal/web/model"
+)
+
+// Longest callback URL the prime_checks table stores
+const maxCallbackURLLength = 2048
+
+// ListWebhookDeliveries returns the attempts to post the result of a prime
+// check to its callback URL, oldest first.
+func (u *Usecase) ListWebhookDeliveries(ctx context.Context, user *model.User, requestID int32) ([]*model.WebhookDelivery, error) {
+	// Also reports a foreign prime check as not found
+	if _, err := u.GetPrimeCheck(ctx, user, requestID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListWebhookDeliveries(ctx, requestID)
+}
+
+// validateCallbackURL accepts an empty callback URL, for no webhook, and
+// absolute http and https URLs whose host resolves only to public addresses.
+func validateCallbackURL(ctx context.Context, callbackURL string) error {
+	if callbackURL == "" {
+		return nil
+	}
+	if len(callbackURL) > maxCallbackURLLength {
+		return fmt.Errorf("%w: longer than %d characters", model.ErrInvalidCallbackURL, maxCallbackURLLength)
+	}
+
+	parsed, err := url.Parse(callbackURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", model.ErrInvalidCallbackURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %q is not an absolute http or https URL", model.ErrInvalidCallbackURL, callbackURL)
+	}
+	if err := callbackaddress.CheckHost(ctx, parsed.Hostname()); err != nil {
+		return fmt.Errorf("%w: %w", model.ErrInvalidCallbackURL, err)
+	}
+	return nil
+}
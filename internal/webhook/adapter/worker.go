@@ -0,0 +1,72 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ponyo877/prime-checker/internal/shared/message"
+	"github.com/ponyo877/prime-checker/internal/webhook/model"
+	"github.com/ponyo877/prime-checker/internal/webhook/usecase"
+)
+
+type WebhookDeliveryWorker struct {
+	usecase *usecase.WebhookDeliveryUsecase
+}
+
+func NewWebhookDeliveryWorker(usecase *usecase.WebhookDeliveryUsecase) *WebhookDeliveryWorker {
+	return &WebhookDeliveryWorker{
+		usecase: usecase,
+	}
+}
+
+func (w *WebhookDeliveryWorker) HandleMessage(ctx context.Context, msg *message.Message) error {
+	// Extract trace context from message
+	ctx = msg.ExtractTraceContext(ctx)
+
+	tracer := otel.Tracer("webhook-delivery-worker")
+	ctx, span := tracer.Start(ctx, "HandleWebhookDeliveryMessage")
+	defer span.End()
+
+	traceID := span.SpanContext().TraceID().String()
+	log.Printf("Processing webhook delivery message: %s with Trace ID: %s", msg.ID, traceID)
+
+	payload, err := msg.UnmarshalWebhookDeliveryPayload()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.Int("request_id", int(payload.RequestID)),
+		attribute.String("status", payload.Status),
+	)
+
+	request := model.NewWebhookRequest(
+		payload.RequestID,
+		payload.UserID,
+		payload.CallbackURL,
+		payload.NumberText,
+		payload.Status,
+		payload.IsPrime,
+		payload.UpdatedAt,
+	)
+
+	attempt, err := w.usecase.Deliver(ctx, request)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("attempts", int(attempt.Attempt())))
+	if attempt.IsSuccess() {
+		log.Printf("Webhook delivered for request ID %d after %d attempts", attempt.RequestID(), attempt.Attempt())
+	} else {
+		log.Printf("Webhook delivery for request ID %d gave up after %d attempts (status %d, error %v)", attempt.RequestID(), attempt.Attempt(), attempt.StatusCode(), attempt.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// DeliveryAttempt is the outcome of one post of a webhook. An attempt that got
+// no response has no status code but an error.
+type DeliveryAttempt struct {
+	requestID  int32
+	url        string
+	attempt    int32
+	statusCode int
+	err        error
+	duration   time.Duration
+}
+
+func NewDeliveryAttempt(requestID int32, url string, attempt int32, statusCode int, err error, duration time.Duration) *DeliveryAttempt {
+	return &DeliveryAttempt{
+		requestID:  requestID,
+		url:        url,
+		attempt:    attempt,
+		statusCode: statusCode,
+		err:        err,
+		duration:   duration,
+	}
+}
+
+func (a *DeliveryAttempt) RequestID() int32 {
+	return a.requestID
+}
+
+func (a *DeliveryAttempt) URL() string {
+	return a.url
+}
+
+// Attempt counts the attempts of a delivery from 1.
+func (a *DeliveryAttempt) Attempt() int32 {
+	return a.attempt
+}
+
+// StatusCode is the HTTP status of the response, 0 when there was none.
+func (a *DeliveryAttempt) StatusCode() int {
+	return a.statusCode
+}
+
+func (a *DeliveryAttempt) Error() error {
+	return a.err
+}
+
+func (a *DeliveryAttempt) Duration() time.Duration {
+	return a.duration
+}
+
+// IsSuccess reports whether the receiver accepted the webhook with a 2xx status.
+func (a *DeliveryAttempt) IsSuccess() bool {
+	return a.err == nil && a.statusCode >= 200 && a.statusCode < 300
+}
+
+// IsRetryable reports whether a failed attempt may succeed later: when the
+// receiver could not be reached, failed, or asked to slow down. Other
+// responses reject the webhook for good.
+func (a *DeliveryAttempt) IsRetryable() bool {
+	if a.IsSuccess() {
+		return false
+	}
+	if a.err != nil {
+		return true
+	}
+	return a.statusCode >= 500 || a.statusCode == http.StatusRequestTimeout || a.statusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader carries the signature of a webhook body
+const SignatureHeader = "X-Prime-Checker-Signature"
+
+// Sign returns the signature header value of a body sent at timestamp:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256>", where the HMAC of the user's
+// webhook secret covers the timestamp, a dot and the body. Signing the
+// timestamp lets receivers reject replays of old deliveries.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%s,v1=%s", t, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookRequest asks to post the result of a prime check to the callback URL
+// its user gave.
+type WebhookRequest struct {
+	requestID   int32
+	userID      int32
+	callbackURL string
+	numberText  string
+	status      string
+	isPrime     *bool
+	updatedAt   time.Time
+}
+
+func NewWebhookRequest(requestID, userID int32, callbackURL, numberText, status string, isPrime *bool, updatedAt time.Time) *WebhookRequest {
+	return &WebhookRequest{
+		requestID:   requestID,
+		userID:      userID,
+		callbackURL: callbackURL,
+		numberText:  numberText,
+		status:      status,
+		isPrime:     isPrime,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (r *WebhookRequest) RequestID() int32 {
+	return r.requestID
+}
+
+func (r *WebhookRequest) UserID() int32 {
+	return r.userID
+}
+
+func (r *WebhookRequest) CallbackURL() string {
+	return r.callbackURL
+}
+
+func (r *WebhookRequest) NumberText() string {
+	return r.numberText
+}
+
+func (r *WebhookRequest) Status() string {
+	return r.status
+}
+
+// IsPrime is the verdict of a completed check, nil otherwise.
+func (r *WebhookRequest) IsPrime() *bool {
+	return r.isPrime
+}
+
+func (r *WebhookRequest) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Body returns the JSON document posted to the callback URL, the same for
+// every attempt of a delivery.
+func (r *WebhookRequest) Body() ([]byte, error) {
+	return json.Marshal(struct {
+		RequestID int32     `json:"request_id"`
+		Number    string    `json:"number"`
+		Status    string    `json:"status"`
+		IsPrime   *bool     `json:"is_prime,omitempty"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}{
+		RequestID: r.requestID,
+		Number:    r.numberText,
+		Status:    r.status,
+		IsPrime:   r.isPrime,
+		UpdatedAt: r.updatedAt,
+	})
+}
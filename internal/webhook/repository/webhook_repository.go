@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ponyo877/prime-checker/db/generated_sql"
+	"github.com/ponyo877/prime-checker/internal/webhook/model"
+	"github.com/ponyo877/prime-checker/internal/webhook/usecase"
+)
+
+type WebhookRepository struct {
+	queries *generated_sql.Queries
+}
+
+func NewWebhookRepository(db *sql.DB) usecase.WebhookRepository {
+	return &WebhookRepository{
+		queries: generated_sql.New(db),
+	}
+}
+
+// GetWebhookSecret returns an empty secret for a user that has none.
+func (r *WebhookRepository) GetWebhookSecret(ctx context.Context, userID int32) (string, error) {
+	secret, err := r.queries.GetUserWebhookSecret(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return secret.String, nil
+}
+
+func (r *WebhookRepository) SaveDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt) error {
+	var errorMessage sql.NullString
+	if err := attempt.Error(); err != nil {
+		errorMessage = sql.NullString{String: err.Error(), Valid: true}
+	}
+
+	return r.queries.CreateWebhookDelivery(ctx, generated_sql.CreateWebhookDeliveryParams{
+		PrimeCheckID: attempt.RequestID(),
+		Url:          attempt.URL(),
+		Attempt:      attempt.Attempt(),
+		StatusCode:   sql.NullInt32{Int32: int32(attempt.StatusCode()), Valid: attempt.StatusCode() != 0},
+		ErrorMessage: errorMessage,
+		Success:      attempt.IsSuccess(),
+		DurationUs:   attempt.Duration().Microseconds(),
+	})
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/shared/callbackaddress"
+	"github.com/ponyo877/prime-checker/internal/webhook/model"
+	"github.com/ponyo877/prime-checker/internal/webhook/usecase"
+)
+
+const (
+	// Longest a receiver may take to answer one attempt
+	webhookTimeout = 10 * time.Second
+	// Most of a response body read so that the connection can be reused
+	maxDrainedResponseBytes = 64 << 10
+)
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender() usecase.WebhookSender {
+	// The address is checked on every connection rather than once per URL,
+	// since the host of a callback URL may have been repointed since the web
+	// server checked it. A proxy would hide the address, so none is used.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   webhookTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   callbackaddress.Control,
+	}).DialContext
+
+	return &webhookSender{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   webhookTimeout,
+			// A redirect would forward the signed body to a URL the user never gave
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *webhookSender) Send(ctx context.Context, url string, body []byte, signature string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "prime-checker-webhook/1.0")
+	req.Header.Set(model.SignatureHeader, signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseBytes))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ponyo877/prime-checker/internal/webhook/model"
+)
+
+type WebhookSender interface {
+	Send(ctx context.Context, url string, body []byte, signature string) (int, error)
+}
+
+type WebhookRepository interface {
+	GetWebhookSecret(ctx context.Context, userID int32) (string, error)
+	SaveDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt) error
+}
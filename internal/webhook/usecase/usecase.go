@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ponyo877/prime-checker/internal/webhook/model"
+)
+
+// Waits before the attempts after the first, doubling from one second. A
+// delivery gives up after the attempt that follows the last wait.
+var retryBackoff = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	4 * time.Second,
+	8 * time.Second,
+	16 * time.Second,
+}
+
+// errNoWebhookSecret is recorded for users registered before webhooks were
+// signed, who get a secret only by rotating it.
+var errNoWebhookSecret = errors.New("user has no webhook secret")
+
+type WebhookDeliveryUsecase struct {
+	sender WebhookSender
+	repo   WebhookRepository
+}
+
+func NewWebhookDeliveryUsecase(sender WebhookSender, repo WebhookRepository) *WebhookDeliveryUsecase {
+	return &WebhookDeliveryUsecase{
+		sender: sender,
+		repo:   repo,
+	}
+}
+
+// Deliver posts the result to the callback URL of the request, signed with the
+// webhook secret of its user, and retries with exponential backoff while the
+// receiver cannot be reached or fails. Every attempt is recorded. It returns
+// the last attempt, and an error only when the delivery should be redelivered
+// as a whole, such as when the worker shuts down.
+func (u *WebhookDeliveryUsecase) Deliver(ctx context.Context, request *model.WebhookRequest) (*model.DeliveryAttempt, error) {
+	log.Printf("Delivering webhook to %s for request ID %d", request.CallbackURL(), request.RequestID())
+
+	secret, err := u.repo.GetWebhookSecret(ctx, request.UserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook secret: %w", err)
+	}
+	if secret == "" {
+		// Unsigned webhooks could not be told from forged ones, so none is sent
+		attempt := model.NewDeliveryAttempt(request.RequestID(), request.CallbackURL(), 1, 0, errNoWebhookSecret, 0)
+		u.saveDeliveryAttempt(ctx, attempt)
+		return attempt, nil
+	}
+
+	body, err := request.Body()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook body: %w", err)
+	}
+
+	for number := int32(1); ; number++ {
+		// Each attempt is signed afresh, so that its timestamp tells when it was sent
+		start := time.Now()
+		statusCode, sendErr := u.sender.Send(ctx, request.CallbackURL(), body, model.Sign(secret, start, body))
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("webhook delivery interrupted: %w", ctx.Err())
+		}
+
+		attempt := model.NewDeliveryAttempt(request.RequestID(), request.CallbackURL(), number, statusCode, sendErr, time.Since(start))
+		u.saveDeliveryAttempt(ctx, attempt)
+
+		if !attempt.IsRetryable() || int(number) > len(retryBackoff) {
+			return attempt, nil
+		}
+
+		wait := retryBackoff[number-1]
+		log.Printf("Webhook attempt %d for request ID %d failed (status %d, error %v), retrying in %v", number, request.RequestID(), statusCode, sendErr, wait)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("webhook delivery interrupted: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// saveDeliveryAttempt records an attempt. A lost record is only logged, since
+// the webhook itself was sent.
+func (u *WebhookDeliveryUsecase) saveDeliveryAttempt(ctx context.Context, attempt *model.DeliveryAttempt) {
+	if err := u.repo.SaveDeliveryAttempt(ctx, attempt); err != nil {
+		log.Printf("Failed to save webhook delivery attempt in DB: %v", err)
+	}
+}
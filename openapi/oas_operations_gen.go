@@ -6,9 +6,27 @@ package openapi
 type OperationName = string
 
 const (
-	PrimeChecksCreateOperation OperationName = "PrimeChecksCreate"
-	PrimeChecksGetOperation    OperationName = "PrimeChecksGet"
-	PrimeChecksListOperation   OperationName = "PrimeChecksList"
-	SettingsCreateOperation    OperationName = "SettingsCreate"
-	SettingsGetOperation       OperationName = "SettingsGet"
+	PrimeChecksCancelOperation                OperationName = "PrimeChecksCancel"
+	PrimeChecksCreateOperation                OperationName = "PrimeChecksCreate"
+	PrimeChecksCreateBatchOperation           OperationName = "PrimeChecksCreateBatch"
+	PrimeChecksDownloadBatchOperation         OperationName = "PrimeChecksDownloadBatch"
+	PrimeChecksGetOperation                   OperationName = "PrimeChecksGet"
+	PrimeChecksGetBatchOperation              OperationName = "PrimeChecksGetBatch"
+	PrimeChecksGetCertificateOperation        OperationName = "PrimeChecksGetCertificate"
+	PrimeChecksListOperation                  OperationName = "PrimeChecksList"
+	PrimeChecksListBatchResultsOperation      OperationName = "PrimeChecksListBatchResults"
+	PrimeChecksListEventsOperation            OperationName = "PrimeChecksListEvents"
+	PrimeChecksListWebhookDeliveriesOperation OperationName = "PrimeChecksListWebhookDeliveries"
+	PrimeChecksRetryOperation                 OperationName = "PrimeChecksRetry"
+	PrimeChecksStreamOperation                OperationName = "PrimeChecksStream"
+	PrimeChecksStreamAllOperation             OperationName = "PrimeChecksStreamAll"
+	PrimeChecksUploadBatchOperation           OperationName = "PrimeChecksUploadBatch"
+	PrimeRangesCreateOperation                OperationName = "PrimeRangesCreate"
+	PrimeRangesGetOperation                   OperationName = "PrimeRangesGet"
+	PrimeRangesListPrimesOperation            OperationName = "PrimeRangesListPrimes"
+	SettingsCreateOperation                   OperationName = "SettingsCreate"
+	SettingsGetOperation                      OperationName = "SettingsGet"
+	UsersRegisterOperation                    OperationName = "UsersRegister"
+	UsersRotateTokenOperation                 OperationName = "UsersRotateToken"
+	UsersRotateWebhookSecretOperation         OperationName = "UsersRotateWebhookSecret"
 )
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"net/http"
 
+	"github.com/go-faster/errors"
 	"github.com/go-faster/jx"
 
 	ht "github.com/ogen-go/ogen/http"
@@ -25,6 +26,54 @@ func encodePrimeChecksCreateRequest(
 	return nil
 }
 
+func encodePrimeChecksCreateBatchRequest(
+	req *PrimeCheckBatchInput,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodePrimeChecksUploadBatchRequest(
+	req PrimeChecksUploadBatchReq,
+	r *http.Request,
+) error {
+	switch req := req.(type) {
+	case *PrimeChecksUploadBatchReqApplicationXNdjson:
+		const contentType = "application/x-ndjson"
+		body := req
+		ht.SetBody(r, body, contentType)
+		return nil
+	case *PrimeChecksUploadBatchReqTextCsv:
+		const contentType = "text/csv"
+		body := req
+		ht.SetBody(r, body, contentType)
+		return nil
+	default:
+		return errors.Errorf("unexpected request type: %T", req)
+	}
+}
+
+func encodePrimeRangesCreateRequest(
+	req *PrimeRangeInput,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodeSettingsCreateRequest(
 	req *Setting,
 	r *http.Request,
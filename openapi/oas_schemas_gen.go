@@ -4,17 +4,216 @@ package openapi
 
 import (
 	"fmt"
+	"io"
 	"time"
+
+	"github.com/go-faster/errors"
 )
 
 func (s *ErrorStatusCode) Error() string {
 	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
 }
 
+// Ref: #/components/schemas/Accuracy
+type Accuracy string
+
+const (
+	AccuracyFast          Accuracy = "fast"
+	AccuracyStandard      Accuracy = "standard"
+	AccuracyParanoid      Accuracy = "paranoid"
+	AccuracyDeterministic Accuracy = "deterministic"
+)
+
+// AllValues returns all Accuracy values.
+func (Accuracy) AllValues() []Accuracy {
+	return []Accuracy{
+		AccuracyFast,
+		AccuracyStandard,
+		AccuracyParanoid,
+		AccuracyDeterministic,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Accuracy) MarshalText() ([]byte, error) {
+	switch s {
+	case AccuracyFast:
+		return []byte(s), nil
+	case AccuracyStandard:
+		return []byte(s), nil
+	case AccuracyParanoid:
+		return []byte(s), nil
+	case AccuracyDeterministic:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Accuracy) UnmarshalText(data []byte) error {
+	switch Accuracy(data) {
+	case AccuracyFast:
+		*s = AccuracyFast
+		return nil
+	case AccuracyStandard:
+		*s = AccuracyStandard
+		return nil
+	case AccuracyParanoid:
+		*s = AccuracyParanoid
+		return nil
+	case AccuracyDeterministic:
+		*s = AccuracyDeterministic
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type BearerAuth struct {
+	Token string
+	Roles []string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// GetRoles returns the value of Roles.
+func (s *BearerAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *BearerAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// Ref: #/components/schemas/CertificateStep
+type CertificateStep struct {
+	Method    string    `json:"method"`
+	N         string    `json:"n"`
+	Factors   []string  `json:"factors"`
+	Witnesses []string  `json:"witnesses"`
+	A         OptString `json:"a"`
+	B         OptString `json:"b"`
+	M         OptString `json:"m"`
+	Q         OptString `json:"q"`
+	X         OptString `json:"x"`
+	Y         OptString `json:"y"`
+}
+
+// GetMethod returns the value of Method.
+func (s *CertificateStep) GetMethod() string {
+	return s.Method
+}
+
+// GetN returns the value of N.
+func (s *CertificateStep) GetN() string {
+	return s.N
+}
+
+// GetFactors returns the value of Factors.
+func (s *CertificateStep) GetFactors() []string {
+	return s.Factors
+}
+
+// GetWitnesses returns the value of Witnesses.
+func (s *CertificateStep) GetWitnesses() []string {
+	return s.Witnesses
+}
+
+// GetA returns the value of A.
+func (s *CertificateStep) GetA() OptString {
+	return s.A
+}
+
+// GetB returns the value of B.
+func (s *CertificateStep) GetB() OptString {
+	return s.B
+}
+
+// GetM returns the value of M.
+func (s *CertificateStep) GetM() OptString {
+	return s.M
+}
+
+// GetQ returns the value of Q.
+func (s *CertificateStep) GetQ() OptString {
+	return s.Q
+}
+
+// GetX returns the value of X.
+func (s *CertificateStep) GetX() OptString {
+	return s.X
+}
+
+// GetY returns the value of Y.
+func (s *CertificateStep) GetY() OptString {
+	return s.Y
+}
+
+// SetMethod sets the value of Method.
+func (s *CertificateStep) SetMethod(val string) {
+	s.Method = val
+}
+
+// SetN sets the value of N.
+func (s *CertificateStep) SetN(val string) {
+	s.N = val
+}
+
+// SetFactors sets the value of Factors.
+func (s *CertificateStep) SetFactors(val []string) {
+	s.Factors = val
+}
+
+// SetWitnesses sets the value of Witnesses.
+func (s *CertificateStep) SetWitnesses(val []string) {
+	s.Witnesses = val
+}
+
+// SetA sets the value of A.
+func (s *CertificateStep) SetA(val OptString) {
+	s.A = val
+}
+
+// SetB sets the value of B.
+func (s *CertificateStep) SetB(val OptString) {
+	s.B = val
+}
+
+// SetM sets the value of M.
+func (s *CertificateStep) SetM(val OptString) {
+	s.M = val
+}
+
+// SetQ sets the value of Q.
+func (s *CertificateStep) SetQ(val OptString) {
+	s.Q = val
+}
+
+// SetX sets the value of X.
+func (s *CertificateStep) SetX(val OptString) {
+	s.X = val
+}
+
+// SetY sets the value of Y.
+func (s *CertificateStep) SetY(val OptString) {
+	s.Y = val
+}
+
 // Ref: #/components/schemas/Error
 type Error struct {
-	Code    int32  `json:"code"`
-	Message string `json:"message"`
+	Code      int32  `json:"code"`
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
 }
 
 // GetCode returns the value of Code.
@@ -22,6 +221,11 @@ func (s *Error) GetCode() int32 {
 	return s.Code
 }
 
+// GetErrorCode returns the value of ErrorCode.
+func (s *Error) GetErrorCode() string {
+	return s.ErrorCode
+}
+
 // GetMessage returns the value of Message.
 func (s *Error) GetMessage() string {
 	return s.Message
@@ -32,6 +236,11 @@ func (s *Error) SetCode(val int32) {
 	s.Code = val
 }
 
+// SetErrorCode sets the value of ErrorCode.
+func (s *Error) SetErrorCode(val string) {
+	s.ErrorCode = val
+}
+
 // SetMessage sets the value of Message.
 func (s *Error) SetMessage(val string) {
 	s.Message = val
@@ -63,6 +272,52 @@ func (s *ErrorStatusCode) SetResponse(val Error) {
 	s.Response = val
 }
 
+// NewOptAccuracy returns new OptAccuracy with value set to v.
+func NewOptAccuracy(v Accuracy) OptAccuracy {
+	return OptAccuracy{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptAccuracy is optional Accuracy.
+type OptAccuracy struct {
+	Value Accuracy
+	Set   bool
+}
+
+// IsSet returns true if OptAccuracy was set.
+func (o OptAccuracy) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptAccuracy) Reset() {
+	var v Accuracy
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptAccuracy) SetTo(v Accuracy) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptAccuracy) Get() (v Accuracy, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptAccuracy) Or(d Accuracy) Accuracy {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptBool returns new OptBool with value set to v.
 func NewOptBool(v bool) OptBool {
 	return OptBool{
@@ -109,38 +364,38 @@ func (o OptBool) Or(d bool) bool {
 	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
 	Set   bool
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
+func (o *OptDateTime) Reset() {
+	var v time.Time
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
+func (o *OptDateTime) SetTo(v time.Time) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
+func (o OptDateTime) Get() (v time.Time, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -148,143 +403,1774 @@ func (o OptString) Get() (v string, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
+func (o OptDateTime) Or(d time.Time) time.Time {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// Ref: #/components/schemas/PrimeCheck
-type PrimeCheck struct {
-	ID        int32     `json:"id"`
-	Number    string    `json:"number"`
-	CreatedAt time.Time `json:"created_at"`
-	TraceID   OptString `json:"trace_id"`
-	MessageID OptString `json:"message_id"`
-	IsPrime   OptBool   `json:"is_prime"`
-	Status    OptString `json:"status"`
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetID returns the value of ID.
-func (s *PrimeCheck) GetID() int32 {
-	return s.ID
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
 }
 
-// GetNumber returns the value of Number.
-func (s *PrimeCheck) GetNumber() string {
-	return s.Number
-}
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
 
-// GetCreatedAt returns the value of CreatedAt.
-func (s *PrimeCheck) GetCreatedAt() time.Time {
-	return s.CreatedAt
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
 }
 
-// GetTraceID returns the value of TraceID.
-func (s *PrimeCheck) GetTraceID() OptString {
-	return s.TraceID
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
 }
 
-// GetMessageID returns the value of MessageID.
-func (s *PrimeCheck) GetMessageID() OptString {
-	return s.MessageID
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetIsPrime returns the value of IsPrime.
-func (s *PrimeCheck) GetIsPrime() OptBool {
-	return s.IsPrime
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// GetStatus returns the value of Status.
-func (s *PrimeCheck) GetStatus() OptString {
-	return s.Status
+// NewOptInt32 returns new OptInt32 with value set to v.
+func NewOptInt32(v int32) OptInt32 {
+	return OptInt32{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// SetID sets the value of ID.
-func (s *PrimeCheck) SetID(val int32) {
-	s.ID = val
+// OptInt32 is optional int32.
+type OptInt32 struct {
+	Value int32
+	Set   bool
 }
 
-// SetNumber sets the value of Number.
-func (s *PrimeCheck) SetNumber(val string) {
-	s.Number = val
-}
+// IsSet returns true if OptInt32 was set.
+func (o OptInt32) IsSet() bool { return o.Set }
 
-// SetCreatedAt sets the value of CreatedAt.
-func (s *PrimeCheck) SetCreatedAt(val time.Time) {
-	s.CreatedAt = val
+// Reset unsets value.
+func (o *OptInt32) Reset() {
+	var v int32
+	o.Value = v
+	o.Set = false
 }
 
-// SetTraceID sets the value of TraceID.
-func (s *PrimeCheck) SetTraceID(val OptString) {
-	s.TraceID = val
+// SetTo sets value to v.
+func (o *OptInt32) SetTo(v int32) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetMessageID sets the value of MessageID.
-func (s *PrimeCheck) SetMessageID(val OptString) {
-	s.MessageID = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt32) Get() (v int32, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetIsPrime sets the value of IsPrime.
-func (s *PrimeCheck) SetIsPrime(val OptBool) {
-	s.IsPrime = val
+// Or returns value if set, or given parameter if does not.
+func (o OptInt32) Or(d int32) int32 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetStatus sets the value of Status.
-func (s *PrimeCheck) SetStatus(val OptString) {
-	s.Status = val
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// Ref: #/components/schemas/PrimeCheckInput
-type PrimeCheckInput struct {
-	Number string `json:"number"`
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
 }
 
-// GetNumber returns the value of Number.
-func (s *PrimeCheckInput) GetNumber() string {
-	return s.Number
-}
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
 
-// SetNumber sets the value of Number.
-func (s *PrimeCheckInput) SetNumber(val string) {
-	s.Number = val
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
 }
 
-// Ref: #/components/schemas/PrimeCheckList
-type PrimeCheckList struct {
-	Items []PrimeCheck `json:"items"`
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
 }
 
-// GetItems returns the value of Items.
-func (s *PrimeCheckList) GetItems() []PrimeCheck {
-	return s.Items
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetItems sets the value of Items.
-func (s *PrimeCheckList) SetItems(val []PrimeCheck) {
-	s.Items = val
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// Ref: #/components/schemas/Setting
-type Setting struct {
-	RecordNumberSuccess bool `json:"record_number_success"`
-	PrimeCheckSuccess   bool `json:"prime_check_success"`
-	EmailSendSuccess    bool `json:"email_send_success"`
-	DlqSaveSuccess      bool `json:"dlq_save_success"`
+// NewOptPrimalityAlgorithm returns new OptPrimalityAlgorithm with value set to v.
+func NewOptPrimalityAlgorithm(v PrimalityAlgorithm) OptPrimalityAlgorithm {
+	return OptPrimalityAlgorithm{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetRecordNumberSuccess returns the value of RecordNumberSuccess.
-func (s *Setting) GetRecordNumberSuccess() bool {
-	return s.RecordNumberSuccess
+// OptPrimalityAlgorithm is optional PrimalityAlgorithm.
+type OptPrimalityAlgorithm struct {
+	Value PrimalityAlgorithm
+	Set   bool
 }
 
-// GetPrimeCheckSuccess returns the value of PrimeCheckSuccess.
-func (s *Setting) GetPrimeCheckSuccess() bool {
-	return s.PrimeCheckSuccess
-}
+// IsSet returns true if OptPrimalityAlgorithm was set.
+func (o OptPrimalityAlgorithm) IsSet() bool { return o.Set }
 
-// GetEmailSendSuccess returns the value of EmailSendSuccess.
+// Reset unsets value.
+func (o *OptPrimalityAlgorithm) Reset() {
+	var v PrimalityAlgorithm
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPrimalityAlgorithm) SetTo(v PrimalityAlgorithm) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPrimalityAlgorithm) Get() (v PrimalityAlgorithm, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPrimalityAlgorithm) Or(d PrimalityAlgorithm) PrimalityAlgorithm {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPrimeCheckAlgorithmParams returns new OptPrimeCheckAlgorithmParams with value set to v.
+func NewOptPrimeCheckAlgorithmParams(v PrimeCheckAlgorithmParams) OptPrimeCheckAlgorithmParams {
+	return OptPrimeCheckAlgorithmParams{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPrimeCheckAlgorithmParams is optional PrimeCheckAlgorithmParams.
+type OptPrimeCheckAlgorithmParams struct {
+	Value PrimeCheckAlgorithmParams
+	Set   bool
+}
+
+// IsSet returns true if OptPrimeCheckAlgorithmParams was set.
+func (o OptPrimeCheckAlgorithmParams) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPrimeCheckAlgorithmParams) Reset() {
+	var v PrimeCheckAlgorithmParams
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPrimeCheckAlgorithmParams) SetTo(v PrimeCheckAlgorithmParams) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPrimeCheckAlgorithmParams) Get() (v PrimeCheckAlgorithmParams, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPrimeCheckAlgorithmParams) Or(d PrimeCheckAlgorithmParams) PrimeCheckAlgorithmParams {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPrimeCheckBatchInputOperation returns new OptPrimeCheckBatchInputOperation with value set to v.
+func NewOptPrimeCheckBatchInputOperation(v PrimeCheckBatchInputOperation) OptPrimeCheckBatchInputOperation {
+	return OptPrimeCheckBatchInputOperation{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPrimeCheckBatchInputOperation is optional PrimeCheckBatchInputOperation.
+type OptPrimeCheckBatchInputOperation struct {
+	Value PrimeCheckBatchInputOperation
+	Set   bool
+}
+
+// IsSet returns true if OptPrimeCheckBatchInputOperation was set.
+func (o OptPrimeCheckBatchInputOperation) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPrimeCheckBatchInputOperation) Reset() {
+	var v PrimeCheckBatchInputOperation
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPrimeCheckBatchInputOperation) SetTo(v PrimeCheckBatchInputOperation) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPrimeCheckBatchInputOperation) Get() (v PrimeCheckBatchInputOperation, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPrimeCheckBatchInputOperation) Or(d PrimeCheckBatchInputOperation) PrimeCheckBatchInputOperation {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPrimeCheckInputOperation returns new OptPrimeCheckInputOperation with value set to v.
+func NewOptPrimeCheckInputOperation(v PrimeCheckInputOperation) OptPrimeCheckInputOperation {
+	return OptPrimeCheckInputOperation{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPrimeCheckInputOperation is optional PrimeCheckInputOperation.
+type OptPrimeCheckInputOperation struct {
+	Value PrimeCheckInputOperation
+	Set   bool
+}
+
+// IsSet returns true if OptPrimeCheckInputOperation was set.
+func (o OptPrimeCheckInputOperation) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPrimeCheckInputOperation) Reset() {
+	var v PrimeCheckInputOperation
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPrimeCheckInputOperation) SetTo(v PrimeCheckInputOperation) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPrimeCheckInputOperation) Get() (v PrimeCheckInputOperation, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPrimeCheckInputOperation) Or(d PrimeCheckInputOperation) PrimeCheckInputOperation {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPrimeOperation returns new OptPrimeOperation with value set to v.
+func NewOptPrimeOperation(v PrimeOperation) OptPrimeOperation {
+	return OptPrimeOperation{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPrimeOperation is optional PrimeOperation.
+type OptPrimeOperation struct {
+	Value PrimeOperation
+	Set   bool
+}
+
+// IsSet returns true if OptPrimeOperation was set.
+func (o OptPrimeOperation) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPrimeOperation) Reset() {
+	var v PrimeOperation
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPrimeOperation) SetTo(v PrimeOperation) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPrimeOperation) Get() (v PrimeOperation, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPrimeOperation) Or(d PrimeOperation) PrimeOperation {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSortOrder returns new OptSortOrder with value set to v.
+func NewOptSortOrder(v SortOrder) OptSortOrder {
+	return OptSortOrder{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSortOrder is optional SortOrder.
+type OptSortOrder struct {
+	Value SortOrder
+	Set   bool
+}
+
+// IsSet returns true if OptSortOrder was set.
+func (o OptSortOrder) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSortOrder) Reset() {
+	var v SortOrder
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSortOrder) SetTo(v SortOrder) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSortOrder) Get() (v SortOrder, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSortOrder) Or(d SortOrder) SortOrder {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/components/schemas/PrimalityAlgorithm
+type PrimalityAlgorithm string
+
+const (
+	PrimalityAlgorithmTrialDivision            PrimalityAlgorithm = "trial_division"
+	PrimalityAlgorithmMillerRabin              PrimalityAlgorithm = "miller_rabin"
+	PrimalityAlgorithmMillerRabinDeterministic PrimalityAlgorithm = "miller_rabin_deterministic"
+	PrimalityAlgorithmBailliePsw               PrimalityAlgorithm = "baillie_psw"
+	PrimalityAlgorithmLucasLehmer              PrimalityAlgorithm = "lucas_lehmer"
+	PrimalityAlgorithmPepin                    PrimalityAlgorithm = "pepin"
+	PrimalityAlgorithmProth                    PrimalityAlgorithm = "proth"
+	PrimalityAlgorithmAks                      PrimalityAlgorithm = "aks"
+)
+
+// AllValues returns all PrimalityAlgorithm values.
+func (PrimalityAlgorithm) AllValues() []PrimalityAlgorithm {
+	return []PrimalityAlgorithm{
+		PrimalityAlgorithmTrialDivision,
+		PrimalityAlgorithmMillerRabin,
+		PrimalityAlgorithmMillerRabinDeterministic,
+		PrimalityAlgorithmBailliePsw,
+		PrimalityAlgorithmLucasLehmer,
+		PrimalityAlgorithmPepin,
+		PrimalityAlgorithmProth,
+		PrimalityAlgorithmAks,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PrimalityAlgorithm) MarshalText() ([]byte, error) {
+	switch s {
+	case PrimalityAlgorithmTrialDivision:
+		return []byte(s), nil
+	case PrimalityAlgorithmMillerRabin:
+		return []byte(s), nil
+	case PrimalityAlgorithmMillerRabinDeterministic:
+		return []byte(s), nil
+	case PrimalityAlgorithmBailliePsw:
+		return []byte(s), nil
+	case PrimalityAlgorithmLucasLehmer:
+		return []byte(s), nil
+	case PrimalityAlgorithmPepin:
+		return []byte(s), nil
+	case PrimalityAlgorithmProth:
+		return []byte(s), nil
+	case PrimalityAlgorithmAks:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PrimalityAlgorithm) UnmarshalText(data []byte) error {
+	switch PrimalityAlgorithm(data) {
+	case PrimalityAlgorithmTrialDivision:
+		*s = PrimalityAlgorithmTrialDivision
+		return nil
+	case PrimalityAlgorithmMillerRabin:
+		*s = PrimalityAlgorithmMillerRabin
+		return nil
+	case PrimalityAlgorithmMillerRabinDeterministic:
+		*s = PrimalityAlgorithmMillerRabinDeterministic
+		return nil
+	case PrimalityAlgorithmBailliePsw:
+		*s = PrimalityAlgorithmBailliePsw
+		return nil
+	case PrimalityAlgorithmLucasLehmer:
+		*s = PrimalityAlgorithmLucasLehmer
+		return nil
+	case PrimalityAlgorithmPepin:
+		*s = PrimalityAlgorithmPepin
+		return nil
+	case PrimalityAlgorithmProth:
+		*s = PrimalityAlgorithmProth
+		return nil
+	case PrimalityAlgorithmAks:
+		*s = PrimalityAlgorithmAks
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/PrimeCertificate
+type PrimeCertificate struct {
+	RequestID int32             `json:"request_id"`
+	Number    string            `json:"number"`
+	Method    string            `json:"method"`
+	Steps     []CertificateStep `json:"steps"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// GetRequestID returns the value of RequestID.
+func (s *PrimeCertificate) GetRequestID() int32 {
+	return s.RequestID
+}
+
+// GetNumber returns the value of Number.
+func (s *PrimeCertificate) GetNumber() string {
+	return s.Number
+}
+
+// GetMethod returns the value of Method.
+func (s *PrimeCertificate) GetMethod() string {
+	return s.Method
+}
+
+// GetSteps returns the value of Steps.
+func (s *PrimeCertificate) GetSteps() []CertificateStep {
+	return s.Steps
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PrimeCertificate) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetRequestID sets the value of RequestID.
+func (s *PrimeCertificate) SetRequestID(val int32) {
+	s.RequestID = val
+}
+
+// SetNumber sets the value of Number.
+func (s *PrimeCertificate) SetNumber(val string) {
+	s.Number = val
+}
+
+// SetMethod sets the value of Method.
+func (s *PrimeCertificate) SetMethod(val string) {
+	s.Method = val
+}
+
+// SetSteps sets the value of Steps.
+func (s *PrimeCertificate) SetSteps(val []CertificateStep) {
+	s.Steps = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PrimeCertificate) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/PrimeCheck
+type PrimeCheck struct {
+	ID                  int32                        `json:"id"`
+	BatchID             OptInt32                     `json:"batch_id"`
+	Number              string                       `json:"number"`
+	BitLength           OptInt32                     `json:"bit_length"`
+	Expression          OptString                    `json:"expression"`
+	Operation           OptString                    `json:"operation"`
+	Accuracy            OptString                    `json:"accuracy"`
+	CallbackURL         OptString                    `json:"callback_url"`
+	TimeoutSeconds      OptInt32                     `json:"timeout_seconds"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	TraceID             OptString                    `json:"trace_id"`
+	MessageID           OptString                    `json:"message_id"`
+	IsPrime             OptBool                      `json:"is_prime"`
+	Algorithm           OptString                    `json:"algorithm"`
+	AlgorithmVersion    OptString                    `json:"algorithm_version"`
+	AlgorithmParams     OptPrimeCheckAlgorithmParams `json:"algorithm_params"`
+	FoundPrime          OptString                    `json:"found_prime"`
+	PrimeGap            OptInt64                     `json:"prime_gap"`
+	Confidence          OptString                    `json:"confidence"`
+	ErrorBound          OptFloat64                   `json:"error_bound"`
+	WorkerID            OptString                    `json:"worker_id"`
+	StartedAt           OptDateTime                  `json:"started_at"`
+	FinishedAt          OptDateTime                  `json:"finished_at"`
+	CalculationUs       OptInt64                     `json:"calculation_us"`
+	Status              OptString                    `json:"status"`
+	CertificateStatus   OptString                    `json:"certificate_status"`
+	FactorizationStatus OptString                    `json:"factorization_status"`
+	Cached              OptBool                      `json:"cached"`
+	Factors             []PrimeFactor                `json:"factors"`
+	Unfactored          OptString                    `json:"unfactored"`
+}
+
+// GetID returns the value of ID.
+func (s *PrimeCheck) GetID() int32 {
+	return s.ID
+}
+
+// GetBatchID returns the value of BatchID.
+func (s *PrimeCheck) GetBatchID() OptInt32 {
+	return s.BatchID
+}
+
+// GetNumber returns the value of Number.
+func (s *PrimeCheck) GetNumber() string {
+	return s.Number
+}
+
+// GetBitLength returns the value of BitLength.
+func (s *PrimeCheck) GetBitLength() OptInt32 {
+	return s.BitLength
+}
+
+// GetExpression returns the value of Expression.
+func (s *PrimeCheck) GetExpression() OptString {
+	return s.Expression
+}
+
+// GetOperation returns the value of Operation.
+func (s *PrimeCheck) GetOperation() OptString {
+	return s.Operation
+}
+
+// GetAccuracy returns the value of Accuracy.
+func (s *PrimeCheck) GetAccuracy() OptString {
+	return s.Accuracy
+}
+
+// GetCallbackURL returns the value of CallbackURL.
+func (s *PrimeCheck) GetCallbackURL() OptString {
+	return s.CallbackURL
+}
+
+// GetTimeoutSeconds returns the value of TimeoutSeconds.
+func (s *PrimeCheck) GetTimeoutSeconds() OptInt32 {
+	return s.TimeoutSeconds
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PrimeCheck) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetTraceID returns the value of TraceID.
+func (s *PrimeCheck) GetTraceID() OptString {
+	return s.TraceID
+}
+
+// GetMessageID returns the value of MessageID.
+func (s *PrimeCheck) GetMessageID() OptString {
+	return s.MessageID
+}
+
+// GetIsPrime returns the value of IsPrime.
+func (s *PrimeCheck) GetIsPrime() OptBool {
+	return s.IsPrime
+}
+
+// GetAlgorithm returns the value of Algorithm.
+func (s *PrimeCheck) GetAlgorithm() OptString {
+	return s.Algorithm
+}
+
+// GetAlgorithmVersion returns the value of AlgorithmVersion.
+func (s *PrimeCheck) GetAlgorithmVersion() OptString {
+	return s.AlgorithmVersion
+}
+
+// GetAlgorithmParams returns the value of AlgorithmParams.
+func (s *PrimeCheck) GetAlgorithmParams() OptPrimeCheckAlgorithmParams {
+	return s.AlgorithmParams
+}
+
+// GetFoundPrime returns the value of FoundPrime.
+func (s *PrimeCheck) GetFoundPrime() OptString {
+	return s.FoundPrime
+}
+
+// GetPrimeGap returns the value of PrimeGap.
+func (s *PrimeCheck) GetPrimeGap() OptInt64 {
+	return s.PrimeGap
+}
+
+// GetConfidence returns the value of Confidence.
+func (s *PrimeCheck) GetConfidence() OptString {
+	return s.Confidence
+}
+
+// GetErrorBound returns the value of ErrorBound.
+func (s *PrimeCheck) GetErrorBound() OptFloat64 {
+	return s.ErrorBound
+}
+
+// GetWorkerID returns the value of WorkerID.
+func (s *PrimeCheck) GetWorkerID() OptString {
+	return s.WorkerID
+}
+
+// GetStartedAt returns the value of StartedAt.
+func (s *PrimeCheck) GetStartedAt() OptDateTime {
+	return s.StartedAt
+}
+
+// GetFinishedAt returns the value of FinishedAt.
+func (s *PrimeCheck) GetFinishedAt() OptDateTime {
+	return s.FinishedAt
+}
+
+// GetCalculationUs returns the value of CalculationUs.
+func (s *PrimeCheck) GetCalculationUs() OptInt64 {
+	return s.CalculationUs
+}
+
+// GetStatus returns the value of Status.
+func (s *PrimeCheck) GetStatus() OptString {
+	return s.Status
+}
+
+// GetCertificateStatus returns the value of CertificateStatus.
+func (s *PrimeCheck) GetCertificateStatus() OptString {
+	return s.CertificateStatus
+}
+
+// GetFactorizationStatus returns the value of FactorizationStatus.
+func (s *PrimeCheck) GetFactorizationStatus() OptString {
+	return s.FactorizationStatus
+}
+
+// GetCached returns the value of Cached.
+func (s *PrimeCheck) GetCached() OptBool {
+	return s.Cached
+}
+
+// GetFactors returns the value of Factors.
+func (s *PrimeCheck) GetFactors() []PrimeFactor {
+	return s.Factors
+}
+
+// GetUnfactored returns the value of Unfactored.
+func (s *PrimeCheck) GetUnfactored() OptString {
+	return s.Unfactored
+}
+
+// SetID sets the value of ID.
+func (s *PrimeCheck) SetID(val int32) {
+	s.ID = val
+}
+
+// SetBatchID sets the value of BatchID.
+func (s *PrimeCheck) SetBatchID(val OptInt32) {
+	s.BatchID = val
+}
+
+// SetNumber sets the value of Number.
+func (s *PrimeCheck) SetNumber(val string) {
+	s.Number = val
+}
+
+// SetBitLength sets the value of BitLength.
+func (s *PrimeCheck) SetBitLength(val OptInt32) {
+	s.BitLength = val
+}
+
+// SetExpression sets the value of Expression.
+func (s *PrimeCheck) SetExpression(val OptString) {
+	s.Expression = val
+}
+
+// SetOperation sets the value of Operation.
+func (s *PrimeCheck) SetOperation(val OptString) {
+	s.Operation = val
+}
+
+// SetAccuracy sets the value of Accuracy.
+func (s *PrimeCheck) SetAccuracy(val OptString) {
+	s.Accuracy = val
+}
+
+// SetCallbackURL sets the value of CallbackURL.
+func (s *PrimeCheck) SetCallbackURL(val OptString) {
+	s.CallbackURL = val
+}
+
+// SetTimeoutSeconds sets the value of TimeoutSeconds.
+func (s *PrimeCheck) SetTimeoutSeconds(val OptInt32) {
+	s.TimeoutSeconds = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PrimeCheck) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetTraceID sets the value of TraceID.
+func (s *PrimeCheck) SetTraceID(val OptString) {
+	s.TraceID = val
+}
+
+// SetMessageID sets the value of MessageID.
+func (s *PrimeCheck) SetMessageID(val OptString) {
+	s.MessageID = val
+}
+
+// SetIsPrime sets the value of IsPrime.
+func (s *PrimeCheck) SetIsPrime(val OptBool) {
+	s.IsPrime = val
+}
+
+// SetAlgorithm sets the value of Algorithm.
+func (s *PrimeCheck) SetAlgorithm(val OptString) {
+	s.Algorithm = val
+}
+
+// SetAlgorithmVersion sets the value of AlgorithmVersion.
+func (s *PrimeCheck) SetAlgorithmVersion(val OptString) {
+	s.AlgorithmVersion = val
+}
+
+// SetAlgorithmParams sets the value of AlgorithmParams.
+func (s *PrimeCheck) SetAlgorithmParams(val OptPrimeCheckAlgorithmParams) {
+	s.AlgorithmParams = val
+}
+
+// SetFoundPrime sets the value of FoundPrime.
+func (s *PrimeCheck) SetFoundPrime(val OptString) {
+	s.FoundPrime = val
+}
+
+// SetPrimeGap sets the value of PrimeGap.
+func (s *PrimeCheck) SetPrimeGap(val OptInt64) {
+	s.PrimeGap = val
+}
+
+// SetConfidence sets the value of Confidence.
+func (s *PrimeCheck) SetConfidence(val OptString) {
+	s.Confidence = val
+}
+
+// SetErrorBound sets the value of ErrorBound.
+func (s *PrimeCheck) SetErrorBound(val OptFloat64) {
+	s.ErrorBound = val
+}
+
+// SetWorkerID sets the value of WorkerID.
+func (s *PrimeCheck) SetWorkerID(val OptString) {
+	s.WorkerID = val
+}
+
+// SetStartedAt sets the value of StartedAt.
+func (s *PrimeCheck) SetStartedAt(val OptDateTime) {
+	s.StartedAt = val
+}
+
+// SetFinishedAt sets the value of FinishedAt.
+func (s *PrimeCheck) SetFinishedAt(val OptDateTime) {
+	s.FinishedAt = val
+}
+
+// SetCalculationUs sets the value of CalculationUs.
+func (s *PrimeCheck) SetCalculationUs(val OptInt64) {
+	s.CalculationUs = val
+}
+
+// SetStatus sets the value of Status.
+func (s *PrimeCheck) SetStatus(val OptString) {
+	s.Status = val
+}
+
+// SetCertificateStatus sets the value of CertificateStatus.
+func (s *PrimeCheck) SetCertificateStatus(val OptString) {
+	s.CertificateStatus = val
+}
+
+// SetFactorizationStatus sets the value of FactorizationStatus.
+func (s *PrimeCheck) SetFactorizationStatus(val OptString) {
+	s.FactorizationStatus = val
+}
+
+// SetCached sets the value of Cached.
+func (s *PrimeCheck) SetCached(val OptBool) {
+	s.Cached = val
+}
+
+// SetFactors sets the value of Factors.
+func (s *PrimeCheck) SetFactors(val []PrimeFactor) {
+	s.Factors = val
+}
+
+// SetUnfactored sets the value of Unfactored.
+func (s *PrimeCheck) SetUnfactored(val OptString) {
+	s.Unfactored = val
+}
+
+type PrimeCheckAlgorithmParams map[string]string
+
+func (s *PrimeCheckAlgorithmParams) init() PrimeCheckAlgorithmParams {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/PrimeCheckBatch
+type PrimeCheckBatch struct {
+	ID           int32     `json:"id"`
+	Format       OptString `json:"format"`
+	UploadStatus OptString `json:"upload_status"`
+	Total        int64     `json:"total"`
+	Pending      int64     `json:"pending"`
+	Completed    int64     `json:"completed"`
+	Failed       int64     `json:"failed"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *PrimeCheckBatch) GetID() int32 {
+	return s.ID
+}
+
+// GetFormat returns the value of Format.
+func (s *PrimeCheckBatch) GetFormat() OptString {
+	return s.Format
+}
+
+// GetUploadStatus returns the value of UploadStatus.
+func (s *PrimeCheckBatch) GetUploadStatus() OptString {
+	return s.UploadStatus
+}
+
+// GetTotal returns the value of Total.
+func (s *PrimeCheckBatch) GetTotal() int64 {
+	return s.Total
+}
+
+// GetPending returns the value of Pending.
+func (s *PrimeCheckBatch) GetPending() int64 {
+	return s.Pending
+}
+
+// GetCompleted returns the value of Completed.
+func (s *PrimeCheckBatch) GetCompleted() int64 {
+	return s.Completed
+}
+
+// GetFailed returns the value of Failed.
+func (s *PrimeCheckBatch) GetFailed() int64 {
+	return s.Failed
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PrimeCheckBatch) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *PrimeCheckBatch) SetID(val int32) {
+	s.ID = val
+}
+
+// SetFormat sets the value of Format.
+func (s *PrimeCheckBatch) SetFormat(val OptString) {
+	s.Format = val
+}
+
+// SetUploadStatus sets the value of UploadStatus.
+func (s *PrimeCheckBatch) SetUploadStatus(val OptString) {
+	s.UploadStatus = val
+}
+
+// SetTotal sets the value of Total.
+func (s *PrimeCheckBatch) SetTotal(val int64) {
+	s.Total = val
+}
+
+// SetPending sets the value of Pending.
+func (s *PrimeCheckBatch) SetPending(val int64) {
+	s.Pending = val
+}
+
+// SetCompleted sets the value of Completed.
+func (s *PrimeCheckBatch) SetCompleted(val int64) {
+	s.Completed = val
+}
+
+// SetFailed sets the value of Failed.
+func (s *PrimeCheckBatch) SetFailed(val int64) {
+	s.Failed = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PrimeCheckBatch) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/PrimeCheckBatchInput
+type PrimeCheckBatchInput struct {
+	Numbers     []string                         `json:"numbers"`
+	Operation   OptPrimeCheckBatchInputOperation `json:"operation"`
+	Accuracy    OptAccuracy                      `json:"accuracy"`
+	Certify     OptBool                          `json:"certify"`
+	BypassCache OptBool                          `json:"bypass_cache"`
+}
+
+// GetNumbers returns the value of Numbers.
+func (s *PrimeCheckBatchInput) GetNumbers() []string {
+	return s.Numbers
+}
+
+// GetOperation returns the value of Operation.
+func (s *PrimeCheckBatchInput) GetOperation() OptPrimeCheckBatchInputOperation {
+	return s.Operation
+}
+
+// GetAccuracy returns the value of Accuracy.
+func (s *PrimeCheckBatchInput) GetAccuracy() OptAccuracy {
+	return s.Accuracy
+}
+
+// GetCertify returns the value of Certify.
+func (s *PrimeCheckBatchInput) GetCertify() OptBool {
+	return s.Certify
+}
+
+// GetBypassCache returns the value of BypassCache.
+func (s *PrimeCheckBatchInput) GetBypassCache() OptBool {
+	return s.BypassCache
+}
+
+// SetNumbers sets the value of Numbers.
+func (s *PrimeCheckBatchInput) SetNumbers(val []string) {
+	s.Numbers = val
+}
+
+// SetOperation sets the value of Operation.
+func (s *PrimeCheckBatchInput) SetOperation(val OptPrimeCheckBatchInputOperation) {
+	s.Operation = val
+}
+
+// SetAccuracy sets the value of Accuracy.
+func (s *PrimeCheckBatchInput) SetAccuracy(val OptAccuracy) {
+	s.Accuracy = val
+}
+
+// SetCertify sets the value of Certify.
+func (s *PrimeCheckBatchInput) SetCertify(val OptBool) {
+	s.Certify = val
+}
+
+// SetBypassCache sets the value of BypassCache.
+func (s *PrimeCheckBatchInput) SetBypassCache(val OptBool) {
+	s.BypassCache = val
+}
+
+type PrimeCheckBatchInputOperation string
+
+const (
+	PrimeCheckBatchInputOperationIsPrime   PrimeCheckBatchInputOperation = "is_prime"
+	PrimeCheckBatchInputOperationNextPrime PrimeCheckBatchInputOperation = "next_prime"
+	PrimeCheckBatchInputOperationPrevPrime PrimeCheckBatchInputOperation = "prev_prime"
+)
+
+// AllValues returns all PrimeCheckBatchInputOperation values.
+func (PrimeCheckBatchInputOperation) AllValues() []PrimeCheckBatchInputOperation {
+	return []PrimeCheckBatchInputOperation{
+		PrimeCheckBatchInputOperationIsPrime,
+		PrimeCheckBatchInputOperationNextPrime,
+		PrimeCheckBatchInputOperationPrevPrime,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PrimeCheckBatchInputOperation) MarshalText() ([]byte, error) {
+	switch s {
+	case PrimeCheckBatchInputOperationIsPrime:
+		return []byte(s), nil
+	case PrimeCheckBatchInputOperationNextPrime:
+		return []byte(s), nil
+	case PrimeCheckBatchInputOperationPrevPrime:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PrimeCheckBatchInputOperation) UnmarshalText(data []byte) error {
+	switch PrimeCheckBatchInputOperation(data) {
+	case PrimeCheckBatchInputOperationIsPrime:
+		*s = PrimeCheckBatchInputOperationIsPrime
+		return nil
+	case PrimeCheckBatchInputOperationNextPrime:
+		*s = PrimeCheckBatchInputOperationNextPrime
+		return nil
+	case PrimeCheckBatchInputOperationPrevPrime:
+		*s = PrimeCheckBatchInputOperationPrevPrime
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/PrimeCheckEvent
+type PrimeCheckEvent struct {
+	ID         int32     `json:"id"`
+	RequestID  int32     `json:"request_id"`
+	FromStatus OptString `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	TraceID    OptString `json:"trace_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *PrimeCheckEvent) GetID() int32 {
+	return s.ID
+}
+
+// GetRequestID returns the value of RequestID.
+func (s *PrimeCheckEvent) GetRequestID() int32 {
+	return s.RequestID
+}
+
+// GetFromStatus returns the value of FromStatus.
+func (s *PrimeCheckEvent) GetFromStatus() OptString {
+	return s.FromStatus
+}
+
+// GetToStatus returns the value of ToStatus.
+func (s *PrimeCheckEvent) GetToStatus() string {
+	return s.ToStatus
+}
+
+// GetActor returns the value of Actor.
+func (s *PrimeCheckEvent) GetActor() string {
+	return s.Actor
+}
+
+// GetTraceID returns the value of TraceID.
+func (s *PrimeCheckEvent) GetTraceID() OptString {
+	return s.TraceID
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PrimeCheckEvent) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *PrimeCheckEvent) SetID(val int32) {
+	s.ID = val
+}
+
+// SetRequestID sets the value of RequestID.
+func (s *PrimeCheckEvent) SetRequestID(val int32) {
+	s.RequestID = val
+}
+
+// SetFromStatus sets the value of FromStatus.
+func (s *PrimeCheckEvent) SetFromStatus(val OptString) {
+	s.FromStatus = val
+}
+
+// SetToStatus sets the value of ToStatus.
+func (s *PrimeCheckEvent) SetToStatus(val string) {
+	s.ToStatus = val
+}
+
+// SetActor sets the value of Actor.
+func (s *PrimeCheckEvent) SetActor(val string) {
+	s.Actor = val
+}
+
+// SetTraceID sets the value of TraceID.
+func (s *PrimeCheckEvent) SetTraceID(val OptString) {
+	s.TraceID = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PrimeCheckEvent) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/PrimeCheckEventList
+type PrimeCheckEventList struct {
+	Items []PrimeCheckEvent `json:"items"`
+}
+
+// GetItems returns the value of Items.
+func (s *PrimeCheckEventList) GetItems() []PrimeCheckEvent {
+	return s.Items
+}
+
+// SetItems sets the value of Items.
+func (s *PrimeCheckEventList) SetItems(val []PrimeCheckEvent) {
+	s.Items = val
+}
+
+// Ref: #/components/schemas/PrimeCheckInput
+type PrimeCheckInput struct {
+	Number         string                      `json:"number"`
+	Operation      OptPrimeCheckInputOperation `json:"operation"`
+	Accuracy       OptAccuracy                 `json:"accuracy"`
+	Algorithm      OptPrimalityAlgorithm       `json:"algorithm"`
+	Certify        OptBool                     `json:"certify"`
+	BypassCache    OptBool                     `json:"bypass_cache"`
+	CallbackURL    OptString                   `json:"callback_url"`
+	TimeoutSeconds OptInt32                    `json:"timeout_seconds"`
+}
+
+// GetNumber returns the value of Number.
+func (s *PrimeCheckInput) GetNumber() string {
+	return s.Number
+}
+
+// GetOperation returns the value of Operation.
+func (s *PrimeCheckInput) GetOperation() OptPrimeCheckInputOperation {
+	return s.Operation
+}
+
+// GetAccuracy returns the value of Accuracy.
+func (s *PrimeCheckInput) GetAccuracy() OptAccuracy {
+	return s.Accuracy
+}
+
+// GetAlgorithm returns the value of Algorithm.
+func (s *PrimeCheckInput) GetAlgorithm() OptPrimalityAlgorithm {
+	return s.Algorithm
+}
+
+// GetCertify returns the value of Certify.
+func (s *PrimeCheckInput) GetCertify() OptBool {
+	return s.Certify
+}
+
+// GetBypassCache returns the value of BypassCache.
+func (s *PrimeCheckInput) GetBypassCache() OptBool {
+	return s.BypassCache
+}
+
+// GetCallbackURL returns the value of CallbackURL.
+func (s *PrimeCheckInput) GetCallbackURL() OptString {
+	return s.CallbackURL
+}
+
+// GetTimeoutSeconds returns the value of TimeoutSeconds.
+func (s *PrimeCheckInput) GetTimeoutSeconds() OptInt32 {
+	return s.TimeoutSeconds
+}
+
+// SetNumber sets the value of Number.
+func (s *PrimeCheckInput) SetNumber(val string) {
+	s.Number = val
+}
+
+// SetOperation sets the value of Operation.
+func (s *PrimeCheckInput) SetOperation(val OptPrimeCheckInputOperation) {
+	s.Operation = val
+}
+
+// SetAccuracy sets the value of Accuracy.
+func (s *PrimeCheckInput) SetAccuracy(val OptAccuracy) {
+	s.Accuracy = val
+}
+
+// SetAlgorithm sets the value of Algorithm.
+func (s *PrimeCheckInput) SetAlgorithm(val OptPrimalityAlgorithm) {
+	s.Algorithm = val
+}
+
+// SetCertify sets the value of Certify.
+func (s *PrimeCheckInput) SetCertify(val OptBool) {
+	s.Certify = val
+}
+
+// SetBypassCache sets the value of BypassCache.
+func (s *PrimeCheckInput) SetBypassCache(val OptBool) {
+	s.BypassCache = val
+}
+
+// SetCallbackURL sets the value of CallbackURL.
+func (s *PrimeCheckInput) SetCallbackURL(val OptString) {
+	s.CallbackURL = val
+}
+
+// SetTimeoutSeconds sets the value of TimeoutSeconds.
+func (s *PrimeCheckInput) SetTimeoutSeconds(val OptInt32) {
+	s.TimeoutSeconds = val
+}
+
+type PrimeCheckInputOperation string
+
+const (
+	PrimeCheckInputOperationIsPrime   PrimeCheckInputOperation = "is_prime"
+	PrimeCheckInputOperationNextPrime PrimeCheckInputOperation = "next_prime"
+	PrimeCheckInputOperationPrevPrime PrimeCheckInputOperation = "prev_prime"
+)
+
+// AllValues returns all PrimeCheckInputOperation values.
+func (PrimeCheckInputOperation) AllValues() []PrimeCheckInputOperation {
+	return []PrimeCheckInputOperation{
+		PrimeCheckInputOperationIsPrime,
+		PrimeCheckInputOperationNextPrime,
+		PrimeCheckInputOperationPrevPrime,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PrimeCheckInputOperation) MarshalText() ([]byte, error) {
+	switch s {
+	case PrimeCheckInputOperationIsPrime:
+		return []byte(s), nil
+	case PrimeCheckInputOperationNextPrime:
+		return []byte(s), nil
+	case PrimeCheckInputOperationPrevPrime:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PrimeCheckInputOperation) UnmarshalText(data []byte) error {
+	switch PrimeCheckInputOperation(data) {
+	case PrimeCheckInputOperationIsPrime:
+		*s = PrimeCheckInputOperationIsPrime
+		return nil
+	case PrimeCheckInputOperationNextPrime:
+		*s = PrimeCheckInputOperationNextPrime
+		return nil
+	case PrimeCheckInputOperationPrevPrime:
+		*s = PrimeCheckInputOperationPrevPrime
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/PrimeCheckList
+type PrimeCheckList struct {
+	Items      []PrimeCheck `json:"items"`
+	NextCursor OptString    `json:"next_cursor"`
+}
+
+// GetItems returns the value of Items.
+func (s *PrimeCheckList) GetItems() []PrimeCheck {
+	return s.Items
+}
+
+// GetNextCursor returns the value of NextCursor.
+func (s *PrimeCheckList) GetNextCursor() OptString {
+	return s.NextCursor
+}
+
+// SetItems sets the value of Items.
+func (s *PrimeCheckList) SetItems(val []PrimeCheck) {
+	s.Items = val
+}
+
+// SetNextCursor sets the value of NextCursor.
+func (s *PrimeCheckList) SetNextCursor(val OptString) {
+	s.NextCursor = val
+}
+
+type PrimeChecksDownloadBatchOKApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PrimeChecksDownloadBatchOKApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*PrimeChecksDownloadBatchOKApplicationXNdjson) primeChecksDownloadBatchRes() {}
+
+type PrimeChecksDownloadBatchOKTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PrimeChecksDownloadBatchOKTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*PrimeChecksDownloadBatchOKTextCsv) primeChecksDownloadBatchRes() {}
+
+type PrimeChecksStreamAllOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PrimeChecksStreamAllOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+type PrimeChecksStreamOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PrimeChecksStreamOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+type PrimeChecksUploadBatchReqApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PrimeChecksUploadBatchReqApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*PrimeChecksUploadBatchReqApplicationXNdjson) primeChecksUploadBatchReq() {}
+
+type PrimeChecksUploadBatchReqTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s PrimeChecksUploadBatchReqTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*PrimeChecksUploadBatchReqTextCsv) primeChecksUploadBatchReq() {}
+
+// Ref: #/components/schemas/PrimeFactor
+type PrimeFactor struct {
+	Prime    string `json:"prime"`
+	Exponent int32  `json:"exponent"`
+}
+
+// GetPrime returns the value of Prime.
+func (s *PrimeFactor) GetPrime() string {
+	return s.Prime
+}
+
+// GetExponent returns the value of Exponent.
+func (s *PrimeFactor) GetExponent() int32 {
+	return s.Exponent
+}
+
+// SetPrime sets the value of Prime.
+func (s *PrimeFactor) SetPrime(val string) {
+	s.Prime = val
+}
+
+// SetExponent sets the value of Exponent.
+func (s *PrimeFactor) SetExponent(val int32) {
+	s.Exponent = val
+}
+
+// Ref: #/components/schemas/PrimeOperation
+type PrimeOperation string
+
+const (
+	PrimeOperationIsPrime   PrimeOperation = "is_prime"
+	PrimeOperationNextPrime PrimeOperation = "next_prime"
+	PrimeOperationPrevPrime PrimeOperation = "prev_prime"
+)
+
+// AllValues returns all PrimeOperation values.
+func (PrimeOperation) AllValues() []PrimeOperation {
+	return []PrimeOperation{
+		PrimeOperationIsPrime,
+		PrimeOperationNextPrime,
+		PrimeOperationPrevPrime,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PrimeOperation) MarshalText() ([]byte, error) {
+	switch s {
+	case PrimeOperationIsPrime:
+		return []byte(s), nil
+	case PrimeOperationNextPrime:
+		return []byte(s), nil
+	case PrimeOperationPrevPrime:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PrimeOperation) UnmarshalText(data []byte) error {
+	switch PrimeOperation(data) {
+	case PrimeOperationIsPrime:
+		*s = PrimeOperationIsPrime
+		return nil
+	case PrimeOperationNextPrime:
+		*s = PrimeOperationNextPrime
+		return nil
+	case PrimeOperationPrevPrime:
+		*s = PrimeOperationPrevPrime
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/PrimeRange
+type PrimeRange struct {
+	ID         int32     `json:"id"`
+	Start      string    `json:"start"`
+	End        string    `json:"end"`
+	CountOnly  bool      `json:"count_only"`
+	PrimeCount OptInt64  `json:"prime_count"`
+	Status     OptString `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *PrimeRange) GetID() int32 {
+	return s.ID
+}
+
+// GetStart returns the value of Start.
+func (s *PrimeRange) GetStart() string {
+	return s.Start
+}
+
+// GetEnd returns the value of End.
+func (s *PrimeRange) GetEnd() string {
+	return s.End
+}
+
+// GetCountOnly returns the value of CountOnly.
+func (s *PrimeRange) GetCountOnly() bool {
+	return s.CountOnly
+}
+
+// GetPrimeCount returns the value of PrimeCount.
+func (s *PrimeRange) GetPrimeCount() OptInt64 {
+	return s.PrimeCount
+}
+
+// GetStatus returns the value of Status.
+func (s *PrimeRange) GetStatus() OptString {
+	return s.Status
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PrimeRange) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *PrimeRange) SetID(val int32) {
+	s.ID = val
+}
+
+// SetStart sets the value of Start.
+func (s *PrimeRange) SetStart(val string) {
+	s.Start = val
+}
+
+// SetEnd sets the value of End.
+func (s *PrimeRange) SetEnd(val string) {
+	s.End = val
+}
+
+// SetCountOnly sets the value of CountOnly.
+func (s *PrimeRange) SetCountOnly(val bool) {
+	s.CountOnly = val
+}
+
+// SetPrimeCount sets the value of PrimeCount.
+func (s *PrimeRange) SetPrimeCount(val OptInt64) {
+	s.PrimeCount = val
+}
+
+// SetStatus sets the value of Status.
+func (s *PrimeRange) SetStatus(val OptString) {
+	s.Status = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PrimeRange) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/PrimeRangeInput
+type PrimeRangeInput struct {
+	Start     string  `json:"start"`
+	End       string  `json:"end"`
+	CountOnly OptBool `json:"count_only"`
+}
+
+// GetStart returns the value of Start.
+func (s *PrimeRangeInput) GetStart() string {
+	return s.Start
+}
+
+// GetEnd returns the value of End.
+func (s *PrimeRangeInput) GetEnd() string {
+	return s.End
+}
+
+// GetCountOnly returns the value of CountOnly.
+func (s *PrimeRangeInput) GetCountOnly() OptBool {
+	return s.CountOnly
+}
+
+// SetStart sets the value of Start.
+func (s *PrimeRangeInput) SetStart(val string) {
+	s.Start = val
+}
+
+// SetEnd sets the value of End.
+func (s *PrimeRangeInput) SetEnd(val string) {
+	s.End = val
+}
+
+// SetCountOnly sets the value of CountOnly.
+func (s *PrimeRangeInput) SetCountOnly(val OptBool) {
+	s.CountOnly = val
+}
+
+// Ref: #/components/schemas/PrimeRangePrimes
+type PrimeRangePrimes struct {
+	RangeID    int32    `json:"range_id"`
+	Offset     int64    `json:"offset"`
+	Items      []int64  `json:"items"`
+	NextOffset OptInt64 `json:"next_offset"`
+}
+
+// GetRangeID returns the value of RangeID.
+func (s *PrimeRangePrimes) GetRangeID() int32 {
+	return s.RangeID
+}
+
+// GetOffset returns the value of Offset.
+func (s *PrimeRangePrimes) GetOffset() int64 {
+	return s.Offset
+}
+
+// GetItems returns the value of Items.
+func (s *PrimeRangePrimes) GetItems() []int64 {
+	return s.Items
+}
+
+// GetNextOffset returns the value of NextOffset.
+func (s *PrimeRangePrimes) GetNextOffset() OptInt64 {
+	return s.NextOffset
+}
+
+// SetRangeID sets the value of RangeID.
+func (s *PrimeRangePrimes) SetRangeID(val int32) {
+	s.RangeID = val
+}
+
+// SetOffset sets the value of Offset.
+func (s *PrimeRangePrimes) SetOffset(val int64) {
+	s.Offset = val
+}
+
+// SetItems sets the value of Items.
+func (s *PrimeRangePrimes) SetItems(val []int64) {
+	s.Items = val
+}
+
+// SetNextOffset sets the value of NextOffset.
+func (s *PrimeRangePrimes) SetNextOffset(val OptInt64) {
+	s.NextOffset = val
+}
+
+// Ref: #/components/schemas/Setting
+type Setting struct {
+	RecordNumberSuccess bool `json:"record_number_success"`
+	PrimeCheckSuccess   bool `json:"prime_check_success"`
+	EmailSendSuccess    bool `json:"email_send_success"`
+	DlqSaveSuccess      bool `json:"dlq_save_success"`
+}
+
+// GetRecordNumberSuccess returns the value of RecordNumberSuccess.
+func (s *Setting) GetRecordNumberSuccess() bool {
+	return s.RecordNumberSuccess
+}
+
+// GetPrimeCheckSuccess returns the value of PrimeCheckSuccess.
+func (s *Setting) GetPrimeCheckSuccess() bool {
+	return s.PrimeCheckSuccess
+}
+
+// GetEmailSendSuccess returns the value of EmailSendSuccess.
 func (s *Setting) GetEmailSendSuccess() bool {
 	return s.EmailSendSuccess
 }
@@ -313,3 +2199,215 @@ func (s *Setting) SetEmailSendSuccess(val bool) {
 func (s *Setting) SetDlqSaveSuccess(val bool) {
 	s.DlqSaveSuccess = val
 }
+
+// Ref: #/components/schemas/SortOrder
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// AllValues returns all SortOrder values.
+func (SortOrder) AllValues() []SortOrder {
+	return []SortOrder{
+		SortOrderAsc,
+		SortOrderDesc,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SortOrder) MarshalText() ([]byte, error) {
+	switch s {
+	case SortOrderAsc:
+		return []byte(s), nil
+	case SortOrderDesc:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SortOrder) UnmarshalText(data []byte) error {
+	switch SortOrder(data) {
+	case SortOrderAsc:
+		*s = SortOrderAsc
+		return nil
+	case SortOrderDesc:
+		*s = SortOrderDesc
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/UserToken
+type UserToken struct {
+	UserID        int32     `json:"user_id"`
+	Token         string    `json:"token"`
+	WebhookSecret OptString `json:"webhook_secret"`
+}
+
+// GetUserID returns the value of UserID.
+func (s *UserToken) GetUserID() int32 {
+	return s.UserID
+}
+
+// GetToken returns the value of Token.
+func (s *UserToken) GetToken() string {
+	return s.Token
+}
+
+// GetWebhookSecret returns the value of WebhookSecret.
+func (s *UserToken) GetWebhookSecret() OptString {
+	return s.WebhookSecret
+}
+
+// SetUserID sets the value of UserID.
+func (s *UserToken) SetUserID(val int32) {
+	s.UserID = val
+}
+
+// SetToken sets the value of Token.
+func (s *UserToken) SetToken(val string) {
+	s.Token = val
+}
+
+// SetWebhookSecret sets the value of WebhookSecret.
+func (s *UserToken) SetWebhookSecret(val OptString) {
+	s.WebhookSecret = val
+}
+
+// Ref: #/components/schemas/WebhookDelivery
+type WebhookDelivery struct {
+	ID         int32     `json:"id"`
+	RequestID  int32     `json:"request_id"`
+	URL        string    `json:"url"`
+	Attempt    int32     `json:"attempt"`
+	StatusCode OptInt32  `json:"status_code"`
+	Error      OptString `json:"error"`
+	Success    bool      `json:"success"`
+	DurationUs int64     `json:"duration_us"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetID returns the value of ID.
+func (s *WebhookDelivery) GetID() int32 {
+	return s.ID
+}
+
+// GetRequestID returns the value of RequestID.
+func (s *WebhookDelivery) GetRequestID() int32 {
+	return s.RequestID
+}
+
+// GetURL returns the value of URL.
+func (s *WebhookDelivery) GetURL() string {
+	return s.URL
+}
+
+// GetAttempt returns the value of Attempt.
+func (s *WebhookDelivery) GetAttempt() int32 {
+	return s.Attempt
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *WebhookDelivery) GetStatusCode() OptInt32 {
+	return s.StatusCode
+}
+
+// GetError returns the value of Error.
+func (s *WebhookDelivery) GetError() OptString {
+	return s.Error
+}
+
+// GetSuccess returns the value of Success.
+func (s *WebhookDelivery) GetSuccess() bool {
+	return s.Success
+}
+
+// GetDurationUs returns the value of DurationUs.
+func (s *WebhookDelivery) GetDurationUs() int64 {
+	return s.DurationUs
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *WebhookDelivery) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *WebhookDelivery) SetID(val int32) {
+	s.ID = val
+}
+
+// SetRequestID sets the value of RequestID.
+func (s *WebhookDelivery) SetRequestID(val int32) {
+	s.RequestID = val
+}
+
+// SetURL sets the value of URL.
+func (s *WebhookDelivery) SetURL(val string) {
+	s.URL = val
+}
+
+// SetAttempt sets the value of Attempt.
+func (s *WebhookDelivery) SetAttempt(val int32) {
+	s.Attempt = val
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *WebhookDelivery) SetStatusCode(val OptInt32) {
+	s.StatusCode = val
+}
+
+// SetError sets the value of Error.
+func (s *WebhookDelivery) SetError(val OptString) {
+	s.Error = val
+}
+
+// SetSuccess sets the value of Success.
+func (s *WebhookDelivery) SetSuccess(val bool) {
+	s.Success = val
+}
+
+// SetDurationUs sets the value of DurationUs.
+func (s *WebhookDelivery) SetDurationUs(val int64) {
+	s.DurationUs = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *WebhookDelivery) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/WebhookDeliveryList
+type WebhookDeliveryList struct {
+	Items []WebhookDelivery `json:"items"`
+}
+
+// GetItems returns the value of Items.
+func (s *WebhookDeliveryList) GetItems() []WebhookDelivery {
+	return s.Items
+}
+
+// SetItems sets the value of Items.
+func (s *WebhookDeliveryList) SetItems(val []WebhookDelivery) {
+	s.Items = val
+}
+
+// Ref: #/components/schemas/WebhookSecret
+type WebhookSecret struct {
+	WebhookSecret string `json:"webhook_secret"`
+}
+
+// GetWebhookSecret returns the value of WebhookSecret.
+func (s *WebhookSecret) GetWebhookSecret() string {
+	return s.WebhookSecret
+}
+
+// SetWebhookSecret sets the value of WebhookSecret.
+func (s *WebhookSecret) SetWebhookSecret(val string) {
+	s.WebhookSecret = val
+}
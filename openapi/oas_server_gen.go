@@ -8,18 +8,78 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// PrimeChecksCancel implements PrimeChecks_cancel operation.
+	//
+	// DELETE /prime-check/{request_id}
+	PrimeChecksCancel(ctx context.Context, params PrimeChecksCancelParams) (*PrimeCheck, error)
 	// PrimeChecksCreate implements PrimeChecks_create operation.
 	//
 	// POST /prime-check
-	PrimeChecksCreate(ctx context.Context, req *PrimeCheckInput) (*PrimeCheck, error)
+	PrimeChecksCreate(ctx context.Context, req *PrimeCheckInput, params PrimeChecksCreateParams) (*PrimeCheck, error)
+	// PrimeChecksCreateBatch implements PrimeChecks_createBatch operation.
+	//
+	// POST /prime-check/batch
+	PrimeChecksCreateBatch(ctx context.Context, req *PrimeCheckBatchInput) (*PrimeCheckBatch, error)
+	// PrimeChecksDownloadBatch implements PrimeChecks_downloadBatch operation.
+	//
+	// GET /prime-check/batch/{batch_id}/download
+	PrimeChecksDownloadBatch(ctx context.Context, params PrimeChecksDownloadBatchParams) (PrimeChecksDownloadBatchRes, error)
 	// PrimeChecksGet implements PrimeChecks_get operation.
 	//
 	// GET /prime-check/{request_id}
 	PrimeChecksGet(ctx context.Context, params PrimeChecksGetParams) (*PrimeCheck, error)
+	// PrimeChecksGetBatch implements PrimeChecks_getBatch operation.
+	//
+	// GET /prime-check/batch/{batch_id}
+	PrimeChecksGetBatch(ctx context.Context, params PrimeChecksGetBatchParams) (*PrimeCheckBatch, error)
+	// PrimeChecksGetCertificate implements PrimeChecks_getCertificate operation.
+	//
+	// GET /prime-check/{request_id}/certificate
+	PrimeChecksGetCertificate(ctx context.Context, params PrimeChecksGetCertificateParams) (*PrimeCertificate, error)
 	// PrimeChecksList implements PrimeChecks_list operation.
 	//
 	// GET /prime-check
-	PrimeChecksList(ctx context.Context) (*PrimeCheckList, error)
+	PrimeChecksList(ctx context.Context, params PrimeChecksListParams) (*PrimeCheckList, error)
+	// PrimeChecksListBatchResults implements PrimeChecks_listBatchResults operation.
+	//
+	// GET /prime-check/batch/{batch_id}/results
+	PrimeChecksListBatchResults(ctx context.Context, params PrimeChecksListBatchResultsParams) (*PrimeCheckList, error)
+	// PrimeChecksListEvents implements PrimeChecks_listEvents operation.
+	//
+	// GET /prime-check/{request_id}/events
+	PrimeChecksListEvents(ctx context.Context, params PrimeChecksListEventsParams) (*PrimeCheckEventList, error)
+	// PrimeChecksListWebhookDeliveries implements PrimeChecks_listWebhookDeliveries operation.
+	//
+	// GET /prime-check/{request_id}/webhook-deliveries
+	PrimeChecksListWebhookDeliveries(ctx context.Context, params PrimeChecksListWebhookDeliveriesParams) (*WebhookDeliveryList, error)
+	// PrimeChecksRetry implements PrimeChecks_retry operation.
+	//
+	// POST /prime-check/{request_id}/retry
+	PrimeChecksRetry(ctx context.Context, params PrimeChecksRetryParams) (*PrimeCheck, error)
+	// PrimeChecksStream implements PrimeChecks_stream operation.
+	//
+	// GET /prime-check/{request_id}/stream
+	PrimeChecksStream(ctx context.Context, params PrimeChecksStreamParams) (PrimeChecksStreamOK, error)
+	// PrimeChecksStreamAll implements PrimeChecks_streamAll operation.
+	//
+	// GET /prime-check/stream
+	PrimeChecksStreamAll(ctx context.Context, params PrimeChecksStreamAllParams) (PrimeChecksStreamAllOK, error)
+	// PrimeChecksUploadBatch implements PrimeChecks_uploadBatch operation.
+	//
+	// POST /prime-check/batch/upload
+	PrimeChecksUploadBatch(ctx context.Context, req PrimeChecksUploadBatchReq, params PrimeChecksUploadBatchParams) (*PrimeCheckBatch, error)
+	// PrimeRangesCreate implements PrimeRanges_create operation.
+	//
+	// POST /prime-range
+	PrimeRangesCreate(ctx context.Context, req *PrimeRangeInput) (*PrimeRange, error)
+	// PrimeRangesGet implements PrimeRanges_get operation.
+	//
+	// GET /prime-range/{range_id}
+	PrimeRangesGet(ctx context.Context, params PrimeRangesGetParams) (*PrimeRange, error)
+	// PrimeRangesListPrimes implements PrimeRanges_listPrimes operation.
+	//
+	// GET /prime-range/{range_id}/primes
+	PrimeRangesListPrimes(ctx context.Context, params PrimeRangesListPrimesParams) (*PrimeRangePrimes, error)
 	// SettingsCreate implements Settings_create operation.
 	//
 	// POST /settings
@@ -28,6 +88,18 @@ type Handler interface {
 	//
 	// GET /settings
 	SettingsGet(ctx context.Context) (*Setting, error)
+	// UsersRegister implements Users_register operation.
+	//
+	// POST /users
+	UsersRegister(ctx context.Context) (*UserToken, error)
+	// UsersRotateToken implements Users_rotateToken operation.
+	//
+	// POST /users/me/token
+	UsersRotateToken(ctx context.Context) (*UserToken, error)
+	// UsersRotateWebhookSecret implements Users_rotateWebhookSecret operation.
+	//
+	// POST /users/me/webhook-secret
+	UsersRotateWebhookSecret(ctx context.Context) (*WebhookSecret, error)
 	// NewError creates *ErrorStatusCode from error returned by handler.
 	//
 	// Used for common default response.
@@ -37,18 +109,20 @@ type Handler interface {
 // Server implements http server based on OpenAPI v3 specification and
 // calls Handler to handle requests.
 type Server struct {
-	h Handler
+	h   Handler
+	sec SecurityHandler
 	baseServer
 }
 
 // NewServer creates new Server.
-func NewServer(h Handler, opts ...ServerOption) (*Server, error) {
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
 	s, err := newServerConfig(opts...).baseServer()
 	if err != nil {
 		return nil, err
 	}
 	return &Server{
 		h:          h,
+		sec:        sec,
 		baseServer: s,
 	}, nil
 }
@@ -13,10 +13,31 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// PrimeChecksCancel implements PrimeChecks_cancel operation.
+//
+// DELETE /prime-check/{request_id}
+func (UnimplementedHandler) PrimeChecksCancel(ctx context.Context, params PrimeChecksCancelParams) (r *PrimeCheck, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // PrimeChecksCreate implements PrimeChecks_create operation.
 //
 // POST /prime-check
-func (UnimplementedHandler) PrimeChecksCreate(ctx context.Context, req *PrimeCheckInput) (r *PrimeCheck, _ error) {
+func (UnimplementedHandler) PrimeChecksCreate(ctx context.Context, req *PrimeCheckInput, params PrimeChecksCreateParams) (r *PrimeCheck, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksCreateBatch implements PrimeChecks_createBatch operation.
+//
+// POST /prime-check/batch
+func (UnimplementedHandler) PrimeChecksCreateBatch(ctx context.Context, req *PrimeCheckBatchInput) (r *PrimeCheckBatch, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksDownloadBatch implements PrimeChecks_downloadBatch operation.
+//
+// GET /prime-check/batch/{batch_id}/download
+func (UnimplementedHandler) PrimeChecksDownloadBatch(ctx context.Context, params PrimeChecksDownloadBatchParams) (r PrimeChecksDownloadBatchRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -27,10 +48,94 @@ func (UnimplementedHandler) PrimeChecksGet(ctx context.Context, params PrimeChec
 	return r, ht.ErrNotImplemented
 }
 
+// PrimeChecksGetBatch implements PrimeChecks_getBatch operation.
+//
+// GET /prime-check/batch/{batch_id}
+func (UnimplementedHandler) PrimeChecksGetBatch(ctx context.Context, params PrimeChecksGetBatchParams) (r *PrimeCheckBatch, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksGetCertificate implements PrimeChecks_getCertificate operation.
+//
+// GET /prime-check/{request_id}/certificate
+func (UnimplementedHandler) PrimeChecksGetCertificate(ctx context.Context, params PrimeChecksGetCertificateParams) (r *PrimeCertificate, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // PrimeChecksList implements PrimeChecks_list operation.
 //
 // GET /prime-check
-func (UnimplementedHandler) PrimeChecksList(ctx context.Context) (r *PrimeCheckList, _ error) {
+func (UnimplementedHandler) PrimeChecksList(ctx context.Context, params PrimeChecksListParams) (r *PrimeCheckList, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksListBatchResults implements PrimeChecks_listBatchResults operation.
+//
+// GET /prime-check/batch/{batch_id}/results
+func (UnimplementedHandler) PrimeChecksListBatchResults(ctx context.Context, params PrimeChecksListBatchResultsParams) (r *PrimeCheckList, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksListEvents implements PrimeChecks_listEvents operation.
+//
+// GET /prime-check/{request_id}/events
+func (UnimplementedHandler) PrimeChecksListEvents(ctx context.Context, params PrimeChecksListEventsParams) (r *PrimeCheckEventList, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksListWebhookDeliveries implements PrimeChecks_listWebhookDeliveries operation.
+//
+// GET /prime-check/{request_id}/webhook-deliveries
+func (UnimplementedHandler) PrimeChecksListWebhookDeliveries(ctx context.Context, params PrimeChecksListWebhookDeliveriesParams) (r *WebhookDeliveryList, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksRetry implements PrimeChecks_retry operation.
+//
+// POST /prime-check/{request_id}/retry
+func (UnimplementedHandler) PrimeChecksRetry(ctx context.Context, params PrimeChecksRetryParams) (r *PrimeCheck, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksStream implements PrimeChecks_stream operation.
+//
+// GET /prime-check/{request_id}/stream
+func (UnimplementedHandler) PrimeChecksStream(ctx context.Context, params PrimeChecksStreamParams) (r PrimeChecksStreamOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksStreamAll implements PrimeChecks_streamAll operation.
+//
+// GET /prime-check/stream
+func (UnimplementedHandler) PrimeChecksStreamAll(ctx context.Context, params PrimeChecksStreamAllParams) (r PrimeChecksStreamAllOK, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeChecksUploadBatch implements PrimeChecks_uploadBatch operation.
+//
+// POST /prime-check/batch/upload
+func (UnimplementedHandler) PrimeChecksUploadBatch(ctx context.Context, req PrimeChecksUploadBatchReq, params PrimeChecksUploadBatchParams) (r *PrimeCheckBatch, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeRangesCreate implements PrimeRanges_create operation.
+//
+// POST /prime-range
+func (UnimplementedHandler) PrimeRangesCreate(ctx context.Context, req *PrimeRangeInput) (r *PrimeRange, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeRangesGet implements PrimeRanges_get operation.
+//
+// GET /prime-range/{range_id}
+func (UnimplementedHandler) PrimeRangesGet(ctx context.Context, params PrimeRangesGetParams) (r *PrimeRange, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PrimeRangesListPrimes implements PrimeRanges_listPrimes operation.
+//
+// GET /prime-range/{range_id}/primes
+func (UnimplementedHandler) PrimeRangesListPrimes(ctx context.Context, params PrimeRangesListPrimesParams) (r *PrimeRangePrimes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -48,6 +153,27 @@ func (UnimplementedHandler) SettingsGet(ctx context.Context) (r *Setting, _ erro
 	return r, ht.ErrNotImplemented
 }
 
+// UsersRegister implements Users_register operation.
+//
+// POST /users
+func (UnimplementedHandler) UsersRegister(ctx context.Context) (r *UserToken, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UsersRotateToken implements Users_rotateToken operation.
+//
+// POST /users/me/token
+func (UnimplementedHandler) UsersRotateToken(ctx context.Context) (r *UserToken, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UsersRotateWebhookSecret implements Users_rotateWebhookSecret operation.
+//
+// POST /users/me/webhook-secret
+func (UnimplementedHandler) UsersRotateWebhookSecret(ctx context.Context) (r *WebhookSecret, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // NewError creates *ErrorStatusCode from error returned by handler.
 //
 // Used for common default response.
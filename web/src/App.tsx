@@ -1,7 +1,25 @@
+import { useState } from 'react'
+import { useQueryClient } from '@tanstack/react-query'
 import PrimeInputForm from './components/PrimeInputForm'
 import PrimeResultsList from './components/PrimeResultsList'
+import TokenForm from './components/TokenForm'
+import { clearToken, getToken, setToken } from './auth'
 
 function App() {
+  const queryClient = useQueryClient()
+  const [token, setTokenState] = useState(getToken)
+
+  const handleTokenChange = (newToken: string | null) => {
+    if (newToken) {
+      setToken(newToken)
+    } else {
+      clearToken()
+    }
+    setTokenState(newToken)
+    // Results of the previous user must not show for the next one
+    queryClient.clear()
+  }
+
   return (
     <div className="max-w-3xl mx-auto px-6 py-12">
       <div className="text-center mb-8">
@@ -12,8 +30,13 @@ function App() {
           Test for prime numbers and track how they move through our system
         </p>
       </div>
-      <PrimeInputForm />
-      <PrimeResultsList />
+      <TokenForm token={token} onChange={handleTokenChange} />
+      {token && (
+        <>
+          <PrimeInputForm />
+          <PrimeResultsList />
+        </>
+      )}
     </div>
   )
 }
@@ -0,0 +1,36 @@
+import axios from 'axios'
+
+const tokenStorageKey = 'prime-checker-token'
+
+export const getToken = (): string | null => localStorage.getItem(tokenStorageKey)
+
+export const setToken = (token: string) => {
+  localStorage.setItem(tokenStorageKey, token)
+}
+
+export const clearToken = () => {
+  localStorage.removeItem(tokenStorageKey)
+}
+
+// The generated client calls the API through the default axios instance, so
+// every call carries the stored token
+axios.interceptors.request.use((config) => {
+  const token = getToken()
+  if (token) {
+    config.headers.Authorization = `Bearer ${token}`
+  }
+  return config
+})
+
+interface UserToken {
+  user_id: number
+  token: string
+  webhook_secret?: string
+}
+
+// registerUser registers a new user and returns its token, which the API
+// shows only this once
+export const registerUser = async (): Promise<string> => {
+  const response = await axios.post<UserToken>('http://localhost:8080/users')
+  return response.data.token
+}
\ No newline at end of file
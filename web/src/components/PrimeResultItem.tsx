@@ -69,15 +69,20 @@ const PrimeResultItem: React.FC<PrimeResultItemProps> = ({ primeCheck }) => {
 
     const baseClass = "px-3 py-1.5 rounded-2xl text-xs font-medium uppercase tracking-wider"
 
+    // One entry per status of the prime check state machine
     const statusClass = {
+      queued: "bg-gray-100 text-gray-800",
+      dispatched: "bg-blue-100 text-blue-800",
+      computing: "bg-yellow-100 text-yellow-800",
       completed: "bg-green-100 text-green-800",
-      processing: "bg-yellow-100 text-yellow-800",
-      failed: "bg-red-100 text-red-800"
+      failed: "bg-red-100 text-red-800",
+      timed_out: "bg-orange-100 text-orange-800",
+      cancelled: "bg-gray-100 text-gray-500"
     }[status] || "bg-gray-100 text-gray-800"
 
     return (
       <span className={`${baseClass} ${statusClass}`}>
-        {status}
+        {status.replace('_', ' ')}
       </span>
     )
   }
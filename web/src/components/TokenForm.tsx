@@ -0,0 +1,86 @@
+import React, { useState } from 'react'
+import { registerUser } from '../auth'
+
+interface TokenFormProps {
+  token: string | null
+  onChange: (token: string | null) => void
+}
+
+const TokenForm: React.FC<TokenFormProps> = ({ token, onChange }) => {
+  const [input, setInput] = useState('')
+  const [registering, setRegistering] = useState(false)
+  const [error, setError] = useState<string | null>(null)
+
+  const handleSubmit = (e: React.FormEvent) => {
+    e.preventDefault()
+    if (input.trim()) {
+      onChange(input.trim())
+      setInput('')
+    }
+  }
+
+  const handleRegister = async () => {
+    setRegistering(true)
+    setError(null)
+    try {
+      onChange(await registerUser())
+    } catch (err) {
+      setError(err instanceof Error ? err.message : 'Registration failed')
+    } finally {
+      setRegistering(false)
+    }
+  }
+
+  if (token) {
+    return (
+      <div className="flex items-center justify-end gap-3 mb-8 text-sm text-gray-500">
+        Signed in with token {token.slice(0, 8)}…
+        <button
+          type="button"
+          onClick={() => onChange(null)}
+          className="px-3 py-2 text-sm font-semibold bg-white text-gray-900 rounded-md ring-1 ring-inset ring-gray-300 hover:bg-gray-50"
+        >
+          Sign out
+        </button>
+      </div>
+    )
+  }
+
+  return (
+    <div className="mb-12">
+      <form onSubmit={handleSubmit} className="flex gap-3 mb-4">
+        <input
+          type="password"
+          value={input}
+          onChange={(e) => setInput(e.target.value)}
+          placeholder="Paste your API token"
+          className="flex-1 px-4 py-3 text-base border-2 border-gray-300 rounded-lg bg-white font-sans transition-colors focus:outline-none focus:border-blue-500 focus:ring-2 focus:ring-blue-100 placeholder-gray-400"
+        />
+        <button
+          type="submit"
+          className="px-6 py-3 text-base font-medium bg-gray-900 text-white border-none rounded-lg cursor-pointer transition-colors hover:bg-gray-700 whitespace-nowrap"
+        >
+          Sign in
+        </button>
+        <button
+          type="button"
+          onClick={handleRegister}
+          disabled={registering}
+          className="px-6 py-3 text-base font-medium bg-white text-gray-900 rounded-lg ring-1 ring-inset ring-gray-300 cursor-pointer transition-colors hover:bg-gray-50 disabled:text-gray-400 disabled:cursor-not-allowed whitespace-nowrap"
+        >
+          Register
+        </button>
+      </form>
+      <p className="text-sm text-gray-500">
+        Every request needs a token. Register to get a new one; it is shown only once and kept in this browser.
+      </p>
+      {error && (
+        <div className="text-red-600 text-sm mt-2">
+          {error}
+        </div>
+      )}
+    </div>
+  )
+}
+
+export default TokenForm
\ No newline at end of file